@@ -1,15 +1,34 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 	"time"
 
+	"github.com/alicebob/miniredis/v2"
 	"github.com/orangeMangoDimz/go-social/internal/config"
+	usersEntity "github.com/orangeMangoDimz/go-social/internal/entities/users"
 	"github.com/orangeMangoDimz/go-social/internal/ratelimiter"
+	"github.com/orangeMangoDimz/go-social/internal/service/domain"
+	"github.com/orangeMangoDimz/go-social/internal/storage/postgres"
+	"github.com/redis/go-redis/v9"
 )
 
+// newTestRedis starts an in-process Redis that runs the rate limiter scripts
+func newTestRedis(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+
+	return mr, rdb
+}
+
 func TestRateLimiterMiddleware(t *testing.T) {
 	cfg := config.Config{
 		RateLimiter: ratelimiter.Config{
@@ -35,6 +54,7 @@ func TestRateLimiterMiddleware(t *testing.T) {
 		}
 
 		req.Header.Set("X-Forwarded-For", mockIP)
+		req.SetBasicAuth(cfg.Auth.Basic.User, cfg.Auth.Basic.Pass)
 
 		resp, err := client.Do(req)
 		if err != nil {
@@ -53,3 +73,247 @@ func TestRateLimiterMiddleware(t *testing.T) {
 		}
 	}
 }
+
+func TestRedisRateLimiter(t *testing.T) {
+	cfg := config.Config{
+		RateLimiter: ratelimiter.Config{
+			RequestPerTimeFrame: 20,
+			TimeFrame:           time.Second * 5,
+			Backend:             ratelimiter.BackendRedis,
+			Enabled:             true,
+		},
+		Addr: ":8080",
+	}
+
+	newInstance := func(rdb redis.Scripter) http.Handler {
+		app := newTestApplication(t, cfg)
+		rateLimiters, err := ratelimiter.NewRegistry(cfg.RateLimiter, ratelimiter.NewRedisFactory(rdb))
+		if err != nil {
+			t.Fatal(err)
+		}
+		app.RateLimiters = rateLimiters
+		return app.Mount("1.0.0")
+	}
+
+	healthRequest := func(t *testing.T) *http.Request {
+		req, err := http.NewRequest(http.MethodGet, "/v1/health", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req.Header.Set("X-Forwarded-For", "192.168.1.1")
+		req.SetBasicAuth(cfg.Auth.Basic.User, cfg.Auth.Basic.Pass)
+		return req
+	}
+
+	t.Run("Should share the limit across instances", func(t *testing.T) {
+		_, rdb := newTestRedis(t)
+		instances := []http.Handler{newInstance(rdb), newInstance(rdb)}
+
+		for i := 0; i < cfg.RateLimiter.RequestPerTimeFrame; i++ {
+			rr := executeRequest(healthRequest(t), instances[i%len(instances)])
+			checkResponseCode(t, http.StatusOK, rr.Code)
+		}
+
+		for _, mux := range instances {
+			rr := executeRequest(healthRequest(t), mux)
+			checkResponseCode(t, http.StatusTooManyRequests, rr.Code)
+		}
+	})
+
+	t.Run("Should fall back to the in-memory limiter when Redis is down", func(t *testing.T) {
+		mr, rdb := newTestRedis(t)
+		mr.SetError("LOADING Redis is loading the dataset in memory")
+		mux := newInstance(rdb)
+
+		for i := 0; i < cfg.RateLimiter.RequestPerTimeFrame; i++ {
+			rr := executeRequest(healthRequest(t), mux)
+			checkResponseCode(t, http.StatusOK, rr.Code)
+		}
+
+		rr := executeRequest(healthRequest(t), mux)
+		checkResponseCode(t, http.StatusTooManyRequests, rr.Code)
+	})
+}
+
+func TestRateLimiterAlgorithms(t *testing.T) {
+	algorithms := []string{
+		ratelimiter.AlgorithmFixedWindow,
+		ratelimiter.AlgorithmSlidingWindow,
+		ratelimiter.AlgorithmTokenBucket,
+	}
+
+	for _, backend := range []string{ratelimiter.BackendMemory, ratelimiter.BackendRedis} {
+		for _, algorithm := range algorithms {
+			t.Run(backend+"/"+algorithm, func(t *testing.T) {
+				cfg := config.Config{
+					RateLimiter: ratelimiter.Config{
+						RequestPerTimeFrame: 20,
+						TimeFrame:           time.Second * 5,
+						Algorithm:           algorithm,
+						Backend:             backend,
+						Enabled:             true,
+					},
+				}
+
+				app := newTestApplication(t, cfg)
+				if backend == ratelimiter.BackendRedis {
+					_, rdb := newTestRedis(t)
+					rateLimiters, err := ratelimiter.NewRegistry(cfg.RateLimiter, ratelimiter.NewRedisFactory(rdb))
+					if err != nil {
+						t.Fatal(err)
+					}
+					app.RateLimiters = rateLimiters
+				}
+				mux := app.Mount("1.0.0")
+
+				var rr *httptest.ResponseRecorder
+				for i := 0; i <= cfg.RateLimiter.RequestPerTimeFrame; i++ {
+					req, err := http.NewRequest(http.MethodGet, "/v1/health", nil)
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					req.Header.Set("X-Forwarded-For", "192.168.1.1")
+					req.SetBasicAuth(cfg.Auth.Basic.User, cfg.Auth.Basic.Pass)
+
+					rr = executeRequest(req, mux)
+					if i < cfg.RateLimiter.RequestPerTimeFrame {
+						checkResponseCode(t, http.StatusOK, rr.Code)
+					}
+				}
+
+				checkResponseCode(t, http.StatusTooManyRequests, rr.Code)
+
+				retryAfter, err := strconv.Atoi(rr.Header().Get("Retry-After"))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if retryAfter <= 0 {
+					t.Errorf("expected a positive Retry-After; got %d", retryAfter)
+				}
+
+				// A token is refilled every window/limit, long before the window ends
+				if algorithm == ratelimiter.AlgorithmTokenBucket && retryAfter > 1 {
+					t.Errorf("expected Retry-After of at most 1 second; got %d", retryAfter)
+				}
+			})
+		}
+	}
+}
+
+func TestRateLimiterUnknownAlgorithm(t *testing.T) {
+	_, err := ratelimiter.NewInMemoryLimiter(ratelimiter.Config{Algorithm: "leaky-bucket"})
+	if !errors.Is(err, ratelimiter.ErrUnknownAlgorithm) {
+		t.Errorf("expected ErrUnknownAlgorithm; got %v", err)
+	}
+}
+
+// roleUsers gives every user the same role
+type roleUsers struct {
+	postgres.MockUserStore
+	role usersEntity.Role
+}
+
+func (s *roleUsers) GetById(ctx context.Context, userID int64) (*usersEntity.User, error) {
+	return &usersEntity.User{ID: userID, Role: s.role}, nil
+}
+
+func TestRateLimitPolicies(t *testing.T) {
+	cfg := config.Config{
+		RateLimiter: ratelimiter.Config{
+			RequestPerTimeFrame: 20,
+			TimeFrame:           time.Second * 5,
+			Policies: map[string]ratelimiter.Policy{
+				ratelimiter.PolicyWrite: {
+					RequestPerTimeFrame:         2,
+					TimeFrame:                   time.Minute,
+					ElevatedRequestPerTimeFrame: 10,
+				},
+			},
+			Enabled: true,
+		},
+	}
+
+	app := newTestApplication(t, cfg)
+	mux := app.Mount("1.0.0")
+	testToken, err := app.Authenticator.GenerateToken(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newRequest := func(t *testing.T, method, path, ip string) *http.Request {
+		req, err := http.NewRequest(method, path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req.Header.Set("X-Forwarded-For", ip)
+		req.Header.Set("Authorization", "Bearer "+testToken)
+		return req
+	}
+
+	t.Run("Should send the RateLimit headers", func(t *testing.T) {
+		rr := executeRequest(newRequest(t, http.MethodGet, "/v1/users/1", "10.0.0.1"), mux)
+		checkResponseCode(t, http.StatusOK, rr.Code)
+
+		if got := rr.Header().Get("RateLimit-Limit"); got != "20" {
+			t.Errorf("expected RateLimit-Limit 20; got %q", got)
+		}
+		if got := rr.Header().Get("RateLimit-Remaining"); got != "19" {
+			t.Errorf("expected RateLimit-Remaining 19; got %q", got)
+		}
+		if got := rr.Header().Get("RateLimit-Reset"); got != "5" {
+			t.Errorf("expected RateLimit-Reset 5; got %q", got)
+		}
+	})
+
+	t.Run("Should key authenticated users on their ID and not their IP", func(t *testing.T) {
+		for i, ip := range []string{"10.0.0.1", "10.0.0.2"} {
+			rr := executeRequest(newRequest(t, http.MethodPut, "/v1/users/1/follow", ip), mux)
+			if rr.Code == http.StatusTooManyRequests {
+				t.Fatalf("request %d was rate limited too early", i)
+			}
+		}
+
+		rr := executeRequest(newRequest(t, http.MethodPut, "/v1/users/1/follow", "10.0.0.3"), mux)
+		checkResponseCode(t, http.StatusTooManyRequests, rr.Code)
+	})
+
+	t.Run("Should keep a separate budget per policy", func(t *testing.T) {
+		rr := executeRequest(newRequest(t, http.MethodGet, "/v1/users/1", "10.0.0.3"), mux)
+		checkResponseCode(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should give admins the elevated quota", func(t *testing.T) {
+		res := app.RateLimiters.Limiter(ratelimiter.PolicyWrite, true).Take("write:user:1")
+		if res.Limit != 10 {
+			t.Errorf("expected an elevated limit of 10; got %d", res.Limit)
+		}
+
+		res = app.RateLimiters.Limiter(ratelimiter.PolicyAuth, true).Take("auth:ip:10.0.0.1")
+		if res.Limit != cfg.RateLimiter.RequestPerTimeFrame {
+			t.Errorf("expected unknown policies to use the default limit; got %d", res.Limit)
+		}
+	})
+
+	t.Run("Should elevate users from the level of the admin role", func(t *testing.T) {
+		for _, tc := range []struct {
+			role usersEntity.Role
+			want string
+		}{
+			{usersEntity.Role{Name: "user", Level: 1}, "2"},
+			{usersEntity.Role{Name: "admin", Level: 2}, "10"},
+			{usersEntity.Role{Name: "owner", Level: 3}, "10"},
+		} {
+			app := newTestApplication(t, cfg)
+			app.Store.Users = &roleUsers{role: tc.role}
+			app.Services = *domain.NewService(app.Store, app.CacheStorage, app.Logger, app.Config)
+
+			rr := executeRequest(newRequest(t, http.MethodPut, "/v1/users/1/follow", "10.0.0.1"), app.Mount("1.0.0"))
+			if got := rr.Header().Get("RateLimit-Limit"); got != tc.want {
+				t.Errorf("%s: expected RateLimit-Limit %s; got %q", tc.role.Name, tc.want, got)
+			}
+		}
+	})
+}
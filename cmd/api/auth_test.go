@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/orangeMangoDimz/go-social/internal/config"
+	outboxEntity "github.com/orangeMangoDimz/go-social/internal/entities/outbox"
+	"github.com/orangeMangoDimz/go-social/internal/mailer"
+	"github.com/orangeMangoDimz/go-social/internal/service/domain"
+	"github.com/orangeMangoDimz/go-social/internal/storage/postgres"
+)
+
+func TestSessionRoutes(t *testing.T) {
+
+	app := newTestApplication(t, config.Config{})
+
+	mux := app.Mount("1.0.0")
+	testToken, err := app.Authenticator.GenerateToken(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Should require a refresh token", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "/v1/authentication/refresh", strings.NewReader(`{}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rr := executeRequest(req, mux)
+		checkResponseCode(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Should rotate a refresh token", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "/v1/authentication/refresh", strings.NewReader(`{"refresh_token":"token"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rr := executeRequest(req, mux)
+		checkResponseCode(t, http.StatusCreated, rr.Code)
+	})
+
+	t.Run("Should not allow unauthenticated logout", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "/v1/authentication/logout", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rr := executeRequest(req, mux)
+		checkResponseCode(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Should logout", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "/v1/authentication/logout", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req.Header.Set("Authorization", "Bearer "+testToken)
+
+		rr := executeRequest(req, mux)
+		checkResponseCode(t, http.StatusNoContent, rr.Code)
+	})
+}
+
+// queuedResets records the emails queued along with the password resets
+type queuedResets struct {
+	postgres.MockUserStore
+	emails []*outboxEntity.Email
+}
+
+func (s *queuedResets) CreatePasswordReset(ctx context.Context, userID int64, token string, resetExp time.Duration, email *outboxEntity.Email) error {
+	s.emails = append(s.emails, email)
+	return nil
+}
+
+func TestPasswordResetRoutes(t *testing.T) {
+
+	app := newTestApplication(t, config.Config{})
+
+	mux := app.Mount("1.0.0")
+
+	t.Run("Should accept a forgot password request", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "/v1/authentication/password/forgot", strings.NewReader(`{"email":"johndoe@example.com"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rr := executeRequest(req, mux)
+		checkResponseCode(t, http.StatusAccepted, rr.Code)
+	})
+
+	t.Run("Should queue the reset email without sending it", func(t *testing.T) {
+		app := newTestApplication(t, config.Config{})
+		// the email is only queued, a failing mailer must not affect the request
+		app.Mail = &mailer.MockClient{Err: errors.New("smtp is down")}
+		users := &queuedResets{}
+		app.Store.Users = users
+		app.Services = *domain.NewService(app.Store, app.CacheStorage, app.Logger, app.Config)
+
+		req, err := http.NewRequest(http.MethodPost, "/v1/authentication/password/forgot", strings.NewReader(`{"email":"johndoe@example.com"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rr := executeRequest(req, app.Mount("1.0.0"))
+		checkResponseCode(t, http.StatusAccepted, rr.Code)
+
+		if len(users.emails) != 1 || users.emails[0].Template != mailer.PasswordResetTemplate {
+			t.Errorf("expected the reset email to be queued, got %+v", users.emails)
+		}
+	})
+
+	t.Run("Should reject a short password", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "/v1/authentication/password/reset", strings.NewReader(`{"token":"token","password":"a"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rr := executeRequest(req, mux)
+		checkResponseCode(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Should reset the password", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "/v1/authentication/password/reset", strings.NewReader(`{"token":"token","password":"newpassword"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rr := executeRequest(req, mux)
+		checkResponseCode(t, http.StatusNoContent, rr.Code)
+	})
+}
+
+func TestResendActivation(t *testing.T) {
+
+	app := newTestApplication(t, config.Config{})
+	mux := app.Mount("1.0.0")
+
+	t.Run("Should accept a resend request", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "/v1/authentication/activation/resend", strings.NewReader(`{"email":"gopher@example.com"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rr := executeRequest(req, mux)
+		checkResponseCode(t, http.StatusAccepted, rr.Code)
+	})
+
+	t.Run("Should reject an invalid email", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "/v1/authentication/activation/resend", strings.NewReader(`{"email":"not-an-email"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rr := executeRequest(req, mux)
+		checkResponseCode(t, http.StatusBadRequest, rr.Code)
+	})
+}
@@ -0,0 +1,361 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/orangeMangoDimz/go-social/internal/config"
+	postsEntity "github.com/orangeMangoDimz/go-social/internal/entities/posts"
+	usersEntity "github.com/orangeMangoDimz/go-social/internal/entities/users"
+	httpserver "github.com/orangeMangoDimz/go-social/internal/server/http"
+	"github.com/orangeMangoDimz/go-social/internal/service/domain"
+	"github.com/orangeMangoDimz/go-social/internal/storage/cache"
+	sessionsCache "github.com/orangeMangoDimz/go-social/internal/storage/cache/sessions"
+	usersCache "github.com/orangeMangoDimz/go-social/internal/storage/cache/users"
+	"github.com/orangeMangoDimz/go-social/internal/storage/postgres"
+)
+
+// cachedUsers always hits with the user of the requested ID and records the
+// evictions
+type cachedUsers struct {
+	evicted []int64
+}
+
+func (c *cachedUsers) Get(ctx context.Context, userID int64) (*usersEntity.User, error) {
+	return &usersEntity.User{ID: userID}, nil
+}
+
+func (c *cachedUsers) Set(ctx context.Context, user *usersEntity.User) error {
+	return nil
+}
+
+func (c *cachedUsers) Delete(ctx context.Context, userID int64) error {
+	c.evicted = append(c.evicted, userID)
+	return nil
+}
+
+// countedUsers counts the users loaded from the database
+type countedUsers struct {
+	postgres.MockUserStore
+	loads atomic.Int64
+}
+
+func (s *countedUsers) GetById(ctx context.Context, userID int64) (*usersEntity.User, error) {
+	s.loads.Add(1)
+	return &usersEntity.User{ID: userID}, nil
+}
+
+// testSessionID is the session of the tokens of auth.TestAuthenticator
+const testSessionID = "00000000-0000-0000-0000-000000000042"
+
+// countedSessions counts the session states loaded from the database, a
+// session is active until it is revoked
+type countedSessions struct {
+	postgres.MockSessionStore
+	loads   atomic.Int64
+	revoked atomic.Bool
+}
+
+func (s *countedSessions) IsActive(ctx context.Context, sessionID string) (bool, error) {
+	s.loads.Add(1)
+	return !s.revoked.Load(), nil
+}
+
+func (s *countedSessions) Revoke(ctx context.Context, sessionID string) error {
+	s.revoked.Store(true)
+	return nil
+}
+
+// signedOutUsers revokes the test session when a password is reset
+type signedOutUsers struct {
+	countedUsers
+	sessions *countedSessions
+}
+
+func (s *signedOutUsers) ResetPassword(ctx context.Context, token string, password *usersEntity.Password) ([]string, error) {
+	s.sessions.revoked.Store(true)
+	return []string{testSessionID}, nil
+}
+
+// evictedPosts always misses and records the evictions
+type evictedPosts struct {
+	evicted []int64
+}
+
+func (c *evictedPosts) Get(ctx context.Context, postID int64) (*postsEntity.Post, error) {
+	return nil, nil
+}
+
+func (c *evictedPosts) Set(ctx context.Context, post *postsEntity.Post) error {
+	return nil
+}
+
+func (c *evictedPosts) Delete(ctx context.Context, postID int64) error {
+	c.evicted = append(c.evicted, postID)
+	return nil
+}
+
+// racedPosts holds a load of the post after it has read the title, so that
+// the post can be updated before the load returns
+type racedPosts struct {
+	postgres.MockPostStore
+	mu      sync.Mutex
+	title   string
+	loads   atomic.Int64
+	read    chan struct{}
+	release chan struct{}
+}
+
+func (s *racedPosts) GetById(ctx context.Context, postID int64) (*postsEntity.Post, error) {
+	s.mu.Lock()
+	post := &postsEntity.Post{ID: postID, Title: s.title}
+	s.mu.Unlock()
+
+	if s.loads.Add(1) == 1 {
+		close(s.read)
+		<-s.release
+	}
+	return post, nil
+}
+
+func (s *racedPosts) Update(ctx context.Context, post *postsEntity.Post) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.title = post.Title
+	return nil
+}
+
+// slowRoles takes a while to load a role and counts the loads
+type slowRoles struct {
+	loads atomic.Int64
+}
+
+func (s *slowRoles) GetByName(ctx context.Context, name string) (*usersEntity.Role, error) {
+	s.loads.Add(1)
+	time.Sleep(50 * time.Millisecond)
+	return &usersEntity.Role{Name: name, Level: 3}, nil
+}
+
+func TestCache(t *testing.T) {
+
+	// rebuild the services once the stores and caches have been replaced
+	rebuild := func(app *httpserver.Application) {
+		app.Services = *domain.NewService(app.Store, app.CacheStorage, app.Logger, app.Config)
+	}
+
+	send := func(t *testing.T, app *httpserver.Application, method, path, body string) int {
+		t.Helper()
+
+		testToken, err := app.Authenticator.GenerateToken(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req, err := http.NewRequest(method, path, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Authorization", "Bearer "+testToken)
+
+		return executeRequest(req, app.Mount("1.0.0")).Code
+	}
+
+	t.Run("Should authenticate with the cached user", func(t *testing.T) {
+		app := newTestApplication(t, config.Config{})
+		users := &countedUsers{}
+		app.Store.Users = users
+		app.CacheStorage.Users = &cachedUsers{}
+		rebuild(app)
+
+		checkResponseCode(t, http.StatusOK, send(t, app, http.MethodGet, "/v1/users/1", ""))
+
+		if n := users.loads.Load(); n != 0 {
+			t.Errorf("expected every user to come from the cache, got %d loads", n)
+		}
+	})
+
+	t.Run("Should evict a post once written", func(t *testing.T) {
+		for _, method := range []string{http.MethodPatch, http.MethodDelete} {
+			app := newTestApplication(t, config.Config{})
+			posts := &evictedPosts{}
+			app.CacheStorage.Posts = posts
+			rebuild(app)
+
+			code := send(t, app, method, "/v1/posts/5", `{"title": "Updated"}`)
+			if code >= http.StatusBadRequest {
+				t.Fatalf("%s: unexpected response code %d", method, code)
+			}
+
+			if want := []int64{5}; !reflect.DeepEqual(posts.evicted, want) {
+				t.Errorf("%s: expected %v to be evicted, got %v", method, want, posts.evicted)
+			}
+		}
+	})
+
+	t.Run("Should evict a user once activated", func(t *testing.T) {
+		app := newTestApplication(t, config.Config{})
+		users := &cachedUsers{}
+		app.CacheStorage.Users = users
+		rebuild(app)
+
+		checkResponseCode(t, http.StatusAccepted, send(t, app, http.MethodPut, "/v1/users/activate/token", ""))
+
+		if want := []int64{1}; !reflect.DeepEqual(users.evicted, want) {
+			t.Errorf("expected %v to be evicted, got %v", want, users.evicted)
+		}
+	})
+
+	t.Run("Should load a missing role once for concurrent requests", func(t *testing.T) {
+		app := newTestApplication(t, config.Config{})
+		roles := &slowRoles{}
+		app.Store.Roles = roles
+		rebuild(app)
+
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				role, err := app.Services.RoleService.GetByName(context.Background(), "moderator")
+				if err != nil || role.Name != "moderator" {
+					t.Errorf("expected the moderator role, got %+v and %v", role, err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if n := roles.loads.Load(); n != 1 {
+			t.Errorf("expected a single load, got %d", n)
+		}
+	})
+	// cacheSessions caches the users and sessions in Redis
+	cacheSessions := func(t *testing.T) (*httpserver.Application, *countedUsers, *countedSessions) {
+		t.Helper()
+
+		_, rdb := newTestRedis(t)
+		app := newTestApplication(t, config.Config{})
+		sessions := &countedSessions{}
+		users := &signedOutUsers{sessions: sessions}
+		app.Store.Users = users
+		app.Store.Sessions = sessions
+		app.CacheStorage = cache.NewRedisStorage(rdb, config.CacheConfig{})
+		rebuild(app)
+
+		return app, &users.countedUsers, sessions
+	}
+
+	t.Run("Should authenticate a warm request without a query", func(t *testing.T) {
+		app, users, sessions := cacheSessions(t)
+
+		checkResponseCode(t, http.StatusOK, send(t, app, http.MethodGet, "/v1/users/1", ""))
+		users.loads.Store(0)
+		sessions.loads.Store(0)
+
+		checkResponseCode(t, http.StatusOK, send(t, app, http.MethodGet, "/v1/users/1", ""))
+
+		if n := users.loads.Load() + sessions.loads.Load(); n != 0 {
+			t.Errorf("expected no database call, got %d", n)
+		}
+	})
+
+	t.Run("Should reject a session revoked while cached", func(t *testing.T) {
+		for _, revoke := range []struct{ method, path, body string }{
+			{http.MethodPost, "/v1/authentication/logout", ""},
+			{http.MethodPost, "/v1/authentication/password/reset", `{"token":"token","password":"newpassword"}`},
+		} {
+			app, _, _ := cacheSessions(t)
+
+			checkResponseCode(t, http.StatusOK, send(t, app, http.MethodGet, "/v1/users/1", ""))
+			checkResponseCode(t, http.StatusNoContent, send(t, app, revoke.method, revoke.path, revoke.body))
+			checkResponseCode(t, http.StatusUnauthorized, send(t, app, http.MethodGet, "/v1/users/1", ""))
+		}
+	})
+
+	t.Run("Should not cache the post read by a load racing an update", func(t *testing.T) {
+		_, rdb := newTestRedis(t)
+		app := newTestApplication(t, config.Config{})
+		posts := &racedPosts{title: "Old", read: make(chan struct{}), release: make(chan struct{})}
+		app.Store.Posts = posts
+		app.CacheStorage = cache.NewRedisStorage(rdb, config.CacheConfig{})
+		rebuild(app)
+		ctx := context.Background()
+
+		loaded := make(chan error)
+		go func() {
+			_, err := app.Services.PostService.GetById(ctx, 5)
+			loaded <- err
+		}()
+
+		// the load read the old title, the update is written and evicted
+		// before the load caches it
+		<-posts.read
+		if err := app.Services.PostService.Update(ctx, &postsEntity.Post{ID: 5, Title: "New"}); err != nil {
+			t.Fatal(err)
+		}
+		close(posts.release)
+		if err := <-loaded; err != nil {
+			t.Fatal(err)
+		}
+
+		post, err := app.Services.PostService.GetById(ctx, 5)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if post.Title != "New" {
+			t.Errorf("expected the updated title, got %q", post.Title)
+		}
+		if n := posts.loads.Load(); n != 2 {
+			t.Errorf("expected the post to be loaded again, got %d loads", n)
+		}
+	})
+
+	t.Run("Should not cache a user evicted while it was loaded", func(t *testing.T) {
+		_, rdb := newTestRedis(t)
+		users := &usersCache.UserStore{Rdb: rdb}
+		ctx := context.Background()
+
+		// a load read the user before the write and caches it after the eviction
+		if err := users.Delete(ctx, 1); err != nil {
+			t.Fatal(err)
+		}
+		if err := users.Set(ctx, &usersEntity.User{ID: 1, Username: "old"}); err != nil {
+			t.Fatal(err)
+		}
+
+		user, err := users.Get(ctx, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if user != nil {
+			t.Errorf("expected the user to stay evicted, got %+v", user)
+		}
+	})
+
+	t.Run("Should not cache a session as active once revoked", func(t *testing.T) {
+		_, rdb := newTestRedis(t)
+		sessions := &sessionsCache.SessionStore{Rdb: rdb}
+		ctx := context.Background()
+
+		// a load read the session before the revocation and caches it after
+		if err := sessions.Revoke(ctx, testSessionID); err != nil {
+			t.Fatal(err)
+		}
+		if err := sessions.Set(ctx, testSessionID, true); err != nil {
+			t.Fatal(err)
+		}
+
+		active, err := sessions.Get(ctx, testSessionID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if active == nil || *active {
+			t.Errorf("expected the session to stay revoked, got %v", active)
+		}
+	})
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/orangeMangoDimz/go-social/internal/config"
+)
+
+func TestCommentRoutes(t *testing.T) {
+
+	app := newTestApplication(t, config.Config{})
+
+	mux := app.Mount("1.0.0")
+	testToken, err := app.Authenticator.GenerateToken(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Should not allow unauthenticated request", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "/v1/posts/1/comments", strings.NewReader(`{"content":"nice"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rr := executeRequest(req, mux)
+		checkResponseCode(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Should create a comment", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "/v1/posts/1/comments", strings.NewReader(`{"content":"nice"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req.Header.Set("Authorization", "Bearer "+testToken)
+
+		rr := executeRequest(req, mux)
+		checkResponseCode(t, http.StatusCreated, rr.Code)
+	})
+
+	t.Run("Should reject an empty comment", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "/v1/posts/1/comments", strings.NewReader(`{"content":""}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req.Header.Set("Authorization", "Bearer "+testToken)
+
+		rr := executeRequest(req, mux)
+		checkResponseCode(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Should allow the author to edit a comment", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPatch, "/v1/posts/1/comments/1", strings.NewReader(`{"content":"edited"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req.Header.Set("Authorization", "Bearer "+testToken)
+
+		rr := executeRequest(req, mux)
+		checkResponseCode(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should not find a comment of another post", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodDelete, "/v1/posts/2/comments/1", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req.Header.Set("Authorization", "Bearer "+testToken)
+
+		rr := executeRequest(req, mux)
+		checkResponseCode(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestCommentReplies(t *testing.T) {
+
+	app := newTestApplication(t, config.Config{
+		Comments: config.CommentsConfig{
+			MaxDepth: 1,
+		},
+	})
+
+	mux := app.Mount("1.0.0")
+	testToken, err := app.Authenticator.GenerateToken(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Should reply to a comment", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "/v1/posts/1/comments", strings.NewReader(`{"content":"agreed","parent_id":1}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req.Header.Set("Authorization", "Bearer "+testToken)
+
+		rr := executeRequest(req, mux)
+		checkResponseCode(t, http.StatusCreated, rr.Code)
+
+		if !strings.Contains(rr.Body.String(), `"depth":1`) {
+			t.Errorf("Expected the reply to be one level deep, got %s", rr.Body.String())
+		}
+	})
+
+	t.Run("Should not reply to a comment of another post", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "/v1/posts/2/comments", strings.NewReader(`{"content":"agreed","parent_id":1}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req.Header.Set("Authorization", "Bearer "+testToken)
+
+		rr := executeRequest(req, mux)
+		checkResponseCode(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Should list the top-level comments page by page", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "/v1/posts/1/comments?limit=5", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req.Header.Set("Authorization", "Bearer "+testToken)
+
+		rr := executeRequest(req, mux)
+		checkResponseCode(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should list the replies to a comment", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "/v1/posts/1/comments/1/replies", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req.Header.Set("Authorization", "Bearer "+testToken)
+
+		rr := executeRequest(req, mux)
+		checkResponseCode(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should not list the replies to a comment of another post", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "/v1/posts/2/comments/1/replies", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req.Header.Set("Authorization", "Bearer "+testToken)
+
+		rr := executeRequest(req, mux)
+		checkResponseCode(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("Should not nest replies deeper than allowed", func(t *testing.T) {
+		app := newTestApplication(t, config.Config{})
+		mux := app.Mount("1.0.0")
+
+		req, err := http.NewRequest(http.MethodPost, "/v1/posts/1/comments", strings.NewReader(`{"content":"agreed","parent_id":1}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req.Header.Set("Authorization", "Bearer "+testToken)
+
+		rr := executeRequest(req, mux)
+		checkResponseCode(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestCommentCORS(t *testing.T) {
+	t.Setenv("CORS_ALLOWED_ORIGIN", "http://localhost:3000")
+	mux := newTestApplication(t, config.Config{}).Mount("1.0.0")
+
+	t.Run("Should allow browsers to edit a comment", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodOptions, "/v1/posts/1/comments/1", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req.Header.Set("Origin", "http://localhost:3000")
+		req.Header.Set("Access-Control-Request-Method", http.MethodPatch)
+
+		rr := executeRequest(req, mux)
+		if got := rr.Header().Get("Access-Control-Allow-Methods"); got != http.MethodPatch {
+			t.Errorf("Expected the preflight to allow PATCH, got %q", got)
+		}
+	})
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/orangeMangoDimz/go-social/internal/config"
+	"github.com/orangeMangoDimz/go-social/internal/logging"
+	"github.com/orangeMangoDimz/go-social/internal/server/http/protocol"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestErrorResponses(t *testing.T) {
+
+	app := newTestApplication(t, config.Config{})
+	mux := app.Mount("1.0.0")
+
+	decode := func(t *testing.T, body string) *protocol.Error {
+		t.Helper()
+
+		var res protocol.ErrorResponse
+		if err := json.NewDecoder(strings.NewReader(body)).Decode(&res); err != nil {
+			t.Fatal(err)
+		}
+		if res.Error == nil {
+			t.Fatalf("Expected an error envelope, got %s", body)
+		}
+		return res.Error
+	}
+
+	t.Run("Should report every invalid field under its JSON name", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "/v1/authentication/user", strings.NewReader(`{"username":"gopher","email":"not-an-email","password":"ab"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rr := executeRequest(req, mux)
+		checkResponseCode(t, http.StatusBadRequest, rr.Code)
+
+		apiErr := decode(t, rr.Body.String())
+		if apiErr.Code != protocol.CodeValidationFailed {
+			t.Errorf("Expected code %q, got %q", protocol.CodeValidationFailed, apiErr.Code)
+		}
+		if apiErr.RequestID == "" {
+			t.Error("Expected the request ID")
+		}
+
+		rules := map[string]string{}
+		for _, f := range apiErr.Fields {
+			rules[f.Field] = f.Rule
+		}
+		if rules["email"] != "email" || rules["password"] != "min" || len(rules) != 2 {
+			t.Errorf("Expected email and password to be rejected, got %+v", apiErr.Fields)
+		}
+	})
+
+	t.Run("Should not leak decoder internals for malformed JSON", func(t *testing.T) {
+		cases := map[string]string{
+			`{"email":`:                    "the request body is not valid JSON",
+			`{"email":42}`:                 `field "email" must be of type string`,
+			`{"email":"a@b.co","admin":1}`: `unknown field "admin"`,
+		}
+
+		for body, message := range cases {
+			req, err := http.NewRequest(http.MethodPost, "/v1/authentication/activation/resend", strings.NewReader(body))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rr := executeRequest(req, mux)
+			checkResponseCode(t, http.StatusBadRequest, rr.Code)
+
+			apiErr := decode(t, rr.Body.String())
+			if apiErr.Code != protocol.CodeMalformedJSON || apiErr.Message != message {
+				t.Errorf("%s: expected %q, got %q %q", body, message, apiErr.Code, apiErr.Message)
+			}
+		}
+	})
+
+	t.Run("Should log the cause of a 5xx and hide it from the client", func(t *testing.T) {
+		core, logs := observer.New(zapcore.ErrorLevel)
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/users/1", nil)
+		req = req.WithContext(logging.WithLogger(req.Context(), zap.New(core).Sugar()))
+		rr := httptest.NewRecorder()
+
+		protocol.InternalServerError(rr, req, errors.New("connection refused"))
+		checkResponseCode(t, http.StatusInternalServerError, rr.Code)
+
+		apiErr := decode(t, rr.Body.String())
+		if apiErr.Code != protocol.CodeInternal || strings.Contains(apiErr.Message, "connection refused") {
+			t.Errorf("Expected a generic internal error, got %+v", apiErr)
+		}
+
+		entries := logs.FilterField(zap.Error(errors.New("connection refused"))).All()
+		if len(entries) != 1 {
+			t.Errorf("Expected the cause to be logged once, got %d", len(entries))
+		}
+	})
+	t.Run("Should send RFC 7807 documents to clients asking for them", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "/v1/authentication/user", strings.NewReader(`{"username":"gopher","email":"not-an-email","password":"secret123"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Accept", "application/json;q=0.9, application/problem+json")
+
+		rr := executeRequest(req, mux)
+		checkResponseCode(t, http.StatusBadRequest, rr.Code)
+
+		if ct := rr.Header().Get("Content-Type"); ct != protocol.ProblemContentType {
+			t.Fatalf("Expected %q, got %q", protocol.ProblemContentType, ct)
+		}
+
+		var problem protocol.Problem
+		if err := json.NewDecoder(rr.Body).Decode(&problem); err != nil {
+			t.Fatal(err)
+		}
+		if problem.Type != protocol.ProblemTypeBase+protocol.CodeValidationFailed ||
+			problem.Title != "Bad Request" ||
+			problem.Status != http.StatusBadRequest ||
+			problem.Instance != "/v1/authentication/user" ||
+			problem.RequestID == "" ||
+			len(problem.Errors) != 1 || problem.Errors[0].Field != "email" {
+			t.Errorf("Unexpected problem document %+v", problem)
+		}
+	})
+
+	t.Run("Should keep the envelope for wildcards and plain JSON", func(t *testing.T) {
+		for _, accept := range []string{"", "*/*", "application/json", "application/json, application/problem+json;q=0.5"} {
+			req, err := http.NewRequest(http.MethodGet, "/v1/users/1", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Accept", accept)
+
+			rr := executeRequest(req, mux)
+			checkResponseCode(t, http.StatusUnauthorized, rr.Code)
+
+			if ct := rr.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("Accept %q: expected the JSON envelope, got %q", accept, ct)
+			}
+			if apiErr := decode(t, rr.Body.String()); apiErr.Code != protocol.CodeUnauthorized {
+				t.Errorf("Accept %q: expected code %q, got %q", accept, protocol.CodeUnauthorized, apiErr.Code)
+			}
+		}
+	})
+}
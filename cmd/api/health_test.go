@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/orangeMangoDimz/go-social/cmd/migrate/migrations"
+	"github.com/orangeMangoDimz/go-social/internal/config"
+	"github.com/orangeMangoDimz/go-social/internal/health"
+)
+
+func TestHealthProbes(t *testing.T) {
+
+	readiness := func(t *testing.T, checks []health.Check) (int, health.Report) {
+		t.Helper()
+
+		app := newTestApplication(t, config.Config{})
+		app.HealthChecks = checks
+		mux := app.Mount("1.0.0")
+
+		req, err := http.NewRequest(http.MethodGet, "/v1/health/ready", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rr := executeRequest(req, mux)
+
+		var body struct {
+			Data health.Report `json:"data"`
+		}
+		if err := json.NewDecoder(rr.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		return rr.Code, body.Data
+	}
+
+	up := health.Check{Name: "postgres", Timeout: time.Second, Fn: func(ctx context.Context) error { return nil }}
+
+	t.Run("Should be alive without credentials", func(t *testing.T) {
+		mux := newTestApplication(t, config.Config{}).Mount("1.0.0")
+
+		req, err := http.NewRequest(http.MethodGet, "/v1/health/live", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rr := executeRequest(req, mux)
+		checkResponseCode(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should be ready when every dependency is up", func(t *testing.T) {
+		code, report := readiness(t, []health.Check{up})
+		checkResponseCode(t, http.StatusOK, code)
+
+		if report.Status != health.StatusReady || report.Checks["postgres"].Status != health.StatusUp {
+			t.Errorf("Expected a ready report, got %+v", report)
+		}
+	})
+
+	t.Run("Should report each failing dependency", func(t *testing.T) {
+		down := health.Check{Name: "redis", Timeout: time.Second, Fn: func(ctx context.Context) error {
+			return errors.New("connection refused")
+		}}
+		slow := health.Check{Name: "migrations", Timeout: 10 * time.Millisecond, Fn: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}}
+
+		code, report := readiness(t, []health.Check{up, down, slow})
+		checkResponseCode(t, http.StatusServiceUnavailable, code)
+
+		want := map[string]string{
+			"postgres":   health.StatusUp,
+			"redis":      health.StatusDown,
+			"migrations": health.StatusTimeout,
+		}
+		for name, status := range want {
+			if got := report.Checks[name].Status; got != status {
+				t.Errorf("Expected %s to be %s, got %s", name, status, got)
+			}
+		}
+	})
+}
+
+func TestLatestMigrationVersion(t *testing.T) {
+	version, err := migrations.LatestVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if version == 0 {
+		t.Error("Expected the embedded migrations to have a version")
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/orangeMangoDimz/go-social/internal/config"
+	usersEntity "github.com/orangeMangoDimz/go-social/internal/entities/users"
+	"github.com/orangeMangoDimz/go-social/internal/service/domain"
+	"github.com/orangeMangoDimz/go-social/internal/storage/postgres"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// storedUser loads the authenticated user with its ID set
+type storedUser struct {
+	postgres.MockUserStore
+}
+
+func (s *storedUser) GetById(ctx context.Context, userID int64) (*usersEntity.User, error) {
+	return &usersEntity.User{ID: userID}, nil
+}
+
+func TestAccessLog(t *testing.T) {
+
+	core, logs := observer.New(zapcore.DebugLevel)
+	app := newTestApplication(t, config.Config{})
+	app.Logger = zap.New(core).Sugar()
+	app.Store.Users = &storedUser{}
+	app.Services = *domain.NewService(app.Store, app.CacheStorage, app.Logger, app.Config)
+	mux := app.Mount("1.0.0")
+
+	testToken, err := app.Authenticator.GenerateToken(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	observe := func(t *testing.T, req *http.Request) observer.LoggedEntry {
+		t.Helper()
+
+		logs.TakeAll()
+
+		executeRequest(req, mux)
+
+		entries := logs.FilterMessage("request served").All()
+		if len(entries) != 1 {
+			t.Fatalf("Expected 1 access log line, got %d", len(entries))
+		}
+		return entries[0]
+	}
+
+	t.Run("Should log the route, request ID and authenticated user", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "/v1/users/1", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Authorization", "Bearer "+testToken)
+
+		fields := observe(t, req).ContextMap()
+
+		if fields["route"] != "/v1/users/{userID}" {
+			t.Errorf("Expected the route pattern, got %v", fields["route"])
+		}
+		if fields["status"] != int64(http.StatusOK) {
+			t.Errorf("Expected status 200, got %v", fields["status"])
+		}
+		if fields["request_id"] == "" || fields["request_id"] == nil {
+			t.Error("Expected a request ID")
+		}
+		if fields["user_id"] != int64(42) {
+			t.Errorf("Expected the authenticated user, got %v", fields["user_id"])
+		}
+
+		headers := fields["headers"].(map[string]string)
+		if headers["Authorization"] != "[REDACTED]" {
+			t.Errorf("Expected the authorization header to be redacted, got %v", headers["Authorization"])
+		}
+	})
+
+	t.Run("Should redact credentials in the body and the path", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "/v1/authentication/token", strings.NewReader(`{"email":"gopher@example.com","password":"secret123"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		fields := observe(t, req).ContextMap()
+
+		body := fields["body"].(map[string]any)
+		if body["password"] != "[REDACTED]" || body["email"] != "gopher@example.com" {
+			t.Errorf("Expected only the password to be redacted, got %v", body)
+		}
+
+		req, err = http.NewRequest(http.MethodPut, "/v1/users/activate/5f0c0e5e-token", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		fields = observe(t, req).ContextMap()
+		if path := fields["path"].(string); strings.Contains(path, "5f0c0e5e-token") {
+			t.Errorf("Expected the activation token to be redacted, got %q", path)
+		}
+	})
+}
@@ -0,0 +1,317 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/orangeMangoDimz/go-social/internal/config"
+	"github.com/orangeMangoDimz/go-social/internal/mailer"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// smtpSink is a minimal SMTP server that accepts every message, in the
+// spirit of MailHog.
+type smtpSink struct {
+	listener net.Listener
+	messages chan string
+}
+
+func newSMTPSink(t *testing.T) *smtpSink {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sink := &smtpSink{listener: listener, messages: make(chan string, 1)}
+	go sink.serve()
+	t.Cleanup(func() { listener.Close() })
+
+	return sink
+}
+
+func (s *smtpSink) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *smtpSink) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *smtpSink) handle(conn net.Conn) {
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+
+	reply("220 sink ready")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		cmd := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			reply("250 sink")
+		case cmd == "DATA":
+			reply("354 go ahead")
+			var data strings.Builder
+			for {
+				line, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if line == ".\r\n" {
+					break
+				}
+				data.WriteString(line)
+			}
+			s.messages <- data.String()
+			reply("250 queued")
+		case cmd == "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("250 ok")
+		}
+	}
+}
+
+func TestSMTPMailer(t *testing.T) {
+
+	sink := newSMTPSink(t)
+
+	client, err := mailer.NewClient(config.MailConfig{
+		Provider:  mailer.ProviderSMTP,
+		FromEmail: "hello@example.com",
+		SMTP: config.SMTPConfig{
+			Host:    "127.0.0.1",
+			Port:    sink.port(),
+			TLSMode: mailer.TLSModeNone,
+		},
+	}, zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vars := map[string]string{"Username": "gopher", "ActivationURL": "http://localhost:3000/confirm/token"}
+	status, err := client.Send(context.Background(), mailer.UserWelcomeTemplate, mailer.DefaultLocale, "gopher", "gopher@example.com", vars, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if status != 200 {
+		t.Errorf("Expected status 200, got %d", status)
+	}
+
+	message := <-sink.messages
+	for _, want := range []string{"Finish Registration with GopherSocial", "gopher@example.com"} {
+		if !strings.Contains(message, want) {
+			t.Errorf("Expected the message to contain %q, got %s", want, message)
+		}
+	}
+}
+
+func TestMailerProviders(t *testing.T) {
+
+	logger := zap.NewNop().Sugar()
+
+	t.Run("Should start without any provider key in log mode", func(t *testing.T) {
+		client, err := mailer.NewClient(config.MailConfig{Provider: mailer.ProviderLog}, logger)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		vars := map[string]string{"Username": "gopher", "ActivationURL": "http://localhost:3000/confirm/token"}
+		if _, err := client.Send(context.Background(), mailer.UserWelcomeTemplate, mailer.DefaultLocale, "gopher", "gopher@example.com", vars, false); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("Should not log the links of the email in log mode", func(t *testing.T) {
+		core, logs := observer.New(zapcore.DebugLevel)
+		client, err := mailer.NewClient(config.MailConfig{Provider: mailer.ProviderLog}, zap.New(core).Sugar())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		vars := map[string]string{"Username": "gopher", "ActivationURL": "http://localhost:3000/confirm/secret-token"}
+		if _, err := client.Send(context.Background(), mailer.UserWelcomeTemplate, mailer.DefaultLocale, "gopher", "gopher@example.com", vars, false); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, entry := range logs.All() {
+			for key, value := range entry.ContextMap() {
+				if strings.Contains(fmt.Sprint(value), "secret-token") {
+					t.Errorf("Expected the token not to be logged, found it in %q", key)
+				}
+			}
+		}
+	})
+
+	t.Run("Should require a key for hosted providers", func(t *testing.T) {
+		for _, provider := range []string{mailer.ProviderSendGrid, mailer.ProviderMailTrap} {
+			if _, err := mailer.NewClient(config.MailConfig{Provider: provider}, logger); err == nil {
+				t.Errorf("Expected %s without a key to fail", provider)
+			}
+		}
+	})
+
+	t.Run("Should reject an unknown SMTP TLS mode", func(t *testing.T) {
+		_, err := mailer.NewClient(config.MailConfig{
+			Provider: mailer.ProviderSMTP,
+			SMTP:     config.SMTPConfig{Host: "localhost", Port: 1025, TLSMode: "ssl3"},
+		}, logger)
+		if err == nil {
+			t.Error("Expected an unknown TLS mode to fail")
+		}
+	})
+
+	t.Run("Should fail without a provider", func(t *testing.T) {
+		_, err := mailer.NewClient(config.MailConfig{}, logger)
+		if !errors.Is(err, mailer.ErrNoProvider) {
+			t.Errorf("Expected ErrNoProvider, got %v", err)
+		}
+	})
+
+	t.Run("Should reject an unknown provider", func(t *testing.T) {
+		_, err := mailer.NewClient(config.MailConfig{Provider: "pigeon"}, logger)
+		if !errors.Is(err, mailer.ErrUnknownProvider) {
+			t.Errorf("Expected ErrUnknownProvider, got %v", err)
+		}
+	})
+}
+
+// templateSampleData holds the variables of every embedded template. A new
+// template needs an entry here, otherwise TestEmailTemplates fails.
+var templateSampleData = map[string]map[string]any{
+	mailer.UserWelcomeTemplate: {
+		"Username":      "<gopher>",
+		"ActivationURL": "http://localhost:3000/confirm/token",
+	},
+	mailer.PasswordResetTemplate: {
+		"Username": "<gopher>",
+		"ResetURL": "http://localhost:3000/reset-password/token",
+	},
+}
+
+// renderEmbeddedTemplates renders every embedded template, in every locale,
+// with its sample data. The messages are keyed by template path.
+func renderEmbeddedTemplates(t *testing.T) map[string]*mailer.Message {
+	t.Helper()
+
+	messages := make(map[string]*mailer.Message)
+	err := fs.WalkDir(mailer.FS, "templates", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		name := path.Base(p)
+		locale := path.Base(path.Dir(p))
+
+		data, ok := templateSampleData[name]
+		if !ok {
+			t.Errorf("No sample data for template %s", p)
+			return nil
+		}
+
+		message, err := mailer.Render(name, locale, data)
+		if err != nil {
+			t.Errorf("Failed to render %s: %v", p, err)
+			return nil
+		}
+
+		if message.Subject == "" || message.HTML == "" || message.Text == "" {
+			t.Errorf("Expected %s to have a subject, an HTML and a plaintext body", p)
+		}
+
+		messages[p] = message
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return messages
+}
+
+func TestEmailTemplates(t *testing.T) {
+
+	messages := renderEmbeddedTemplates(t)
+
+	t.Run("Should render every template in every locale", func(t *testing.T) {
+		expected := len(templateSampleData) * len(mailer.Locales())
+		if len(messages) != expected {
+			t.Errorf("Expected %d rendered templates, got %d", expected, len(messages))
+		}
+	})
+
+	t.Run("Should escape the data in the HTML body only", func(t *testing.T) {
+		message := messages["templates/en/"+mailer.UserWelcomeTemplate]
+		if message == nil {
+			t.Fatal("Expected the English invitation to be rendered")
+		}
+
+		if strings.Contains(message.HTML, "<gopher>") || !strings.Contains(message.HTML, "&lt;gopher&gt;") {
+			t.Errorf("Expected the username to be escaped in the HTML body")
+		}
+
+		for _, want := range []string{"Hi <gopher>,", "http://localhost:3000/confirm/token"} {
+			if !strings.Contains(message.Text, want) {
+				t.Errorf("Expected the plaintext body to contain %q, got %s", want, message.Text)
+			}
+		}
+
+		if strings.Contains(message.Text, "<p>") {
+			t.Errorf("Expected the plaintext body to have no markup, got %s", message.Text)
+		}
+	})
+
+	t.Run("Should fall back to the default locale", func(t *testing.T) {
+		message, err := mailer.Render(mailer.UserWelcomeTemplate, "fr", templateSampleData[mailer.UserWelcomeTemplate])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if expected := messages["templates/en/"+mailer.UserWelcomeTemplate].Subject; message.Subject != expected {
+			t.Errorf("Expected subject %q, got %q", expected, message.Subject)
+		}
+	})
+
+	t.Run("Should match the user's language", func(t *testing.T) {
+		cases := []struct {
+			preferences []string
+			expected    string
+		}{
+			{[]string{"id"}, "id"},
+			{[]string{"", "id-ID,id;q=0.9,en;q=0.8"}, "id"},
+			{[]string{"en-GB", "id"}, "en"},
+			{[]string{"fr"}, mailer.DefaultLocale},
+			{nil, mailer.DefaultLocale},
+		}
+
+		for _, c := range cases {
+			if locale := mailer.MatchLocale(c.preferences...); locale != c.expected {
+				t.Errorf("Expected %v to match %q, got %q", c.preferences, c.expected, locale)
+			}
+		}
+	})
+}
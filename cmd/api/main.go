@@ -38,7 +38,7 @@ func main() {
 
 	db, app := httpserver.NewApp()
 	repositories := postgres.NewStore(db)
-	services := domain.NewService(repositories, app.Logger, app.Config)
+	services := domain.NewService(repositories, app.CacheStorage, app.Logger, app.Config)
 	app.Services = *services
 
 	mux := app.Mount(VERSION)
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/orangeMangoDimz/go-social/internal/config"
+	"github.com/orangeMangoDimz/go-social/internal/mailer"
+	"github.com/orangeMangoDimz/go-social/internal/metrics"
+	"github.com/orangeMangoDimz/go-social/internal/ratelimiter"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetricsEndpoint(t *testing.T) {
+
+	cfg := config.Config{
+		Auth: config.AuthConfig{
+			Basic: config.BasicConfig{User: "admin", Pass: "secret"},
+		},
+		RateLimiter: ratelimiter.Config{
+			RequestPerTimeFrame: 1,
+			TimeFrame:           time.Minute,
+			Enabled:             true,
+		},
+	}
+
+	app := newTestApplication(t, cfg)
+	mux := app.Mount("1.0.0")
+
+	scrape := func(t *testing.T) string {
+		req, err := http.NewRequest(http.MethodGet, "/metrics", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.SetBasicAuth("admin", "secret")
+
+		rr := executeRequest(req, mux)
+		checkResponseCode(t, http.StatusOK, rr.Code)
+
+		if got := rr.Header().Get("Content-Type"); !strings.HasPrefix(got, "text/plain; version=0.0.4") {
+			t.Errorf("Expected the Prometheus text format, got %q", got)
+		}
+		return rr.Body.String()
+	}
+
+	t.Run("Should require basic auth", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "/metrics", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rr := executeRequest(req, mux)
+		checkResponseCode(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Should count requests by route pattern and rate limit rejections", func(t *testing.T) {
+		for range 2 {
+			req, err := http.NewRequest(http.MethodGet, "/v1/health", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("X-Forwarded-For", "192.168.1.1")
+			req.SetBasicAuth("admin", "secret")
+			executeRequest(req, mux)
+		}
+
+		body := scrape(t)
+		for _, want := range []string{
+			`http_requests_total{method="GET",route="/v1/health",status="200"} 1`,
+			`http_requests_total{method="GET",route="/v1/health",status="429"} 1`,
+			`http_request_duration_seconds_bucket{method="GET",route="/v1/health",status="200",le="+Inf"} 1`,
+			`ratelimit_rejected_requests_total{policy="default"} 1`,
+		} {
+			if !strings.Contains(body, want) {
+				t.Errorf("Expected the metrics to contain %q, got:\n%s", want, body)
+			}
+		}
+	})
+}
+
+func TestMetricsDBStats(t *testing.T) {
+
+	// the pool is never used, so no connection is opened
+	db, err := sql.Open("postgres", "postgres://localhost/social")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(30)
+
+	req, err := http.NewRequest(http.MethodGet, "/metrics", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := executeRequest(req, metrics.New(db).Handler())
+	checkResponseCode(t, http.StatusOK, rr.Code)
+
+	want := `go_sql_max_open_connections{db_name="social"} 30`
+	if body := rr.Body.String(); !strings.Contains(body, want) {
+		t.Errorf("Expected the metrics to contain %q, got:\n%s", want, body)
+	}
+}
+
+func TestInstrumentedMailer(t *testing.T) {
+
+	m := metrics.New(nil)
+
+	mailer.NewInstrumentedClient(&mailer.MockClient{}, m.MailSends).Send(context.Background(), mailer.UserWelcomeTemplate, mailer.DefaultLocale, "gopher", "gopher@example.com", nil, true)
+	mailer.NewInstrumentedClient(&mailer.MockClient{Err: errors.New("smtp is down")}, m.MailSends).Send(context.Background(), mailer.UserWelcomeTemplate, mailer.DefaultLocale, "gopher", "gopher@example.com", nil, true)
+
+	if got := testutil.ToFloat64(m.MailSends.WithLabelValues(mailer.UserWelcomeTemplate, metrics.MailSent)); got != 1 {
+		t.Errorf("Expected 1 sent email, got %v", got)
+	}
+	if got := testutil.ToFloat64(m.MailSends.WithLabelValues(mailer.UserWelcomeTemplate, metrics.MailFailed)); got != 1 {
+		t.Errorf("Expected 1 failed email, got %v", got)
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/orangeMangoDimz/go-social/internal/config"
+	outboxEntity "github.com/orangeMangoDimz/go-social/internal/entities/outbox"
+	"github.com/orangeMangoDimz/go-social/internal/mailer"
+	"github.com/orangeMangoDimz/go-social/internal/storage/postgres"
+	"github.com/orangeMangoDimz/go-social/internal/worker"
+	"go.uber.org/zap"
+)
+
+func TestRegisterUser(t *testing.T) {
+
+	app := newTestApplication(t, config.Config{})
+	// the email is only queued, a failing mailer must not affect registration
+	app.Mail = &mailer.MockClient{Err: errors.New("smtp is down")}
+
+	mux := app.Mount("1.0.0")
+
+	t.Run("Should register without waiting for the email", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "/v1/authentication/user", strings.NewReader(`{"username":"gopher","email":"gopher@example.com","password":"secret123"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rr := executeRequest(req, mux)
+		checkResponseCode(t, http.StatusCreated, rr.Code)
+	})
+}
+
+func TestOutboxWorker(t *testing.T) {
+
+	cfg := config.Config{
+		Outbox: config.OutboxConfig{
+			BatchSize:   10,
+			MaxAttempts: 3,
+		},
+	}
+
+	newEmail := func(t *testing.T, id int64) *outboxEntity.Email {
+		email, err := outboxEntity.NewEmail(mailer.UserWelcomeTemplate, mailer.DefaultLocale, "gopher", "gopher@example.com", map[string]string{"Username": "gopher"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		email.ID = id
+		return email
+	}
+
+	t.Run("Should mark delivered emails as sent", func(t *testing.T) {
+		store := &postgres.MockOutboxStore{Emails: []*outboxEntity.Email{newEmail(t, 1), newEmail(t, 2)}}
+		w := worker.NewOutboxWorker(store, &mailer.MockClient{}, cfg, zap.NewNop().Sugar())
+
+		n, err := w.ProcessBatch(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if n != 2 {
+			t.Fatalf("Expected 2 emails to be claimed, got %d", n)
+		}
+
+		for _, e := range store.Emails {
+			if e.Status != outboxEntity.StatusSent {
+				t.Errorf("Expected email %d to be sent, got %q", e.ID, e.Status)
+			}
+		}
+	})
+
+	t.Run("Should retry and then dead-letter failing emails", func(t *testing.T) {
+		store := &postgres.MockOutboxStore{Emails: []*outboxEntity.Email{newEmail(t, 1)}}
+		w := worker.NewOutboxWorker(store, &mailer.MockClient{Err: errors.New("smtp is down")}, cfg, zap.NewNop().Sugar())
+
+		for attempt := 1; attempt <= cfg.Outbox.MaxAttempts; attempt++ {
+			if _, err := w.ProcessBatch(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+
+			email := store.Emails[0]
+			if email.Attempts != attempt {
+				t.Fatalf("Expected %d attempts, got %d", attempt, email.Attempts)
+			}
+
+			expected := outboxEntity.StatusPending
+			if attempt == cfg.Outbox.MaxAttempts {
+				expected = outboxEntity.StatusDead
+			}
+
+			if email.Status != expected {
+				t.Fatalf("Expected status %q after attempt %d, got %q", expected, attempt, email.Status)
+			}
+
+			if email.LastError != "smtp is down" {
+				t.Errorf("Expected the last error to be recorded, got %q", email.LastError)
+			}
+		}
+
+		n, err := w.ProcessBatch(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if n != 0 {
+			t.Errorf("Expected dead emails not to be claimed again, got %d", n)
+		}
+	})
+}
+
+func TestInvitationPurger(t *testing.T) {
+
+	cfg := config.Config{
+		Invitations: config.InvitationsConfig{
+			MaxUnactivatedAge: 7 * 24 * time.Hour,
+		},
+	}
+
+	p := worker.NewInvitationPurger(&postgres.MockUserStore{}, cfg, zap.NewNop().Sugar())
+	if err := p.Purge(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/orangeMangoDimz/go-social/internal/config"
+	"github.com/orangeMangoDimz/go-social/internal/storage/postgres/pagination"
+)
+
+func TestGetUserFeed(t *testing.T) {
+
+	app := newTestApplication(t, config.Config{})
+
+	mux := app.Mount("1.0.0")
+	testToken, err := app.Authenticator.GenerateToken(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Should accept offset pagination", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "/v1/posts/feed?limit=10&offset=20", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req.Header.Set("Authorization", "Bearer "+testToken)
+
+		rr := executeRequest(req, mux)
+		checkResponseCode(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should accept a cursor", func(t *testing.T) {
+		cursor := pagination.NewCursor("2025-01-01T12:00:00Z", 42, "desc").Encode()
+		req, err := http.NewRequest(http.MethodGet, "/v1/posts/feed?cursor="+cursor, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req.Header.Set("Authorization", "Bearer "+testToken)
+
+		rr := executeRequest(req, mux)
+		checkResponseCode(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should reject a cursor replayed with another sort", func(t *testing.T) {
+		cursor := pagination.NewCursor("2025-01-01T12:00:00Z", 42, "desc").Encode()
+		req, err := http.NewRequest(http.MethodGet, "/v1/posts/feed?sort=asc&cursor="+cursor, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req.Header.Set("Authorization", "Bearer "+testToken)
+
+		rr := executeRequest(req, mux)
+		checkResponseCode(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Should reject a malformed cursor", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "/v1/posts/feed?cursor=not-a-cursor", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req.Header.Set("Authorization", "Bearer "+testToken)
+
+		rr := executeRequest(req, mux)
+		checkResponseCode(t, http.StatusBadRequest, rr.Code)
+	})
+}
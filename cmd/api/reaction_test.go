@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/orangeMangoDimz/go-social/internal/config"
+)
+
+func TestReactionRoutes(t *testing.T) {
+
+	app := newTestApplication(t, config.Config{})
+
+	mux := app.Mount("1.0.0")
+	testToken, err := app.Authenticator.GenerateToken(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newRequest := func(t *testing.T, method, path string) *http.Request {
+		req, err := http.NewRequest(method, path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req.Header.Set("Authorization", "Bearer "+testToken)
+		return req
+	}
+
+	t.Run("Should not allow unauthenticated request", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPut, "/v1/posts/1/reactions/like", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rr := executeRequest(req, mux)
+		checkResponseCode(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Should react to a post", func(t *testing.T) {
+		rr := executeRequest(newRequest(t, http.MethodPut, "/v1/posts/1/reactions/like"), mux)
+		checkResponseCode(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should reject an unknown reaction kind", func(t *testing.T) {
+		rr := executeRequest(newRequest(t, http.MethodPut, "/v1/posts/1/reactions/meh"), mux)
+		checkResponseCode(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Should remove a reaction", func(t *testing.T) {
+		rr := executeRequest(newRequest(t, http.MethodDelete, "/v1/posts/1/reactions/like"), mux)
+		checkResponseCode(t, http.StatusNoContent, rr.Code)
+	})
+
+	t.Run("Should list who reacted", func(t *testing.T) {
+		rr := executeRequest(newRequest(t, http.MethodGet, "/v1/posts/1/reactions/like?limit=10"), mux)
+		checkResponseCode(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should include the reactions in the post", func(t *testing.T) {
+		rr := executeRequest(newRequest(t, http.MethodGet, "/v1/posts/1"), mux)
+		checkResponseCode(t, http.StatusOK, rr.Code)
+
+		var body struct {
+			Data struct {
+				Reactions *struct {
+					Counts          map[string]int64 `json:"counts"`
+					ViewerReactions []string         `json:"viewer_reactions"`
+				} `json:"reactions"`
+			} `json:"data"`
+		}
+		if err := json.NewDecoder(rr.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+
+		if body.Data.Reactions == nil {
+			t.Error("expected the post to include its reactions")
+		}
+	})
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/orangeMangoDimz/go-social/internal/config"
+	searchEntity "github.com/orangeMangoDimz/go-social/internal/entities/search"
+	"github.com/orangeMangoDimz/go-social/internal/server/http/protocol"
+)
+
+func TestSearchRoutes(t *testing.T) {
+
+	app := newTestApplication(t, config.Config{})
+
+	mux := app.Mount("1.0.0")
+	testToken, err := app.Authenticator.GenerateToken(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	search := func(t *testing.T, query string, authenticated bool) (int, []byte) {
+		t.Helper()
+
+		req, err := http.NewRequest(http.MethodGet, "/v1/search"+query, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if authenticated {
+			req.Header.Set("Authorization", "Bearer "+testToken)
+		}
+
+		rr := executeRequest(req, mux)
+		return rr.Code, rr.Body.Bytes()
+	}
+
+	t.Run("Should require authentication", func(t *testing.T) {
+		code, _ := search(t, "?q=golang", false)
+		checkResponseCode(t, http.StatusUnauthorized, code)
+	})
+
+	t.Run("Should search the requested type, posts by default", func(t *testing.T) {
+		cases := map[string]string{
+			"?q=golang":                   searchEntity.TypePosts,
+			"?q=golang&type=comments":     searchEntity.TypeComments,
+			"?q=gopher&type=users":        searchEntity.TypeUsers,
+			"?q=golang&limit=5&offset=10": searchEntity.TypePosts,
+		}
+
+		for query, want := range cases {
+			code, body := search(t, query, true)
+			checkResponseCode(t, http.StatusOK, code)
+
+			var res struct {
+				Data []searchEntity.Result `json:"data"`
+			}
+			if err := json.Unmarshal(body, &res); err != nil {
+				t.Fatal(err)
+			}
+			if len(res.Data) != 1 || res.Data[0].Type != want {
+				t.Errorf("%s: expected %s results, got %+v", query, want, res.Data)
+			}
+		}
+	})
+
+	t.Run("Should reject invalid queries", func(t *testing.T) {
+		cases := map[string]string{
+			"":                      protocol.CodeValidationFailed,
+			"?q=g":                  protocol.CodeValidationFailed,
+			"?q=golang&type=tags":   protocol.CodeValidationFailed,
+			"?q=golang&limit=50":    protocol.CodeValidationFailed,
+			"?q=golang&offset=5000": protocol.CodeValidationFailed,
+			"?q=golang&limit=ten":   protocol.CodeInvalidParameter,
+		}
+
+		for query, want := range cases {
+			code, body := search(t, query, true)
+			checkResponseCode(t, http.StatusBadRequest, code)
+
+			var res protocol.ErrorResponse
+			if err := json.Unmarshal(body, &res); err != nil {
+				t.Fatal(err)
+			}
+			if res.Error.Code != want {
+				t.Errorf("%q: expected code %q, got %q", query, want, res.Error.Code)
+			}
+		}
+	})
+}
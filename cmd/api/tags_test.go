@@ -0,0 +1,200 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/orangeMangoDimz/go-social/internal/config"
+	postsEntity "github.com/orangeMangoDimz/go-social/internal/entities/posts"
+	tagsEntity "github.com/orangeMangoDimz/go-social/internal/entities/tags"
+	"github.com/orangeMangoDimz/go-social/internal/server/http/protocol"
+)
+
+func TestPostTags(t *testing.T) {
+
+	app := newTestApplication(t, config.Config{})
+
+	mux := app.Mount("1.0.0")
+	testToken, err := app.Authenticator.GenerateToken(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	send := func(t *testing.T, method, path string, body any) (int, []byte) {
+		t.Helper()
+
+		b, err := json.Marshal(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req, err := http.NewRequest(method, path, bytes.NewReader(b))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Authorization", "Bearer "+testToken)
+
+		rr := executeRequest(req, mux)
+		return rr.Code, rr.Body.Bytes()
+	}
+
+	decodeTags := func(t *testing.T, body []byte) []string {
+		t.Helper()
+
+		var res struct {
+			Data postsEntity.Post `json:"data"`
+		}
+		if err := json.Unmarshal(body, &res); err != nil {
+			t.Fatal(err)
+		}
+		return res.Data.Tags
+	}
+
+	t.Run("Should normalize the tags of a new post", func(t *testing.T) {
+		code, body := send(t, http.MethodPost, "/v1/posts", map[string]any{
+			"title":   "Generics",
+			"content": "Type parameters in practice",
+			"tags":    []string{"#GoLang", " golang ", "Type_Params"},
+		})
+		checkResponseCode(t, http.StatusOK, code)
+
+		want := []string{"golang", "type_params"}
+		if got := decodeTags(t, body); !reflect.DeepEqual(got, want) {
+			t.Errorf("expected tags %v, got %v", want, got)
+		}
+	})
+
+	t.Run("Should normalize the tags of an updated post", func(t *testing.T) {
+		code, body := send(t, http.MethodPatch, "/v1/posts/1", map[string]any{
+			"tags": []string{"#Rust", "RUST"},
+		})
+		checkResponseCode(t, http.StatusOK, code)
+
+		want := []string{"rust"}
+		if got := decodeTags(t, body); !reflect.DeepEqual(got, want) {
+			t.Errorf("expected tags %v, got %v", want, got)
+		}
+	})
+
+	t.Run("Should reject invalid tags", func(t *testing.T) {
+		tooMany := make([]string, tagsEntity.MaxTags+1)
+		for i := range tooMany {
+			tooMany[i] = string(rune('a' + i))
+		}
+
+		cases := []struct {
+			method string
+			path   string
+			tags   []string
+			code   string
+		}{
+			{http.MethodPost, "/v1/posts", []string{"go lang"}, protocol.CodeInvalidTag},
+			{http.MethodPost, "/v1/posts", []string{"#"}, protocol.CodeInvalidTag},
+			{http.MethodPost, "/v1/posts", tooMany, protocol.CodeTooManyTags},
+			{http.MethodPatch, "/v1/posts/1", []string{"c++"}, protocol.CodeInvalidTag},
+		}
+
+		for _, c := range cases {
+			code, body := send(t, c.method, c.path, map[string]any{
+				"title":   "Tags",
+				"content": "Some content",
+				"tags":    c.tags,
+			})
+			checkResponseCode(t, http.StatusBadRequest, code)
+
+			var res protocol.ErrorResponse
+			if err := json.Unmarshal(body, &res); err != nil {
+				t.Fatal(err)
+			}
+			if res.Error.Code != c.code {
+				t.Errorf("%s %s %v: expected code %s, got %s", c.method, c.path, c.tags, c.code, res.Error.Code)
+			}
+		}
+	})
+}
+
+func TestTagRoutes(t *testing.T) {
+
+	app := newTestApplication(t, config.Config{})
+
+	mux := app.Mount("1.0.0")
+	testToken, err := app.Authenticator.GenerateToken(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	get := func(t *testing.T, path string) (int, []byte) {
+		t.Helper()
+
+		req, err := http.NewRequest(http.MethodGet, path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Authorization", "Bearer "+testToken)
+
+		rr := executeRequest(req, mux)
+		return rr.Code, rr.Body.Bytes()
+	}
+
+	t.Run("Should get the posts of a tag", func(t *testing.T) {
+		for _, path := range []string{
+			"/v1/tags/golang/posts",
+			"/v1/tags/%23GoLang/posts?limit=10&sort=asc",
+		} {
+			code, _ := get(t, path)
+			checkResponseCode(t, http.StatusOK, code)
+		}
+	})
+
+	t.Run("Should reject an invalid tag", func(t *testing.T) {
+		code, _ := get(t, "/v1/tags/c++/posts")
+		checkResponseCode(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("Should get the trending tags", func(t *testing.T) {
+		code, body := get(t, "/v1/tags/trending?limit=2")
+		checkResponseCode(t, http.StatusOK, code)
+
+		var res struct {
+			Data []tagsEntity.TrendingTag `json:"data"`
+		}
+		if err := json.Unmarshal(body, &res); err != nil {
+			t.Fatal(err)
+		}
+		if len(res.Data) != 2 || res.Data[0].Tag != "golang" {
+			t.Errorf("expected the 2 most used tags, got %+v", res.Data)
+		}
+	})
+
+	t.Run("Should reject an invalid limit", func(t *testing.T) {
+		for _, limit := range []string{"0", "21", "ten"} {
+			code, _ := get(t, "/v1/tags/trending?limit="+limit)
+			checkResponseCode(t, http.StatusBadRequest, code)
+		}
+	})
+}
+
+func TestCleanTags(t *testing.T) {
+
+	t.Run("Should drop the invalid tags instead of mangling them", func(t *testing.T) {
+		got := tagsEntity.Clean([]string{"c++", " #GoLang ", "golang", "café", "", "##go", "a b"})
+		if want := []string{"golang", "café"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Expected %v, got %v", want, got)
+		}
+	})
+
+	t.Run("Should keep the first tags up to the limit", func(t *testing.T) {
+		tags := []string{}
+		for i := range tagsEntity.MaxTags + 2 {
+			tags = append(tags, string(rune('a'+i)))
+		}
+
+		got := tagsEntity.Clean(tags)
+		if !reflect.DeepEqual(got, tags[:tagsEntity.MaxTags]) {
+			t.Errorf("Expected the first %d tags, got %v", tagsEntity.MaxTags, got)
+		}
+	})
+}
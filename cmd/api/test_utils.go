@@ -7,8 +7,11 @@ import (
 
 	"github.com/orangeMangoDimz/go-social/internal/auth"
 	"github.com/orangeMangoDimz/go-social/internal/config"
+	"github.com/orangeMangoDimz/go-social/internal/mailer"
+	"github.com/orangeMangoDimz/go-social/internal/metrics"
 	"github.com/orangeMangoDimz/go-social/internal/ratelimiter"
 	httpserver "github.com/orangeMangoDimz/go-social/internal/server/http"
+	"github.com/orangeMangoDimz/go-social/internal/service/domain"
 	"github.com/orangeMangoDimz/go-social/internal/storage/cache"
 	"github.com/orangeMangoDimz/go-social/internal/storage/postgres"
 	"go.uber.org/zap"
@@ -26,10 +29,10 @@ func newTestApplication(t *testing.T, cfg config.Config) *httpserver.Application
 	testAuth := &auth.TestAuthenticator{}
 
 	// Rate limiter
-	rateLimiter := ratelimiter.NewFixedWindowLimiter(
-		cfg.RateLimiter.RequestPerTimeFrame,
-		cfg.RateLimiter.TimeFrame,
-	)
+	rateLimiters, err := ratelimiter.NewRegistry(cfg.RateLimiter, ratelimiter.NewInMemoryLimiter)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	return &httpserver.Application{
 		Logger:        logger,
@@ -37,7 +40,10 @@ func newTestApplication(t *testing.T, cfg config.Config) *httpserver.Application
 		CacheStorage:  mockCacheStore,
 		Authenticator: testAuth,
 		Config:        cfg,
-		RateLimiter:   rateLimiter,
+		Mail:          &mailer.MockClient{},
+		RateLimiters:  rateLimiters,
+		Metrics:       metrics.New(nil),
+		Services:      *domain.NewService(mockStore, mockCacheStore, logger, cfg),
 	}
 }
 
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/orangeMangoDimz/go-social/internal/config"
+	"github.com/orangeMangoDimz/go-social/internal/service/domain"
+	timelinesCache "github.com/orangeMangoDimz/go-social/internal/storage/cache/timelines"
+	"github.com/orangeMangoDimz/go-social/internal/storage/postgres"
+)
+
+// recordingTimelines misses on every read and records the writes
+type recordingTimelines struct {
+	pushed map[int64][]int64
+	set    map[int64][]int64
+}
+
+func (c *recordingTimelines) Get(ctx context.Context, userID int64) ([]int64, error) {
+	return nil, nil
+}
+
+func (c *recordingTimelines) Set(ctx context.Context, userID int64, postIDs []int64) error {
+	c.set[userID] = postIDs
+	return nil
+}
+
+func (c *recordingTimelines) Push(ctx context.Context, postID int64, userIDs []int64, maxEntries int) error {
+	c.pushed[postID] = userIDs
+	return nil
+}
+
+// emptyTimelines follows nobody and counts the timelines built from Postgres
+type emptyTimelines struct {
+	postgres.MockTimelineStore
+	builds int
+}
+
+func (s *emptyTimelines) GetPostIDs(ctx context.Context, userID int64, maxFollowers, limit int) ([]int64, error) {
+	s.builds++
+	return []int64{}, nil
+}
+
+func TestTimelines(t *testing.T) {
+
+	newApp := func(t *testing.T, cfg config.TimelineConfig) (http.Handler, *recordingTimelines, string) {
+		t.Helper()
+
+		app := newTestApplication(t, config.Config{Timeline: cfg})
+		timelines := &recordingTimelines{pushed: map[int64][]int64{}, set: map[int64][]int64{}}
+		app.CacheStorage.Timelines = timelines
+		app.Services = *domain.NewService(app.Store, app.CacheStorage, app.Logger, app.Config)
+
+		testToken, err := app.Authenticator.GenerateToken(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return app.Mount("1.0.0"), timelines, testToken
+	}
+
+	send := func(t *testing.T, mux http.Handler, token, method, path, body string) int {
+		t.Helper()
+
+		req, err := http.NewRequest(method, path, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		return executeRequest(req, mux).Code
+	}
+
+	newPost := `{"title": "Timelines", "content": "Fan-out on write"}`
+	enabled := config.TimelineConfig{Enabled: true, MaxEntries: 800, FanoutMaxFollowers: 10}
+
+	t.Run("Should push a new post to the timelines of the followers", func(t *testing.T) {
+		mux, timelines, token := newApp(t, enabled)
+
+		checkResponseCode(t, http.StatusOK, send(t, mux, token, http.MethodPost, "/v1/posts", newPost))
+
+		if want := []int64{2, 3}; !reflect.DeepEqual(timelines.pushed[0], want) {
+			t.Errorf("expected the post to be pushed to %v, got %v", want, timelines.pushed)
+		}
+	})
+
+	t.Run("Should not fan out the posts of heavily followed users", func(t *testing.T) {
+		cfg := enabled
+		cfg.FanoutMaxFollowers = 1
+		mux, timelines, token := newApp(t, cfg)
+
+		checkResponseCode(t, http.StatusOK, send(t, mux, token, http.MethodPost, "/v1/posts", newPost))
+
+		if len(timelines.pushed) != 0 {
+			t.Errorf("expected no fan out, got %v", timelines.pushed)
+		}
+	})
+
+	t.Run("Should rebuild a missing timeline on read", func(t *testing.T) {
+		mux, timelines, token := newApp(t, enabled)
+
+		checkResponseCode(t, http.StatusOK, send(t, mux, token, http.MethodGet, "/v1/posts/feed", ""))
+
+		if want := []int64{1}; !reflect.DeepEqual(timelines.set[0], want) {
+			t.Errorf("expected the timeline to be rebuilt with %v, got %v", want, timelines.set)
+		}
+	})
+
+	t.Run("Should rebuild the timeline on follow and unfollow", func(t *testing.T) {
+		for _, path := range []string{"/v1/users/2/follow", "/v1/users/2/unfollow"} {
+			mux, timelines, token := newApp(t, enabled)
+
+			checkResponseCode(t, http.StatusNoContent, send(t, mux, token, http.MethodPut, path, ""))
+
+			if _, ok := timelines.set[0]; !ok {
+				t.Errorf("%s: expected the timeline to be rebuilt", path)
+			}
+		}
+	})
+
+	t.Run("Should compute feeds on read when disabled", func(t *testing.T) {
+		mux, timelines, token := newApp(t, config.TimelineConfig{})
+
+		checkResponseCode(t, http.StatusOK, send(t, mux, token, http.MethodPost, "/v1/posts", newPost))
+		checkResponseCode(t, http.StatusOK, send(t, mux, token, http.MethodGet, "/v1/posts/feed", ""))
+		checkResponseCode(t, http.StatusNoContent, send(t, mux, token, http.MethodPut, "/v1/users/2/follow", ""))
+
+		if len(timelines.pushed) != 0 || len(timelines.set) != 0 {
+			t.Errorf("expected the timelines to be left alone, got pushed %v and set %v", timelines.pushed, timelines.set)
+		}
+	})
+	t.Run("Should cache an empty timeline", func(t *testing.T) {
+		_, rdb := newTestRedis(t)
+
+		app := newTestApplication(t, config.Config{Timeline: enabled})
+		store := &emptyTimelines{}
+		app.Store.Timelines = store
+		app.CacheStorage.Timelines = &timelinesCache.TimelineStore{Rdb: rdb}
+		app.Services = *domain.NewService(app.Store, app.CacheStorage, app.Logger, app.Config)
+		mux := app.Mount("1.0.0")
+
+		token, err := app.Authenticator.GenerateToken(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for range 2 {
+			checkResponseCode(t, http.StatusOK, send(t, mux, token, http.MethodGet, "/v1/posts/feed", ""))
+		}
+
+		if store.builds != 1 {
+			t.Errorf("expected the empty timeline to be built once, got %d builds", store.builds)
+		}
+	})
+}
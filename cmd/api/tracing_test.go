@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/orangeMangoDimz/go-social/internal/config"
+	"github.com/orangeMangoDimz/go-social/internal/mailer"
+	"github.com/orangeMangoDimz/go-social/internal/metrics"
+	"github.com/orangeMangoDimz/go-social/internal/tracing"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// recordSpans installs a tracer provider keeping every span in memory for
+// the duration of the test.
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	if _, err := tracing.Setup(context.Background(), config.TracingConfig{Exporter: tracing.ExporterNone}, "1.0.0"); err != nil {
+		t.Fatal(err)
+	}
+
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	return recorder
+}
+
+func TestTracing(t *testing.T) {
+
+	t.Run("Should continue the caller's trace and name the span after the route", func(t *testing.T) {
+		recorder := recordSpans(t)
+
+		cfg := config.Config{
+			Auth: config.AuthConfig{
+				Basic: config.BasicConfig{User: "admin", Pass: "secret"},
+			},
+		}
+		mux := newTestApplication(t, cfg).Mount("1.0.0")
+
+		req, err := http.NewRequest(http.MethodGet, "/v1/health", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.SetBasicAuth("admin", "secret")
+		req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+		rr := executeRequest(req, mux)
+		checkResponseCode(t, http.StatusOK, rr.Code)
+
+		spans := recorder.Ended()
+		if len(spans) != 1 {
+			t.Fatalf("Expected 1 span, got %d", len(spans))
+		}
+
+		span := spans[0]
+		if span.Name() != "GET /v1/health" {
+			t.Errorf("Expected the span to be named after the route, got %q", span.Name())
+		}
+		if got := span.SpanContext().TraceID().String(); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+			t.Errorf("Expected the trace of the caller, got %s", got)
+		}
+		if got := span.Parent().SpanID().String(); got != "00f067aa0ba902b7" {
+			t.Errorf("Expected the span of the caller as parent, got %s", got)
+		}
+	})
+
+	t.Run("Should redact the activation token from the span path", func(t *testing.T) {
+		recorder := recordSpans(t)
+		mux := newTestApplication(t, config.Config{}).Mount("1.0.0")
+
+		req, err := http.NewRequest(http.MethodPut, "/v1/users/activate/5f0c0e5e-token", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		executeRequest(req, mux)
+
+		for _, span := range recorder.Ended() {
+			for _, attr := range span.Attributes() {
+				if strings.Contains(attr.Value.Emit(), "5f0c0e5e-token") {
+					t.Errorf("Expected the activation token to be redacted, found it in %s", attr.Key)
+				}
+			}
+		}
+	})
+
+	t.Run("Should trace mailer sends as children of the caller", func(t *testing.T) {
+		recorder := recordSpans(t)
+
+		ctx, parent := tracing.Start(context.Background(), "outbox.deliver")
+		client := mailer.NewInstrumentedClient(&mailer.MockClient{}, metrics.New(nil).MailSends)
+		if _, err := client.Send(ctx, mailer.UserWelcomeTemplate, mailer.DefaultLocale, "gopher", "gopher@example.com", nil, true); err != nil {
+			t.Fatal(err)
+		}
+		parent.End()
+
+		spans := recorder.Ended()
+		if len(spans) != 2 {
+			t.Fatalf("Expected 2 spans, got %d", len(spans))
+		}
+
+		send := spans[0]
+		if send.Name() != "mailer.Send" {
+			t.Errorf("Expected the mailer span to end first, got %q", send.Name())
+		}
+		if send.Parent().SpanID() != parent.SpanContext().SpanID() {
+			t.Errorf("Expected the mailer span to be a child of the caller")
+		}
+	})
+	t.Run("Should name pipeline spans after the batch, not its commands", func(t *testing.T) {
+		recorder := recordSpans(t)
+
+		_, rdb := newTestRedis(t)
+		rdb.AddHook(tracing.RedisHook{})
+
+		_, err := rdb.TxPipelined(context.Background(), func(pipe redis.Pipeliner) error {
+			for i := range 20 {
+				pipe.Set(context.Background(), fmt.Sprintf("key:%d", i), i, 0)
+				pipe.Incr(context.Background(), "count")
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// the connection handshake is traced too, the batch is the last span
+		spans := recorder.Ended()
+		span := spans[len(spans)-1]
+		if span.Name() != "redis pipeline" {
+			t.Errorf("Expected the span to be named redis pipeline, got %q", span.Name())
+		}
+
+		attrs := map[string]attribute.Value{}
+		for _, attr := range span.Attributes() {
+			attrs[string(attr.Key)] = attr.Value
+		}
+		if got, want := attrs["db.redis.commands"].AsStringSlice(), []string{"multi", "set", "incr", "exec"}; !slices.Equal(got, want) {
+			t.Errorf("Expected the distinct commands %v, got %v", want, got)
+		}
+		if got := attrs["db.operation.batch.size"].AsInt64(); got != 42 {
+			t.Errorf("Expected a batch of 42 commands, got %d", got)
+		}
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/orangeMangoDimz/go-social/internal/config"
+	"github.com/orangeMangoDimz/go-social/internal/storage/postgres/pagination"
 )
 
 func TestGetUser(t *testing.T) {
@@ -40,3 +41,101 @@ func TestGetUser(t *testing.T) {
 		checkResponseCode(t, http.StatusOK, rr.Code)
 	})
 }
+
+func TestGetUserConnections(t *testing.T) {
+
+	app := newTestApplication(t, config.Config{})
+
+	mux := app.Mount("1.0.0")
+	testToken, err := app.Authenticator.GenerateToken(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{"/v1/users/1/followers", "/v1/users/1/following"} {
+		t.Run("Should list "+path, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, path+"?limit=10", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req.Header.Set("Authorization", "Bearer "+testToken)
+
+			rr := executeRequest(req, mux)
+			checkResponseCode(t, http.StatusOK, rr.Code)
+		})
+	}
+
+	t.Run("Should reject an out of range limit", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "/v1/users/1/followers?limit=100", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req.Header.Set("Authorization", "Bearer "+testToken)
+
+		rr := executeRequest(req, mux)
+		checkResponseCode(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestGetUserPosts(t *testing.T) {
+
+	app := newTestApplication(t, config.Config{})
+
+	mux := app.Mount("1.0.0")
+	testToken, err := app.Authenticator.GenerateToken(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Should not allow unauthenticated request", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "/v1/users/1/posts", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rr := executeRequest(req, mux)
+		checkResponseCode(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Should accept the feed filters", func(t *testing.T) {
+		cursor := pagination.NewCursor("2025-01-01T12:00:00Z", 42, "desc").Encode()
+		for _, query := range []string{
+			"",
+			"?limit=10&offset=20&sort=asc",
+			"?tags=golang,%23Rust&search=generics",
+			"?since=2024-01-01%2000:00:00&until=2024-12-31%2023:59:59",
+			"?cursor=" + cursor,
+		} {
+			req, err := http.NewRequest(http.MethodGet, "/v1/users/1/posts"+query, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req.Header.Set("Authorization", "Bearer "+testToken)
+
+			rr := executeRequest(req, mux)
+			checkResponseCode(t, http.StatusOK, rr.Code)
+		}
+	})
+
+	t.Run("Should reject invalid filters", func(t *testing.T) {
+		for _, path := range []string{
+			"/v1/users/abc/posts",
+			"/v1/users/1/posts?limit=100",
+			"/v1/users/1/posts?cursor=not-a-cursor",
+			"/v1/users/1/posts?tags=c%2B%2B",
+		} {
+			req, err := http.NewRequest(http.MethodGet, path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req.Header.Set("Authorization", "Bearer "+testToken)
+
+			rr := executeRequest(req, mux)
+			checkResponseCode(t, http.StatusBadRequest, rr.Code)
+		}
+	})
+}
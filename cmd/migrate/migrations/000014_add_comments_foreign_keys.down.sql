@@ -0,0 +1,4 @@
+ALTER TABLE comments DROP COLUMN IF EXISTS updated_at;
+
+ALTER TABLE comments DROP CONSTRAINT IF EXISTS fk_comments_user;
+ALTER TABLE comments DROP CONSTRAINT IF EXISTS fk_comments_post;
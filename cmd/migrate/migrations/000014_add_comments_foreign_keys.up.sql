@@ -0,0 +1,24 @@
+-- Turn comments.post_id and comments.user_id into plain references
+-- They were created as bigserial, which attached a sequence default to each column
+
+-- Step 1: Drop the sequence defaults and the sequences owned by the columns
+ALTER TABLE comments ALTER COLUMN post_id DROP DEFAULT;
+ALTER TABLE comments ALTER COLUMN user_id DROP DEFAULT;
+DROP SEQUENCE IF EXISTS comments_post_id_seq;
+DROP SEQUENCE IF EXISTS comments_user_id_seq;
+
+-- Step 2: Remove comments that point to posts or users that no longer exist
+DELETE FROM comments c
+WHERE NOT EXISTS (SELECT 1 FROM posts p WHERE p.id = c.post_id)
+   OR NOT EXISTS (SELECT 1 FROM users u WHERE u.id = c.user_id);
+
+-- Step 3: Add the foreign keys, comments go away with their post or author
+ALTER TABLE comments
+ADD CONSTRAINT fk_comments_post FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE;
+
+ALTER TABLE comments
+ADD CONSTRAINT fk_comments_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
+-- Step 4: Track comment edits
+ALTER TABLE comments
+ADD COLUMN updated_at timestamp(0) with time zone NOT NULL DEFAULT NOW();
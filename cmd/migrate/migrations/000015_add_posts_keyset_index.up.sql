@@ -0,0 +1,2 @@
+-- Supports keyset pagination of the feed on (created_at, id)
+CREATE INDEX IF NOT EXISTS idx_posts_created_at_id ON posts (created_at DESC, id DESC);
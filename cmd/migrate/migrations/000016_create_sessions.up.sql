@@ -0,0 +1,23 @@
+-- A session groups every refresh token issued from one login (the token family)
+CREATE TABLE IF NOT EXISTS user_sessions (
+      id uuid PRIMARY KEY,
+      user_id bigint NOT NULL,
+      created_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+      revoked_at timestamp(0) with time zone,
+
+      FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+-- Refresh tokens are stored hashed, rotated_at is set once a token has been exchanged
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+      token bytea PRIMARY KEY,
+      session_id uuid NOT NULL,
+      expiry timestamp(0) with time zone NOT NULL,
+      rotated_at timestamp(0) with time zone,
+      created_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+
+      FOREIGN KEY (session_id) REFERENCES user_sessions (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_sessions_user_id ON user_sessions (user_id);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_session_id ON refresh_tokens (session_id);
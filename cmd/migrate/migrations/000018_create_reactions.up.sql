@@ -0,0 +1,14 @@
+-- A user can leave one reaction of each kind on a post
+CREATE TABLE IF NOT EXISTS reactions (
+      post_id bigint NOT NULL,
+      user_id bigint NOT NULL,
+      kind varchar(16) NOT NULL,
+      created_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+
+      PRIMARY KEY (post_id, user_id, kind),
+      FOREIGN KEY (post_id) REFERENCES posts (id) ON DELETE CASCADE,
+      FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+-- Serves the keyset pagination of who reacted with a given kind
+CREATE INDEX IF NOT EXISTS idx_reactions_post_kind_created ON reactions (post_id, kind, created_at, user_id);
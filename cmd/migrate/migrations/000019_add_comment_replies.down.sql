@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_comments_parent_created;
+DROP INDEX IF EXISTS idx_comments_post_top_level;
+
+ALTER TABLE comments DROP COLUMN IF EXISTS depth;
+ALTER TABLE comments DROP COLUMN IF EXISTS parent_id;
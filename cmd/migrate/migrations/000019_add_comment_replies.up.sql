@@ -0,0 +1,13 @@
+-- A comment can reply to another comment of the same post
+-- depth is 0 for top-level comments and grows by one with every level of nesting
+ALTER TABLE comments
+ADD COLUMN parent_id bigint REFERENCES comments (id) ON DELETE CASCADE;
+
+ALTER TABLE comments
+ADD COLUMN depth int NOT NULL DEFAULT 0;
+
+-- Serves the keyset pagination of the top-level comments of a post
+CREATE INDEX IF NOT EXISTS idx_comments_post_top_level ON comments (post_id, created_at, id) WHERE parent_id IS NULL;
+
+-- Serves the keyset pagination of the replies to a comment and their counts
+CREATE INDEX IF NOT EXISTS idx_comments_parent_created ON comments (parent_id, created_at, id);
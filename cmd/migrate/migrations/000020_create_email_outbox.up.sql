@@ -0,0 +1,18 @@
+-- Emails waiting to be delivered by the outbox worker
+-- status moves from pending to sent, or to dead once every attempt has failed
+CREATE TABLE IF NOT EXISTS email_outbox (
+      id bigserial PRIMARY KEY,
+      template varchar(255) NOT NULL,
+      username varchar(255) NOT NULL,
+      email citext NOT NULL,
+      data jsonb NOT NULL DEFAULT '{}',
+      status varchar(16) NOT NULL DEFAULT 'pending',
+      attempts int NOT NULL DEFAULT 0,
+      last_error text NOT NULL DEFAULT '',
+      next_attempt_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+      created_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+      sent_at timestamp(0) with time zone
+);
+
+-- Serves the worker looking for the next pending emails that are due
+CREATE INDEX IF NOT EXISTS idx_email_outbox_pending ON email_outbox (next_attempt_at) WHERE status = 'pending';
@@ -0,0 +1,7 @@
+-- Language the user receives emails in
+ALTER TABLE users
+ADD COLUMN language varchar(16) NOT NULL DEFAULT 'en';
+
+-- Locale a queued email is rendered in
+ALTER TABLE email_outbox
+ADD COLUMN locale varchar(16) NOT NULL DEFAULT 'en';
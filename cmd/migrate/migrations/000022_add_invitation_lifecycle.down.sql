@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_users_unactivated_created;
+DROP INDEX IF EXISTS idx_user_invitations_user_id;
+
+ALTER TABLE user_invitations DROP COLUMN IF EXISTS created_at;
@@ -0,0 +1,8 @@
+-- When the invitation was issued, used for the resend cooldown
+ALTER TABLE user_invitations
+ADD COLUMN created_at timestamp(0) with time zone NOT NULL DEFAULT NOW();
+
+CREATE INDEX IF NOT EXISTS idx_user_invitations_user_id ON user_invitations (user_id);
+
+-- Serves the purge of accounts that were never activated
+CREATE INDEX IF NOT EXISTS idx_users_unactivated_created ON users (created_at) WHERE is_active = false;
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_users_username_trgm;
+
+DROP INDEX IF EXISTS idx_comments_search_vector;
+ALTER TABLE comments DROP COLUMN IF EXISTS search_vector;
+
+DROP INDEX IF EXISTS idx_posts_search_vector;
+ALTER TABLE posts DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,6 @@
+UPDATE posts
+SET tags = tags_raw
+WHERE tags_raw IS NOT NULL;
+
+ALTER TABLE posts
+DROP COLUMN tags_raw;
@@ -0,0 +1,5 @@
+-- The tags stored before the API normalized them are rewritten by the
+-- tags-normalize command, which keeps the originals here until the author
+-- edits the post.
+ALTER TABLE posts
+ADD COLUMN tags_raw VARCHAR(100) [];
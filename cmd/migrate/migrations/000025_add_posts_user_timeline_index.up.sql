@@ -0,0 +1,2 @@
+-- Supports keyset pagination of the posts of a single user
+CREATE INDEX IF NOT EXISTS idx_posts_user_id_created_at ON posts (user_id, created_at DESC, id DESC);
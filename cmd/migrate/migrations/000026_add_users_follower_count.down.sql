@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS followers_follower_count ON followers;
+DROP FUNCTION IF EXISTS update_follower_count();
+
+ALTER TABLE users DROP COLUMN IF EXISTS follower_count;
@@ -0,0 +1,28 @@
+-- Kept by a trigger so the timelines can tell the heavily followed users
+-- apart without counting their followers on every read
+ALTER TABLE users
+ADD COLUMN follower_count bigint NOT NULL DEFAULT 0;
+
+UPDATE users u
+SET follower_count = f.count
+FROM (
+    SELECT user_id, COUNT(*) AS count
+    FROM followers
+    GROUP BY user_id
+) f
+WHERE f.user_id = u.id;
+
+CREATE OR REPLACE FUNCTION update_follower_count() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE users SET follower_count = follower_count + 1 WHERE id = NEW.user_id;
+    ELSE
+        UPDATE users SET follower_count = follower_count - 1 WHERE id = OLD.user_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER followers_follower_count
+AFTER INSERT OR DELETE ON followers
+FOR EACH ROW EXECUTE FUNCTION update_follower_count();
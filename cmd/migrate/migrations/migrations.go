@@ -0,0 +1,39 @@
+// Package migrations embeds the SQL migrations applied with golang-migrate,
+// so the API knows which schema version it was built against.
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"strconv"
+	"strings"
+)
+
+//go:embed *.sql
+var FS embed.FS
+
+// LatestVersion returns the version of the newest migration.
+func LatestVersion() (uint64, error) {
+	entries, err := fs.ReadDir(FS, ".")
+	if err != nil {
+		return 0, err
+	}
+
+	var latest uint64
+	for _, entry := range entries {
+		// files are named <version>_<title>.<up|down>.sql
+		prefix, _, ok := strings.Cut(entry.Name(), "_")
+		if !ok {
+			continue
+		}
+
+		version, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid migration file name %q: %w", entry.Name(), err)
+		}
+		latest = max(latest, version)
+	}
+
+	return latest, nil
+}
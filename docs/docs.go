@@ -24,9 +24,233 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/authentication/activation/resend": {
+            "post": {
+                "description": "Send a new activation link to an account that has not been activated yet. Earlier links stop working. A new link is sent at most once per cooldown period, and the response is the same whether or not such an account exists.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "authentication"
+                ],
+                "summary": "Resend the activation email",
+                "parameters": [
+                    {
+                        "description": "Account email",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_entities_payload.ResendActivationPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Activation link sent if the account is waiting for activation"
+                    },
+                    "400": {
+                        "description": "Bad request - validation error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/authentication/logout": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revoke the session of the current access token together with all of its refresh tokens. Requires JWT authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "authentication"
+                ],
+                "summary": "Logout",
+                "responses": {
+                    "204": {
+                        "description": "Session revoked"
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/authentication/password/forgot": {
+            "post": {
+                "description": "Send a single-use password reset link to the email address. The email is queued and sent in the background. The response is the same whether or not an account exists for that address.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "authentication"
+                ],
+                "summary": "Request a password reset",
+                "parameters": [
+                    {
+                        "description": "Account email",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_entities_payload.ForgotPasswordPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Reset link sent if the account exists"
+                    },
+                    "400": {
+                        "description": "Bad request - validation error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/authentication/password/reset": {
+            "post": {
+                "description": "Set a new password using the token received by email. Every existing session of the user is revoked.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "authentication"
+                ],
+                "summary": "Reset the password",
+                "parameters": [
+                    {
+                        "description": "Reset token and new password",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_entities_payload.ResetPasswordPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Password changed"
+                    },
+                    "400": {
+                        "description": "Bad request - validation error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Token not found or expired",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/authentication/refresh": {
+            "post": {
+                "description": "Exchange a refresh token for a new access token and a new refresh token. Each refresh token can only be used once, reusing one revokes the whole session.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "authentication"
+                ],
+                "summary": "Refresh the authentication token",
+                "parameters": [
+                    {
+                        "description": "Refresh token",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_entities_payload.RefreshTokenPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "New token pair",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_entities_payload.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - validation error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid, expired, reused or revoked refresh token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/authentication/token": {
             "post": {
-                "description": "Authenticate user with email and password, returns a JWT token for API access",
+                "description": "Authenticate user with email and password, returns a short-lived JWT token for API access and a refresh token",
                 "consumes": [
                     "application/json"
                 ],
@@ -58,28 +282,19 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad request - validation error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized - invalid credentials",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
                         }
                     }
                 }
@@ -87,7 +302,7 @@ const docTemplate = `{
         },
         "/authentication/user": {
             "post": {
-                "description": "Create a new user account with username, email and password. Returns user information with an activation token.",
+                "description": "Create a new user account with username, email and password. Returns user information with an activation token. The activation email is queued and sent in the background, in the language from the payload or the Accept-Language header.",
                 "consumes": [
                     "application/json"
                 ],
@@ -107,6 +322,13 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_entities_payload.RegisterUserPayload"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "example": "id-ID,id;q=0.9",
+                        "description": "Preferred email language when the payload has none",
+                        "name": "Accept-Language",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -119,19 +341,13 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad request - validation error or duplicate email/username",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
                         }
                     }
                 }
@@ -162,6 +378,26 @@ const docTemplate = `{
                     },
                     "500": {
                         "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/live": {
+            "get": {
+                "description": "Reports that the process is up and able to answer requests. It does not check any dependency, so a failing database does not get the instance restarted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Liveness probe",
+                "responses": {
+                    "200": {
+                        "description": "The process is alive",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -172,6 +408,32 @@ const docTemplate = `{
                 }
             }
         },
+        "/health/ready": {
+            "get": {
+                "description": "Checks Postgres, the schema migration version and Redis when it is enabled, each with its own timeout, and reports the status and latency of each of them. Fails while the server is shutting down so load balancers stop sending traffic.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "Every dependency is up",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_health.Report"
+                        }
+                    },
+                    "503": {
+                        "description": "A dependency is down or the server is shutting down",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_health.Report"
+                        }
+                    }
+                }
+            }
+        },
         "/posts": {
             "post": {
                 "security": [
@@ -179,7 +441,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new post with title, content and optional tags. Requires JWT authentication.",
+                "description": "Create a new post with title, content and optional tags. Tags are lowercased, stripped of a leading # and deduplicated, up to 10 tags of 50 letters, digits, _ or -. Requires JWT authentication.",
                 "consumes": [
                     "application/json"
                 ],
@@ -211,28 +473,19 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized - invalid or missing token",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
                         }
                     }
                 }
@@ -245,7 +498,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a paginated feed of posts from followed users and own posts. Requires JWT authentication.",
+                "description": "Get a paginated feed of posts from followed users and own posts. Requires JWT authentication.\nWhen timelines are enabled, the feed goes back as far as the newest TIMELINE_MAX_ENTRIES posts of followed users.\nPages can be walked with offset or, preferably, with the opaque cursor returned as next_cursor.",
                 "consumes": [
                     "application/json"
                 ],
@@ -273,6 +526,12 @@ const docTemplate = `{
                         "name": "offset",
                         "in": "query"
                     },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
                     {
                         "enum": [
                             "asc",
@@ -326,28 +585,19 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized - invalid or missing token",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
                         }
                     }
                 }
@@ -360,7 +610,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get detailed information about a specific post including comments",
+                "description": "Get detailed information about a specific post including reaction counts and the viewer's reactions. Comments are listed separately with GET /posts/{postID}/comments.",
                 "consumes": [
                     "application/json"
                 ],
@@ -383,7 +633,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Post information with comments",
+                        "description": "Post information",
                         "schema": {
                             "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_entities_posts.Post"
                         }
@@ -391,19 +641,13 @@ const docTemplate = `{
                     "404": {
                         "description": "Post not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
                         }
                     }
                 }
@@ -442,28 +686,19 @@ const docTemplate = `{
                     "401": {
                         "description": "Unauthorized - invalid or missing token",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
                         }
                     },
                     "404": {
                         "description": "Post not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
                         }
                     }
                 }
@@ -474,7 +709,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update the title and/or content of an existing post. Requires JWT authentication.",
+                "description": "Update the title, content and/or tags of an existing post. Tags are normalized like on creation. Requires JWT authentication.",
                 "consumes": [
                     "application/json"
                 ],
@@ -514,45 +749,38 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized - invalid or missing token",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
                         }
                     },
                     "404": {
                         "description": "Post not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/users/activate/{token}": {
-            "put": {
-                "description": "Activate a user account using the activation token received during registration",
+        "/posts/{postID}/comments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a paginated list of the top-level comments of a specific post, newest first. Each comment carries its reply count, replies are listed with GET /posts/{postID}/comments/{commentID}/replies. Requires JWT authentication.",
                 "consumes": [
                     "application/json"
                 ],
@@ -560,52 +788,95 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "authentication"
+                    "comments"
                 ],
-                "summary": "Activate user account",
+                "summary": "List comments of a post",
                 "parameters": [
                     {
-                        "type": "string",
-                        "example": "\"550e8400-e29b-41d4-a716-446655440000\"",
-                        "description": "Activation token",
-                        "name": "token",
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Post ID",
+                        "name": "postID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "example": 10,
+                        "description": "Number of comments per page (1-20)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "example": 0,
+                        "description": "Number of comments to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "desc",
+                        "description": "Sort order (asc/desc)",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "202": {
-                        "description": "User account activated successfully"
+                    "200": {
+                        "description": "Top-level comments of the post",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_entities_comments.Comment"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
                     },
                     "404": {
-                        "description": "Token not found or invalid",
+                        "description": "Post not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/users/{userID}": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get detailed information about a specific user. Requires JWT authentication.",
+                "description": "Add a new comment to a specific post, or reply to one of its comments by setting parent_id. Replies can be nested up to a configured depth. Requires JWT authentication.",
                 "consumes": [
                     "application/json"
                 ],
@@ -613,64 +884,70 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "comments"
                 ],
-                "summary": "Get user by ID",
+                "summary": "Comment on a post",
                 "parameters": [
                     {
                         "type": "integer",
                         "example": 1,
-                        "description": "User ID",
-                        "name": "userID",
+                        "description": "Post ID",
+                        "name": "postID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Comment creation data",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_entities_payload.CreateCommentPayload"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "User information",
+                    "201": {
+                        "description": "Created comment",
                         "schema": {
-                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_entities_users.User"
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_entities_comments.Comment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request, unknown parent comment or replies nested too deeply",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized - invalid or missing token",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Post not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/users/{userID}/follow": {
-            "put": {
+        "/posts/{postID}/comments/{commentID}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Follow another user to see their posts in your feed. Requires JWT authentication.",
+                "description": "Delete a comment. Only the author and admins can delete a comment. Requires JWT authentication.",
                 "consumes": [
                     "application/json"
                 ],
@@ -678,79 +955,64 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "comments"
                 ],
-                "summary": "Follow a user",
+                "summary": "Delete a comment",
                 "parameters": [
                     {
                         "type": "integer",
                         "example": 1,
-                        "description": "ID of the user to follow",
-                        "name": "userID",
+                        "description": "Post ID",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Comment ID",
+                        "name": "commentID",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "204": {
-                        "description": "Successfully followed user"
-                    },
-                    "400": {
-                        "description": "Bad request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
+                        "description": "Comment successfully deleted"
                     },
                     "401": {
                         "description": "Unauthorized - invalid or missing token",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "User not found",
+                    "403": {
+                        "description": "Forbidden - not the comment author",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Already following this user",
+                    "404": {
+                        "description": "Comment not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/users/{userID}/unfollow": {
-            "put": {
+            },
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Unfollow a user to stop seeing their posts in your feed. Requires JWT authentication.",
+                "description": "Edit the content of a comment. Only the author, moderators and admins can edit a comment. Requires JWT authentication.",
                 "consumes": [
                     "application/json"
                 ],
@@ -758,66 +1020,1238 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "comments"
                 ],
-                "summary": "Unfollow a user",
+                "summary": "Update a comment",
                 "parameters": [
                     {
                         "type": "integer",
                         "example": 1,
-                        "description": "ID of the user to unfollow",
-                        "name": "userID",
+                        "description": "Post ID",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Comment ID",
+                        "name": "commentID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Comment update data",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_entities_payload.UpdateCommentPayload"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Successfully unfollowed user"
+                    "200": {
+                        "description": "Updated comment",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_entities_comments.Comment"
+                        }
                     },
                     "400": {
                         "description": "Bad request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized - invalid or missing token",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not the comment author",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Comment not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/{postID}/comments/{commentID}/replies": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a paginated list of the direct replies to a comment, oldest first. Each reply carries its own reply count. Requires JWT authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "List replies to a comment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Post ID",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Comment ID",
+                        "name": "commentID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "example": 10,
+                        "description": "Number of replies per page (1-20)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "example": 0,
+                        "description": "Number of replies to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort order (asc/desc)",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Replies to the comment",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_entities_comments.Comment"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Comment not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/{postID}/reactions/{kind}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a paginated list of the users who left a reaction of the given kind on a post, most recent first. Requires JWT authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reactions"
+                ],
+                "summary": "List who reacted to a post",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Post ID",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "like",
+                            "love",
+                            "laugh",
+                            "wow",
+                            "sad",
+                            "angry"
+                        ],
+                        "type": "string",
+                        "description": "Reaction kind",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "example": 10,
+                        "description": "Number of users per page (1-20)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "example": 0,
+                        "description": "Number of users to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "desc",
+                        "description": "Sort order (asc/desc)",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reactions of the post",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_entities_reactions.Reaction"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Post not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Leave a reaction of the given kind on a post. Reacting twice with the same kind has no effect. Requires JWT authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reactions"
+                ],
+                "summary": "React to a post",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Post ID",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "like",
+                            "love",
+                            "laugh",
+                            "wow",
+                            "sad",
+                            "angry"
+                        ],
+                        "type": "string",
+                        "description": "Reaction kind",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reactions of the post",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_entities_reactions.Summary"
+                        }
+                    },
+                    "400": {
+                        "description": "Unknown reaction kind",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Post not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove the reaction of the given kind the current user left on a post. Requires JWT authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reactions"
+                ],
+                "summary": "Remove a reaction from a post",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Post ID",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "like",
+                            "love",
+                            "laugh",
+                            "wow",
+                            "sad",
+                            "angry"
+                        ],
+                        "type": "string",
+                        "description": "Reaction kind",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Reaction successfully removed"
+                    },
+                    "400": {
+                        "description": "Unknown reaction kind",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Post or reaction not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/search": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Full-text search over posts, weighting titles above content, over comments, or fuzzy search over usernames. Results are ranked by relevance, and posts and comments come with an HTML-escaped snippet where the matches are wrapped in \u003cmark\u003e. Requires JWT authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "Search posts, comments or users",
+                "parameters": [
+                    {
+                        "maxLength": 100,
+                        "minLength": 2,
+                        "type": "string",
+                        "description": "Search terms, supports quoted phrases, or and -exclusions",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "posts",
+                            "comments",
+                            "users"
+                        ],
+                        "type": "string",
+                        "default": "posts",
+                        "description": "Kind of document to search",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of results per page (1-20)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of results to skip (max 1000)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ranked results",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_entities_search.Result"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/trending": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the tags used by the most distinct authors over the trending window, 24 hours by default. The ranking is cached for a few minutes. Requires JWT authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Get the trending tags",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "example": 10,
+                        "description": "Number of tags (1-20)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Trending tags, most used first",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_entities_tags.TrendingTag"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/{tag}/posts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a paginated feed of the posts of every user tagged with a tag, not only of followed users. The tag is normalized, so #GoLang and golang are the same. Requires JWT authentication.\nPages can be walked with offset or, preferably, with the opaque cursor returned as next_cursor.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Get the posts of a tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "golang",
+                        "description": "Tag",
+                        "name": "tag",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "example": 10,
+                        "description": "Number of posts per page (1-20)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "example": 0,
+                        "description": "Number of posts to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "desc",
+                        "description": "Sort order (asc/desc)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"2024-01-01 00:00:00\"",
+                        "description": "Posts created after this date",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"2024-12-31 23:59:59\"",
+                        "description": "Posts created before this date",
+                        "name": "until",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Posts of the tag",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_entities_posts.Feed"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/activate/{token}": {
+            "put": {
+                "description": "Activate a user account using the activation token received during registration",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "authentication"
+                ],
+                "summary": "Activate user account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"550e8400-e29b-41d4-a716-446655440000\"",
+                        "description": "Activation token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "User account activated successfully"
+                    },
+                    "404": {
+                        "description": "Token not found or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userID}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get detailed information about a specific user with follower, following and post counts, and whether the viewer follows the user or is followed back. Requires JWT authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get user by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User profile",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_entities_users.Profile"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userID}/follow": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Follow another user to see their posts in your feed. Requires JWT authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Follow a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "ID of the user to follow",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Successfully followed user"
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Already following this user",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userID}/followers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a paginated list of the users following a user, most recent follow first. Requires JWT authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "List followers of a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "example": 10,
+                        "description": "Number of users per page (1-20)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "example": 0,
+                        "description": "Number of users to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "desc",
+                        "description": "Sort order (asc/desc)",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Followers",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_entities_users.Connection"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userID}/following": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a paginated list of the users a user follows, most recent follow first. Requires JWT authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "List users followed by a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "example": 10,
+                        "description": "Number of users per page (1-20)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "example": 0,
+                        "description": "Number of users to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "desc",
+                        "description": "Sort order (asc/desc)",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Followed users",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_entities_users.Connection"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userID}/posts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a paginated list of the posts written by a user, for their profile page. Filterable like the feed. Requires JWT authentication.\nPages can be walked with offset or, preferably, with the opaque cursor returned as next_cursor.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "List posts of a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "example": 10,
+                        "description": "Number of posts per page (1-20)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "example": 0,
+                        "description": "Number of posts to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "desc",
+                        "description": "Sort order (asc/desc)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"golang\"",
+                        "description": "Search in title and content",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"golang,programming\"",
+                        "description": "Comma-separated list of tags",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"2024-01-01 00:00:00\"",
+                        "description": "Posts created after this date",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"2024-12-31 23:59:59\"",
+                        "description": "Posts created before this date",
+                        "name": "until",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Posts of the user",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_entities_posts.Feed"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userID}/unfollow": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Unfollow a user to stop seeing their posts in your feed. Requires JWT authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Unfollow a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "ID of the user to unfollow",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Successfully unfollowed user"
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
                         }
                     },
                     "409": {
                         "description": "Already unfollowed this user",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse"
                         }
                     }
                 }
@@ -826,7 +2260,7 @@ const docTemplate = `{
     },
     "definitions": {
         "github_com_orangeMangoDimz_go-social_internal_entities_comments.Comment": {
-            "description": "Comment on a social media post",
+            "description": "Comment on a social media post, or a reply to another comment",
             "type": "object",
             "properties": {
                 "content": {
@@ -839,16 +2273,36 @@ const docTemplate = `{
                     "type": "string",
                     "example": "2024-01-01 12:00:00"
                 },
+                "depth": {
+                    "description": "Nesting level, 0 for top-level comments",
+                    "type": "integer",
+                    "example": 1
+                },
                 "id": {
                     "description": "Comment ID",
                     "type": "integer",
                     "example": 1
                 },
+                "parent_id": {
+                    "description": "ID of the comment this one replies to, null for top-level comments",
+                    "type": "integer",
+                    "example": 12
+                },
                 "post_id": {
                     "description": "ID of the post this comment belongs to",
                     "type": "integer",
                     "example": 123
                 },
+                "reply_count": {
+                    "description": "Number of direct replies to this comment",
+                    "type": "integer",
+                    "example": 3
+                },
+                "updated_at": {
+                    "description": "Comment last update timestamp",
+                    "type": "string",
+                    "example": "2024-01-01 12:30:00"
+                },
                 "user": {
                     "description": "User who made the comment",
                     "allOf": [
@@ -864,6 +2318,27 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_orangeMangoDimz_go-social_internal_entities_payload.CreateCommentPayload": {
+            "description": "Request payload for creating a new comment",
+            "type": "object",
+            "required": [
+                "content"
+            ],
+            "properties": {
+                "content": {
+                    "description": "Comment content (max 1000 characters)",
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "Great post!"
+                },
+                "parent_id": {
+                    "description": "ID of the comment to reply to, omit for a top-level comment",
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 12
+                }
+            }
+        },
         "github_com_orangeMangoDimz_go-social_internal_entities_payload.CreatePOstPayload": {
             "description": "Request payload for creating a new post",
             "type": "object",
@@ -879,7 +2354,7 @@ const docTemplate = `{
                     "example": "This is the content of my post"
                 },
                 "tags": {
-                    "description": "Post tags",
+                    "description": "Post tags, lowercased without # (max 10, 50 characters each)",
                     "type": "array",
                     "items": {
                         "type": "string"
@@ -919,33 +2394,107 @@ const docTemplate = `{
                 }
             }
         },
-        "github_com_orangeMangoDimz_go-social_internal_entities_payload.RegisterUserPayload": {
-            "description": "Request payload for user registration",
+        "github_com_orangeMangoDimz_go-social_internal_entities_payload.ForgotPasswordPayload": {
+            "description": "Request payload for starting a password reset",
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "description": "Email address of the account",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "johndoe@example.com"
+                }
+            }
+        },
+        "github_com_orangeMangoDimz_go-social_internal_entities_payload.RefreshTokenPayload": {
+            "description": "Request payload carrying a refresh token",
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "description": "Refresh token",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "github_com_orangeMangoDimz_go-social_internal_entities_payload.RegisterUserPayload": {
+            "description": "Request payload for user registration",
+            "type": "object",
+            "required": [
+                "email",
+                "password",
+                "username"
+            ],
+            "properties": {
+                "email": {
+                    "description": "Email address (max 255 characters)",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "johndoe@example.com"
+                },
+                "language": {
+                    "description": "Preferred email language, defaults to the Accept-Language header",
+                    "type": "string",
+                    "maxLength": 35,
+                    "example": "id"
+                },
+                "password": {
+                    "description": "Password (3-72 characters)",
+                    "type": "string",
+                    "maxLength": 72,
+                    "minLength": 3,
+                    "example": "securepassword123"
+                },
+                "username": {
+                    "description": "Username (max 100 characters)",
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "johndoe"
+                }
+            }
+        },
+        "github_com_orangeMangoDimz_go-social_internal_entities_payload.ResendActivationPayload": {
+            "description": "Request payload for resending the activation email",
             "type": "object",
             "required": [
-                "email",
-                "password",
-                "username"
+                "email"
             ],
             "properties": {
                 "email": {
-                    "description": "Email address (max 255 characters)",
+                    "description": "Email address the account was registered with",
                     "type": "string",
                     "maxLength": 255,
                     "example": "johndoe@example.com"
-                },
+                }
+            }
+        },
+        "github_com_orangeMangoDimz_go-social_internal_entities_payload.ResetPasswordPayload": {
+            "description": "Request payload for completing a password reset",
+            "type": "object",
+            "required": [
+                "password",
+                "token"
+            ],
+            "properties": {
                 "password": {
-                    "description": "Password (3-72 characters)",
+                    "description": "New password (3-72 characters)",
                     "type": "string",
                     "maxLength": 72,
                     "minLength": 3,
-                    "example": "securepassword123"
+                    "example": "newsecurepassword123"
                 },
-                "username": {
-                    "description": "Username (max 100 characters)",
+                "token": {
+                    "description": "Reset token received by email",
                     "type": "string",
-                    "maxLength": 100,
-                    "example": "johndoe"
+                    "maxLength": 255,
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
                 }
             }
         },
@@ -953,6 +2502,11 @@ const docTemplate = `{
             "description": "Response containing a JWT authentication token",
             "type": "object",
             "properties": {
+                "refresh_token": {
+                    "description": "Single-use token to obtain a new access token",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
                 "token": {
                     "description": "JWT authentication token",
                     "type": "string",
@@ -960,6 +2514,21 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_orangeMangoDimz_go-social_internal_entities_payload.UpdateCommentPayload": {
+            "description": "Request payload for updating an existing comment",
+            "type": "object",
+            "required": [
+                "content"
+            ],
+            "properties": {
+                "content": {
+                    "description": "Updated comment content (max 1000 characters)",
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "Great post, thanks!"
+                }
+            }
+        },
         "github_com_orangeMangoDimz_go-social_internal_entities_payload.UpdatePostPayload": {
             "description": "Request payload for updating an existing post",
             "type": "object",
@@ -970,6 +2539,17 @@ const docTemplate = `{
                     "maxLength": 1000,
                     "example": "Updated post content"
                 },
+                "tags": {
+                    "description": "Replaces the post tags when present, an empty list removes them",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "golang",
+                        "generics"
+                    ]
+                },
                 "title": {
                     "description": "Updated post title (max 100 characters)",
                     "type": "string",
@@ -1000,6 +2580,11 @@ const docTemplate = `{
                 "is_active": {
                     "type": "boolean"
                 },
+                "language": {
+                    "description": "Language the user receives emails in",
+                    "type": "string",
+                    "example": "en"
+                },
                 "role": {
                     "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_entities_users.Role"
                 },
@@ -1019,16 +2604,9 @@ const docTemplate = `{
             }
         },
         "github_com_orangeMangoDimz_go-social_internal_entities_posts.Feed": {
-            "description": "Post feed item with comment count",
+            "description": "Post feed item with comment count and reactions",
             "type": "object",
             "properties": {
-                "comments": {
-                    "description": "Comments on this post",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_entities_comments.Comment"
-                    }
-                },
                 "content": {
                     "description": "Post content",
                     "type": "string",
@@ -1044,6 +2622,14 @@ const docTemplate = `{
                     "type": "integer",
                     "example": 1
                 },
+                "reactions": {
+                    "description": "Reaction counts and the viewer's reactions",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_entities_reactions.Summary"
+                        }
+                    ]
+                },
                 "tags": {
                     "description": "Post tags",
                     "type": "array",
@@ -1094,13 +2680,6 @@ const docTemplate = `{
             "description": "Social media post with content, tags and metadata",
             "type": "object",
             "properties": {
-                "comments": {
-                    "description": "Comments on this post",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_entities_comments.Comment"
-                    }
-                },
                 "content": {
                     "description": "Post content",
                     "type": "string",
@@ -1116,6 +2695,14 @@ const docTemplate = `{
                     "type": "integer",
                     "example": 1
                 },
+                "reactions": {
+                    "description": "Reaction counts and the viewer's reactions",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_entities_reactions.Summary"
+                        }
+                    ]
+                },
                 "tags": {
                     "description": "Post tags",
                     "type": "array",
@@ -1157,6 +2744,229 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_orangeMangoDimz_go-social_internal_entities_reactions.Reaction": {
+            "description": "Reaction left by a user on a post",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "When the reaction was left",
+                    "type": "string",
+                    "example": "2024-01-01 12:00:00"
+                },
+                "kind": {
+                    "description": "Kind of reaction",
+                    "type": "string",
+                    "example": "like"
+                },
+                "post_id": {
+                    "description": "ID of the post",
+                    "type": "integer",
+                    "example": 1
+                },
+                "user_id": {
+                    "description": "ID of the user who reacted",
+                    "type": "integer",
+                    "example": 456
+                },
+                "username": {
+                    "description": "Username of the user who reacted",
+                    "type": "string",
+                    "example": "johndoe"
+                }
+            }
+        },
+        "github_com_orangeMangoDimz_go-social_internal_entities_reactions.Summary": {
+            "description": "Reaction counts of a post and the viewer's own reactions",
+            "type": "object",
+            "properties": {
+                "counts": {
+                    "description": "Number of reactions per kind",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "total": {
+                    "description": "Number of reactions of every kind",
+                    "type": "integer",
+                    "example": 12
+                },
+                "viewer_reactions": {
+                    "description": "Kinds the viewer reacted with",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "like"
+                    ]
+                }
+            }
+        },
+        "github_com_orangeMangoDimz_go-social_internal_entities_search.Result": {
+            "description": "Search hit with its rank and highlighted snippet",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "When the document was created",
+                    "type": "string",
+                    "example": "2024-01-01 12:00:00"
+                },
+                "id": {
+                    "description": "ID of the post, comment or user",
+                    "type": "integer",
+                    "example": 1
+                },
+                "post_id": {
+                    "description": "Post the comment belongs to, for comments",
+                    "type": "integer",
+                    "example": 1
+                },
+                "rank": {
+                    "description": "Relevance, only comparable between results of the same type",
+                    "type": "number",
+                    "example": 0.6079
+                },
+                "snippet": {
+                    "description": "HTML-escaped excerpt with the matches wrapped in \u003cmark\u003e",
+                    "type": "string",
+                    "example": "how \u003cmark\u003egenerics\u003c/mark\u003e changed the way..."
+                },
+                "title": {
+                    "description": "Highlighted title, for posts",
+                    "type": "string",
+                    "example": "Generics in \u003cmark\u003eGo\u003c/mark\u003e"
+                },
+                "type": {
+                    "description": "Kind of document",
+                    "type": "string",
+                    "enum": [
+                        "posts",
+                        "comments",
+                        "users"
+                    ],
+                    "example": "posts"
+                },
+                "user_id": {
+                    "description": "Author of the document, or the user itself",
+                    "type": "integer",
+                    "example": 123
+                },
+                "username": {
+                    "description": "Username of the author, or of the user itself",
+                    "type": "string",
+                    "example": "johndoe"
+                }
+            }
+        },
+        "github_com_orangeMangoDimz_go-social_internal_entities_tags.TrendingTag": {
+            "description": "Tag used by the most authors over the trending window",
+            "type": "object",
+            "properties": {
+                "authors": {
+                    "description": "Number of distinct users who posted with the tag",
+                    "type": "integer",
+                    "example": 42
+                },
+                "posts": {
+                    "description": "Number of posts with the tag",
+                    "type": "integer",
+                    "example": 57
+                },
+                "tag": {
+                    "description": "Normalized tag",
+                    "type": "string",
+                    "example": "golang"
+                }
+            }
+        },
+        "github_com_orangeMangoDimz_go-social_internal_entities_users.Connection": {
+            "description": "User listed as a follower or as a followed account",
+            "type": "object",
+            "properties": {
+                "followed_at": {
+                    "description": "When the follow happened",
+                    "type": "string",
+                    "example": "2024-01-01 12:00:00"
+                },
+                "id": {
+                    "description": "User ID",
+                    "type": "integer",
+                    "example": 1
+                },
+                "username": {
+                    "description": "Username",
+                    "type": "string",
+                    "example": "johndoe"
+                }
+            }
+        },
+        "github_com_orangeMangoDimz_go-social_internal_entities_users.Profile": {
+            "description": "User account information with social counters and the viewer's relationship",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "Account creation timestamp",
+                    "type": "string",
+                    "example": "2024-01-01 12:00:00"
+                },
+                "email": {
+                    "description": "Email address",
+                    "type": "string",
+                    "example": "johndoe@example.com"
+                },
+                "follower_count": {
+                    "description": "Number of users following this user",
+                    "type": "integer",
+                    "example": 120
+                },
+                "following_count": {
+                    "description": "Number of users this user follows",
+                    "type": "integer",
+                    "example": 80
+                },
+                "follows_you": {
+                    "description": "Whether this user follows the viewer",
+                    "type": "boolean",
+                    "example": false
+                },
+                "id": {
+                    "description": "User ID",
+                    "type": "integer",
+                    "example": 1
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "is_following": {
+                    "description": "Whether the viewer follows this user",
+                    "type": "boolean",
+                    "example": true
+                },
+                "language": {
+                    "description": "Language the user receives emails in",
+                    "type": "string",
+                    "example": "en"
+                },
+                "post_count": {
+                    "description": "Number of posts of this user",
+                    "type": "integer",
+                    "example": 42
+                },
+                "role": {
+                    "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_entities_users.Role"
+                },
+                "role_id": {
+                    "type": "integer"
+                },
+                "username": {
+                    "description": "Username",
+                    "type": "string",
+                    "example": "johndoe"
+                }
+            }
+        },
         "github_com_orangeMangoDimz_go-social_internal_entities_users.Role": {
             "type": "object",
             "properties": {
@@ -1196,6 +3006,11 @@ const docTemplate = `{
                 "is_active": {
                     "type": "boolean"
                 },
+                "language": {
+                    "description": "Language the user receives emails in",
+                    "type": "string",
+                    "example": "en"
+                },
                 "role": {
                     "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_entities_users.Role"
                 },
@@ -1208,6 +3023,113 @@ const docTemplate = `{
                     "example": "johndoe"
                 }
             }
+        },
+        "github_com_orangeMangoDimz_go-social_internal_health.Report": {
+            "description": "Readiness of the API and of each of its dependencies",
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "description": "Status of each dependency by name",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_health.Result"
+                    }
+                },
+                "status": {
+                    "description": "Overall readiness",
+                    "type": "string",
+                    "enum": [
+                        "ready",
+                        "not_ready",
+                        "shutting_down"
+                    ],
+                    "example": "ready"
+                }
+            }
+        },
+        "github_com_orangeMangoDimz_go-social_internal_health.Result": {
+            "description": "Status of a dependency",
+            "type": "object",
+            "properties": {
+                "latency_ms": {
+                    "description": "Time taken by the check in milliseconds",
+                    "type": "number",
+                    "example": 1.25
+                },
+                "status": {
+                    "description": "Status of the dependency",
+                    "type": "string",
+                    "enum": [
+                        "up",
+                        "down",
+                        "timeout"
+                    ],
+                    "example": "up"
+                }
+            }
+        },
+        "github_com_orangeMangoDimz_go-social_internal_server_http_protocol.Error": {
+            "description": "Machine-readable error",
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Stable error code",
+                    "type": "string",
+                    "example": "validation_failed"
+                },
+                "fields": {
+                    "description": "Invalid fields, for validation errors",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.FieldError"
+                    }
+                },
+                "message": {
+                    "description": "Human readable description",
+                    "type": "string",
+                    "example": "the request has invalid fields"
+                },
+                "request_id": {
+                    "description": "ID of the request, to be quoted when reporting an issue",
+                    "type": "string",
+                    "example": "host/AbCdEf1234-000001"
+                }
+            }
+        },
+        "github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse": {
+            "description": "Error envelope",
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/github_com_orangeMangoDimz_go-social_internal_server_http_protocol.Error"
+                }
+            }
+        },
+        "github_com_orangeMangoDimz_go-social_internal_server_http_protocol.FieldError": {
+            "description": "Validation error of a single field",
+            "type": "object",
+            "properties": {
+                "field": {
+                    "description": "JSON name of the field",
+                    "type": "string",
+                    "example": "email"
+                },
+                "message": {
+                    "description": "Human readable description",
+                    "type": "string",
+                    "example": "must be a valid email"
+                },
+                "param": {
+                    "description": "Parameter of the rule, such as the maximum length",
+                    "type": "string",
+                    "example": ""
+                },
+                "rule": {
+                    "description": "Validation rule that failed",
+                    "type": "string",
+                    "example": "email"
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -15,6 +15,7 @@ var testClaims = jwt.MapClaims{
 	"aud": "test-aud",
 	"iss": "test-aud",
 	"sub": int64(42),
+	"sid": "00000000-0000-0000-0000-000000000042",
 	"exp": time.Now().Add(time.Hour).Unix(),
 }
 
@@ -15,7 +15,77 @@ type Config struct {
 	FrontendURL string
 	Auth        AuthConfig
 	RedisCfg    RedisConfig
+	Cache       CacheConfig
 	RateLimiter ratelimiter.Config
+	Comments    CommentsConfig
+	Outbox      OutboxConfig
+	Invitations InvitationsConfig
+	Tracing     TracingConfig
+	Health      HealthConfig
+	Tags        TagsConfig
+	Timeline    TimelineConfig
+}
+
+type TimelineConfig struct {
+	// Enabled switches the feed to timelines precomputed in Redis on every new post
+	Enabled bool
+	// MaxEntries caps each timeline, the feed goes no further back
+	MaxEntries int
+	// FanoutMaxFollowers is the follower count above which posts are merged in at read time instead
+	FanoutMaxFollowers int
+}
+
+type TagsConfig struct {
+	// TrendingWindow is how far back posts count towards the trending tags
+	TrendingWindow time.Duration
+}
+
+type HealthConfig struct {
+	// CheckTimeout bounds each readiness check on its own
+	CheckTimeout time.Duration
+	// ShutdownDrain is how long readiness fails before the server stops accepting requests
+	ShutdownDrain time.Duration
+}
+
+type TracingConfig struct {
+	// Exporter is where spans are sent: none, stdout or otlp
+	Exporter string
+	// Endpoint is the OTLP/HTTP collector URL, the OTEL_EXPORTER_OTLP_* variables apply when empty
+	Endpoint    string
+	ServiceName string
+	// SampleRatio is the fraction of new traces that are recorded, from 0 to 1
+	SampleRatio float64
+}
+
+type InvitationsConfig struct {
+	// ResendCooldown is the minimum time between two activation emails to the same address
+	ResendCooldown time.Duration
+	PurgeEnabled   bool
+	PurgeInterval  time.Duration
+	// MaxUnactivatedAge is how old a never-activated account gets before it is deleted
+	MaxUnactivatedAge time.Duration
+}
+
+type CommentsConfig struct {
+	MaxDepth int
+}
+
+type OutboxConfig struct {
+	Enabled      bool
+	PollInterval time.Duration
+	BatchSize    int
+	MaxAttempts  int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	// Lease is how long a claimed email stays hidden from other workers
+	Lease time.Duration
+}
+
+type CacheConfig struct {
+	UserTTL    time.Duration
+	PostTTL    time.Duration
+	RoleTTL    time.Duration
+	SessionTTL time.Duration
 }
 
 type RedisConfig struct {
@@ -36,16 +106,28 @@ type BasicConfig struct {
 }
 
 type TokenConfig struct {
-	Secret string
-	Exp    time.Duration
-	Iss    string
+	Secret     string
+	Exp        time.Duration
+	RefreshExp time.Duration
+	Iss        string
 }
 
 type MailConfig struct {
-	SendGrid  SendGridConfig
-	MailTrap  MailTrapConfig
-	FromEmail string
-	Exp       time.Duration
+	Provider         string
+	SMTP             SMTPConfig
+	SendGrid         SendGridConfig
+	MailTrap         MailTrapConfig
+	FromEmail        string
+	Exp              time.Duration
+	PasswordResetExp time.Duration
+}
+
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	TLSMode  string
 }
 
 type SendGridConfig struct {
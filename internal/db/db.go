@@ -4,13 +4,21 @@ import (
 	"context"
 	"database/sql"
 	"time"
+
+	"github.com/lib/pq"
+	"github.com/orangeMangoDimz/go-social/internal/tracing"
 )
 
+// storePackages is the import path prefix of the Postgres stores
+const storePackages = "github.com/orangeMangoDimz/go-social/internal/storage/postgres/"
+
 func New(addr string, maxOpenConns, maxIdleConns int, maxIdleTime string) (*sql.DB, error) {
-	db, err := sql.Open("postgres", addr)
+	connector, err := pq.NewConnector(addr)
 	if err != nil {
 		return nil, err
 	}
+	// every query issued by the stores gets a span named after the store method
+	db := sql.OpenDB(tracing.NewSQLConnector(connector, storePackages))
 
 	db.SetMaxOpenConns(maxOpenConns)
 	db.SetMaxIdleConns(maxIdleConns)
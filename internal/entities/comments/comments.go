@@ -1,15 +1,28 @@
 package commentsEntity
 
-import usersEntity "github.com/orangeMangoDimz/go-social/internal/entities/users"
+import (
+	"errors"
+
+	usersEntity "github.com/orangeMangoDimz/go-social/internal/entities/users"
+)
+
+var (
+	ErrInvalidParent    = errors.New("the parent comment does not belong to this post")
+	ErrMaxDepthExceeded = errors.New("replies cannot be nested any deeper")
+)
 
 // Comment represents a comment on a post
 //
-//	@Description	Comment on a social media post
+//	@Description	Comment on a social media post, or a reply to another comment
 type Comment struct {
-	ID        int64            `json:"id" example:"1"`                           // Comment ID
-	PostID    int64            `json:"post_id" example:"123"`                    // ID of the post this comment belongs to
-	UserID    int64            `json:"user_id" example:"456"`                    // ID of the user who made the comment
-	Content   string           `json:"content" example:"Great post!"`            // Comment content
-	CreatedAt string           `json:"created_at" example:"2024-01-01 12:00:00"` // Comment creation timestamp
-	User      usersEntity.User `json:"user"`                                     // User who made the comment
+	ID         int64            `json:"id" example:"1"`                           // Comment ID
+	PostID     int64            `json:"post_id" example:"123"`                    // ID of the post this comment belongs to
+	ParentID   *int64           `json:"parent_id" example:"12"`                   // ID of the comment this one replies to, null for top-level comments
+	Depth      int              `json:"depth" example:"1"`                        // Nesting level, 0 for top-level comments
+	UserID     int64            `json:"user_id" example:"456"`                    // ID of the user who made the comment
+	Content    string           `json:"content" example:"Great post!"`            // Comment content
+	ReplyCount int64            `json:"reply_count" example:"3"`                  // Number of direct replies to this comment
+	CreatedAt  string           `json:"created_at" example:"2024-01-01 12:00:00"` // Comment creation timestamp
+	UpdatedAt  string           `json:"updated_at" example:"2024-01-01 12:30:00"` // Comment last update timestamp
+	User       usersEntity.User `json:"user"`                                     // User who made the comment
 }
@@ -0,0 +1,46 @@
+package outboxEntity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	StatusPending = "pending"
+	StatusSent    = "sent"
+	StatusDead    = "dead"
+)
+
+// Email is a message queued in the outbox. It is written together with the
+// change that triggers it and delivered later by the outbox worker.
+type Email struct {
+	ID            int64
+	Template      string
+	Locale        string
+	Username      string
+	Email         string
+	Data          json.RawMessage
+	Status        string
+	Attempts      int
+	LastError     string
+	NextAttemptAt time.Time
+	CreatedAt     time.Time
+}
+
+// NewEmail queues the template for the recipient in the given locale, data
+// holds the variables the template is rendered with.
+func NewEmail(template, locale, username, email string, data any) (*Email, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Email{
+		Template: template,
+		Locale:   locale,
+		Username: username,
+		Email:    email,
+		Data:     raw,
+		Status:   StatusPending,
+	}, nil
+}
@@ -15,4 +15,5 @@ type PaginatedQuery struct {
 	Search string   `json:"search" validate:"max=100" example:"golang"`         // Search in title and content (max 100 chars)
 	Since  string   `json:"since" example:"2024-01-01 00:00:00"`                // Filter posts created after this date
 	Until  string   `json:"until" example:"2024-12-31 23:59:59"`                // Filter posts created before this date
+	Cursor string   `json:"cursor" validate:"max=256"`                          // Opaque keyset cursor, takes precedence over offset
 }
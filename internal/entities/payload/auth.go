@@ -10,7 +10,15 @@ import (
 //
 //	@Description	Response containing a JWT authentication token
 type TokenResponse struct {
-	Token string `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."` // JWT authentication token
+	Token        string `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`      // JWT authentication token
+	RefreshToken string `json:"refresh_token" example:"550e8400-e29b-41d4-a716-446655440000"` // Single-use token to obtain a new access token
+}
+
+// RefreshTokenPayload represents the request payload for refreshing an access token
+//
+//	@Description	Request payload carrying a refresh token
+type RefreshTokenPayload struct {
+	RefreshToken string `json:"refresh_token" validate:"required,max=255" example:"550e8400-e29b-41d4-a716-446655440000"` // Refresh token
 }
 
 type JWTAuthenticator struct {
@@ -0,0 +1,16 @@
+package payloadEntity
+
+// CreateCommentPayload represents the request payload for commenting on a post
+//
+//	@Description	Request payload for creating a new comment
+type CreateCommentPayload struct {
+	Content  string `json:"content" validate:"required,max=1000" example:"Great post!"` // Comment content (max 1000 characters)
+	ParentID *int64 `json:"parent_id" validate:"omitempty,min=1" example:"12"`          // ID of the comment to reply to, omit for a top-level comment
+}
+
+// UpdateCommentPayload represents the request payload for editing a comment
+//
+//	@Description	Request payload for updating an existing comment
+type UpdateCommentPayload struct {
+	Content string `json:"content" validate:"required,max=1000" example:"Great post, thanks!"` // Updated comment content (max 1000 characters)
+}
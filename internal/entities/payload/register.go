@@ -9,6 +9,7 @@ type RegisterUserPayload struct {
 	Username string `json:"username" validate:"required,max=100" example:"johndoe"`                // Username (max 100 characters)
 	Email    string `json:"email" validate:"required,email,max=255" example:"johndoe@example.com"` // Email address (max 255 characters)
 	Password string `json:"password" validate:"required,min=3,max=72" example:"securepassword123"` // Password (3-72 characters)
+	Language string `json:"language" validate:"omitempty,max=35" example:"id"`                     // Preferred email language, defaults to the Accept-Language header
 }
 
 // UserWithToken represents a user with an activation token
@@ -23,3 +24,25 @@ type CreateUserTokenPayload struct {
 	Email    string `json:"email" validate:"required,email,max=255" example:"johndoe@example.com"` // Email address
 	Password string `json:"password" validate:"required,min=3,max=72" example:"securepassword123"` // Password
 }
+
+// ResendActivationPayload represents the request payload for asking a new activation link
+//
+//	@Description	Request payload for resending the activation email
+type ResendActivationPayload struct {
+	Email string `json:"email" validate:"required,email,max=255" example:"johndoe@example.com"` // Email address the account was registered with
+}
+
+// ForgotPasswordPayload represents the request payload for asking a password reset link
+//
+//	@Description	Request payload for starting a password reset
+type ForgotPasswordPayload struct {
+	Email string `json:"email" validate:"required,email,max=255" example:"johndoe@example.com"` // Email address of the account
+}
+
+// ResetPasswordPayload represents the request payload for choosing a new password
+//
+//	@Description	Request payload for completing a password reset
+type ResetPasswordPayload struct {
+	Token    string `json:"token" validate:"required,max=255" example:"550e8400-e29b-41d4-a716-446655440000"` // Reset token received by email
+	Password string `json:"password" validate:"required,min=3,max=72" example:"newsecurepassword123"`         // New password (3-72 characters)
+}
@@ -2,7 +2,7 @@ package postsEntity
 
 // Feed represents a post in the user's feed with additional metadata
 //
-//	@Description	Post feed item with comment count
+//	@Description	Post feed item with comment count and reactions
 type Feed struct {
 	Post
 	TotalComments int64 `json:"total_comment" example:"5"` // Total number of comments on this post
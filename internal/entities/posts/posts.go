@@ -1,7 +1,7 @@
 package postsEntity
 
 import (
-	commentsEntity "github.com/orangeMangoDimz/go-social/internal/entities/comments"
+	reactionsEntity "github.com/orangeMangoDimz/go-social/internal/entities/reactions"
 	usersEntity "github.com/orangeMangoDimz/go-social/internal/entities/users"
 )
 
@@ -17,6 +17,6 @@ type Post struct {
 	CreatedAt string                   `json:"created_at" example:"2024-01-01 12:00:00"`  // Post creation timestamp
 	UpdatedAt string                   `json:"updated_at" example:"2024-01-01 12:30:00"`  // Post last update timestamp
 	Version   int                      `json:"version" example:"1"`                       // Post version for optimistic locking
-	Comments  []commentsEntity.Comment `json:"comments"`                                  // Comments on this post
 	User      usersEntity.User         `json:"user"`                                      // User who created the post
+	Reactions *reactionsEntity.Summary `json:"reactions,omitempty"`                       // Reaction counts and the viewer's reactions
 }
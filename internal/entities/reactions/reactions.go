@@ -0,0 +1,39 @@
+package reactionsEntity
+
+import "slices"
+
+// Kinds lists the reactions that can be left on a post
+var Kinds = []string{"like", "love", "laugh", "wow", "sad", "angry"}
+
+// IsValidKind reports whether kind is one of Kinds
+func IsValidKind(kind string) bool {
+	return slices.Contains(Kinds, kind)
+}
+
+// Reaction represents a user reacting to a post
+//
+//	@Description	Reaction left by a user on a post
+type Reaction struct {
+	PostID    int64  `json:"post_id" example:"1"`                      // ID of the post
+	UserID    int64  `json:"user_id" example:"456"`                    // ID of the user who reacted
+	Username  string `json:"username" example:"johndoe"`               // Username of the user who reacted
+	Kind      string `json:"kind" example:"like"`                      // Kind of reaction
+	CreatedAt string `json:"created_at" example:"2024-01-01 12:00:00"` // When the reaction was left
+}
+
+// Summary aggregates the reactions of a post for the requesting user
+//
+//	@Description	Reaction counts of a post and the viewer's own reactions
+type Summary struct {
+	Counts          map[string]int64 `json:"counts"`                          // Number of reactions per kind
+	Total           int64            `json:"total" example:"12"`              // Number of reactions of every kind
+	ViewerReactions []string         `json:"viewer_reactions" example:"like"` // Kinds the viewer reacted with
+}
+
+// NewSummary returns an empty summary
+func NewSummary() *Summary {
+	return &Summary{
+		Counts:          map[string]int64{},
+		ViewerReactions: []string{},
+	}
+}
@@ -0,0 +1,33 @@
+package searchEntity
+
+// Kinds of documents that can be searched
+const (
+	TypePosts    = "posts"
+	TypeComments = "comments"
+	TypeUsers    = "users"
+)
+
+// Query is a search request
+//
+//	@Description	Search terms, document type and page
+type Query struct {
+	Q      string `json:"q" validate:"required,min=2,max=100" example:"golang generics"` // Search terms, supports quoted phrases, or and -exclusions
+	Type   string `json:"type" validate:"oneof=posts comments users" example:"posts"`    // Kind of document to search
+	Limit  int    `json:"limit" validate:"gte=1,lte=20" example:"10"`                    // Number of results per page (1-20)
+	Offset int    `json:"offset" validate:"gte=0,lte=1000" example:"0"`                  // Number of results to skip
+}
+
+// Result is a single search hit, ranked by relevance
+//
+//	@Description	Search hit with its rank and highlighted snippet
+type Result struct {
+	Type      string  `json:"type" example:"posts" enums:"posts,comments,users"`                        // Kind of document
+	ID        int64   `json:"id" example:"1"`                                                           // ID of the post, comment or user
+	PostID    int64   `json:"post_id,omitempty" example:"1"`                                            // Post the comment belongs to, for comments
+	UserID    int64   `json:"user_id" example:"123"`                                                    // Author of the document, or the user itself
+	Username  string  `json:"username" example:"johndoe"`                                               // Username of the author, or of the user itself
+	Title     string  `json:"title,omitempty" example:"Generics in <mark>Go</mark>"`                    // Highlighted title, for posts
+	Snippet   string  `json:"snippet,omitempty" example:"how <mark>generics</mark> changed the way..."` // HTML-escaped excerpt with the matches wrapped in <mark>
+	Rank      float64 `json:"rank" example:"0.6079"`                                                    // Relevance, only comparable between results of the same type
+	CreatedAt string  `json:"created_at" example:"2024-01-01 12:00:00"`                                 // When the document was created
+}
@@ -0,0 +1,10 @@
+package sessionsEntity
+
+// Session represents one login of a user. Every refresh token issued from
+// that login belongs to the same session, so revoking it revokes the family.
+type Session struct {
+	ID        string  `json:"id"`
+	UserID    int64   `json:"user_id"`
+	CreatedAt string  `json:"created_at"`
+	RevokedAt *string `json:"revoked_at"`
+}
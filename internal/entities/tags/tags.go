@@ -0,0 +1,98 @@
+package tagsEntity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// MaxTags is how many tags a post can have
+	MaxTags = 10
+	// MaxLength is how many characters a tag can have
+	MaxLength = 50
+)
+
+var (
+	ErrInvalidTag  = errors.New("invalid tag")
+	ErrTooManyTags = errors.New("too many tags")
+)
+
+// TrendingTag is a tag ranked by how many people used it recently
+//
+//	@Description	Tag used by the most authors over the trending window
+type TrendingTag struct {
+	Tag     string `json:"tag" example:"golang"` // Normalized tag
+	Authors int64  `json:"authors" example:"42"` // Number of distinct users who posted with the tag
+	Posts   int64  `json:"posts" example:"57"`   // Number of posts with the tag
+}
+
+// Normalize lowercases tag and drops surrounding spaces and a leading #, so
+// "#GoLang" and "golang" are the same tag. A tag is up to MaxLength letters,
+// digits, _ or -.
+func Normalize(tag string) (string, error) {
+	tag = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+
+	if tag == "" {
+		return "", fmt.Errorf("%w: tags cannot be empty", ErrInvalidTag)
+	}
+	if utf8.RuneCountInString(tag) > MaxLength {
+		return "", fmt.Errorf("%w %q: tags are at most %d characters", ErrInvalidTag, tag, MaxLength)
+	}
+	for _, r := range tag {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '-' {
+			return "", fmt.Errorf("%w %q: tags can only contain letters, digits, _ and -", ErrInvalidTag, tag)
+		}
+	}
+	return tag, nil
+}
+
+// NormalizeAll normalizes every tag and drops the duplicates, keeping the
+// order they were given in.
+func NormalizeAll(tags []string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+
+	for _, tag := range tags {
+		tag, err := Normalize(tag)
+		if err != nil {
+			return nil, err
+		}
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+
+	if len(normalized) > MaxTags {
+		return nil, fmt.Errorf("%w: a post can have at most %d tags", ErrTooManyTags, MaxTags)
+	}
+	return normalized, nil
+}
+
+// PostTags are the tags stored on a post
+type PostTags struct {
+	PostID int64
+	Tags   []string
+}
+
+// Clean normalizes the tags stored before they were validated. Unlike
+// NormalizeAll it drops the tags Normalize rejects instead of failing, and
+// keeps the first MaxTags of them.
+func Clean(tags []string) []string {
+	cleaned := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+
+	for _, tag := range tags {
+		tag, err := Normalize(tag)
+		if err != nil || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		cleaned = append(cleaned, tag)
+	}
+	return cleaned[:min(len(cleaned), MaxTags)]
+}
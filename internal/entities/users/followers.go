@@ -5,3 +5,12 @@ type Follower struct {
 	FollowerID int64  `json:"follower_id"`
 	CreatedAT  string `json:"created_at"`
 }
+
+// Connection represents a user in a followers or following list
+//
+//	@Description	User listed as a follower or as a followed account
+type Connection struct {
+	ID         int64  `json:"id" example:"1"`                            // User ID
+	Username   string `json:"username" example:"johndoe"`                // Username
+	FollowedAt string `json:"followed_at" example:"2024-01-01 12:00:00"` // When the follow happened
+}
@@ -0,0 +1,22 @@
+package usersEntity
+
+// Profile represents a user profile as seen by the requesting user
+//
+//	@Description	User account information with social counters and the viewer's relationship
+type Profile struct {
+	User
+	FollowerCount  int64 `json:"follower_count" example:"120"` // Number of users following this user
+	FollowingCount int64 `json:"following_count" example:"80"` // Number of users this user follows
+	PostCount      int64 `json:"post_count" example:"42"`      // Number of posts of this user
+	IsFollowing    bool  `json:"is_following" example:"true"`  // Whether the viewer follows this user
+	FollowsYou     bool  `json:"follows_you" example:"false"`  // Whether this user follows the viewer
+}
+
+// ProfileStats holds the counters and relationship flags of a profile
+type ProfileStats struct {
+	FollowerCount  int64
+	FollowingCount int64
+	PostCount      int64
+	IsFollowing    bool
+	FollowsYou     bool
+}
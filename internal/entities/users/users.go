@@ -11,6 +11,7 @@ type User struct {
 	Email     string   `json:"email" example:"johndoe@example.com"`      // Email address
 	Password  Password `json:"-"`                                        // Password (never returned in responses)
 	CreatedAt string   `json:"created_at" example:"2024-01-01 12:00:00"` // Account creation timestamp
+	Language  string   `json:"language" example:"en"`                    // Language the user receives emails in
 	IsActive  bool     `json:"is_active"`
 	RoleID    int64    `json:"role_id"`
 	Role      Role     `json:"role"`
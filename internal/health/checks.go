@@ -0,0 +1,73 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	ErrPendingMigrations = errors.New("database schema is behind the latest migration")
+	ErrDirtyMigration    = errors.New("last migration failed and left the schema dirty")
+)
+
+// Postgres checks that a connection to the database can be used.
+func Postgres(db *sql.DB, timeout time.Duration) Check {
+	return Check{
+		Name:    "postgres",
+		Timeout: timeout,
+		Fn:      db.PingContext,
+	}
+}
+
+// Redis checks that the Redis server answers.
+func Redis(rdb *redis.Client, timeout time.Duration) Check {
+	return Check{
+		Name:    "redis",
+		Timeout: timeout,
+		Fn: func(ctx context.Context) error {
+			return rdb.Ping(ctx).Err()
+		},
+	}
+}
+
+// Migrations checks that golang-migrate has applied every migration up to
+// version. A newer schema is accepted, as during a rolling deploy the
+// migrations of the next release run before the old instances are stopped.
+func Migrations(db *sql.DB, version uint64, timeout time.Duration) Check {
+	return Check{
+		Name:    "migrations",
+		Timeout: timeout,
+		Fn: func(ctx context.Context) error {
+			query := `SELECT version, dirty FROM schema_migrations LIMIT 1`
+
+			var (
+				current uint64
+				dirty   bool
+			)
+			err := db.QueryRowContext(ctx, query).Scan(&current, &dirty)
+			if err != nil {
+				switch {
+				case errors.Is(err, sql.ErrNoRows):
+					return fmt.Errorf("%w: no migration applied, want %d", ErrPendingMigrations, version)
+				default:
+					return err
+				}
+			}
+
+			if dirty {
+				return fmt.Errorf("%w at version %d", ErrDirtyMigration, current)
+			}
+
+			if current < version {
+				return fmt.Errorf("%w: at %d, want %d", ErrPendingMigrations, current, version)
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,107 @@
+// Package health checks the dependencies the API needs to serve traffic.
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Check statuses
+const (
+	StatusUp      = "up"
+	StatusDown    = "down"
+	StatusTimeout = "timeout"
+)
+
+// Report statuses
+const (
+	StatusReady        = "ready"
+	StatusNotReady     = "not_ready"
+	StatusShuttingDown = "shutting_down"
+)
+
+// Check probes a single dependency. Fn gets a context that expires after
+// Timeout.
+type Check struct {
+	Name    string
+	Timeout time.Duration
+	Fn      func(ctx context.Context) error
+}
+
+// Result is the outcome of a single check
+//
+//	@Description	Status of a dependency
+type Result struct {
+	Status    string  `json:"status" example:"up" enums:"up,down,timeout"` // Status of the dependency
+	LatencyMs float64 `json:"latency_ms" example:"1.25"`                   // Time taken by the check in milliseconds
+	err       error
+}
+
+// Err returns the error of a failed check.
+func (r Result) Err() error {
+	return r.err
+}
+
+// Report is the outcome of every check
+//
+//	@Description	Readiness of the API and of each of its dependencies
+type Report struct {
+	Status string            `json:"status" example:"ready" enums:"ready,not_ready,shutting_down"` // Overall readiness
+	Checks map[string]Result `json:"checks"`                                                       // Status of each dependency by name
+}
+
+// Ready reports whether the API can serve traffic.
+func (r Report) Ready() bool {
+	return r.Status == StatusReady
+}
+
+// Run runs the checks concurrently and reports ready when they all pass.
+func Run(ctx context.Context, checks []Check) Report {
+	results := make([]Result, len(checks))
+
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = run(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	report := Report{
+		Status: StatusReady,
+		Checks: make(map[string]Result, len(checks)),
+	}
+	for i, check := range checks {
+		report.Checks[check.Name] = results[i]
+		if results[i].Status != StatusUp {
+			report.Status = StatusNotReady
+		}
+	}
+	return report
+}
+
+func run(ctx context.Context, check Check) Result {
+	ctx, cancel := context.WithTimeout(ctx, check.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check.Fn(ctx)
+	result := Result{
+		Status:    StatusUp,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+		err:       err,
+	}
+
+	switch {
+	case err == nil:
+	case errors.Is(err, context.DeadlineExceeded) || ctx.Err() != nil:
+		result.Status = StatusTimeout
+	default:
+		result.Status = StatusDown
+	}
+	return result
+}
@@ -0,0 +1,38 @@
+// Package logging carries a request-scoped zap logger through the context and
+// writes a structured access log line for every request.
+package logging
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+type loggerKey struct{}
+
+// WithLogger returns a copy of ctx carrying logger.
+func WithLogger(ctx context.Context, logger *zap.SugaredLogger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext returns the logger of the request ctx belongs to, which tags
+// every line with the request ID, or fallback outside of a request.
+func FromContext(ctx context.Context, fallback *zap.SugaredLogger) *zap.SugaredLogger {
+	if logger, ok := ctx.Value(loggerKey{}).(*zap.SugaredLogger); ok {
+		return logger
+	}
+	return fallback
+}
+
+// WithUserID tags the request logger in ctx with the authenticated user and
+// reports the user to the access log.
+func WithUserID(ctx context.Context, userID int64) context.Context {
+	if info, ok := ctx.Value(requestInfoKey{}).(*requestInfo); ok {
+		info.userID.Store(userID)
+	}
+
+	if logger, ok := ctx.Value(loggerKey{}).(*zap.SugaredLogger); ok {
+		ctx = WithLogger(ctx, logger.With("user_id", userID))
+	}
+	return ctx
+}
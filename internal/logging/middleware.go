@@ -0,0 +1,134 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// maxLoggedBody is how much of a JSON request body is logged at debug level
+const maxLoggedBody = 4 << 10
+
+type requestInfoKey struct{}
+
+// requestInfo collects what the access log learns while the request is
+// served deeper in the chain.
+type requestInfo struct {
+	userID atomic.Int64
+}
+
+// Middleware places a logger tagged with the request ID, and the trace ID
+// when the request is traced, in the request context and writes an access
+// log line once the request has been served. It has to run after
+// middleware.RequestID and middleware.RealIP.
+//
+// At debug level the line also carries the request headers and JSON body,
+// with credentials redacted.
+func Middleware(logger *zap.SugaredLogger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			ctx := r.Context()
+
+			requestLogger := logger.With("request_id", middleware.GetReqID(ctx))
+			if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+				requestLogger = requestLogger.With("trace_id", sc.TraceID().String())
+			}
+
+			info := &requestInfo{}
+			ctx = context.WithValue(ctx, requestInfoKey{}, info)
+			ctx = WithLogger(ctx, requestLogger)
+
+			debug := logger.Desugar().Core().Enabled(zapcore.DebugLevel)
+			var body *bytes.Buffer
+			if debug && isJSON(r.Header.Get("Content-Type")) && r.Body != nil {
+				body = &bytes.Buffer{}
+				r.Body = teeBody{Reader: io.TeeReader(r.Body, &limitedWriter{buf: body, n: maxLoggedBody}), Closer: r.Body}
+			}
+
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r.WithContext(ctx))
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			route := ""
+			rctx := chi.RouteContext(ctx)
+			if rctx != nil {
+				route = rctx.RoutePattern()
+			}
+
+			fields := []any{
+				"method", r.Method,
+				"route", route,
+				"path", RedactPath(r.URL.Path, rctx),
+				"status", status,
+				"bytes", ww.BytesWritten(),
+				"latency_ms", float64(time.Since(start).Microseconds()) / 1000,
+				"remote_ip", remoteIP(r.RemoteAddr),
+				"user_agent", r.UserAgent(),
+			}
+			if userID := info.userID.Load(); userID != 0 {
+				fields = append(fields, "user_id", userID)
+			}
+			if debug {
+				fields = append(fields, "headers", RedactHeaders(r.Header))
+				if body != nil && body.Len() > 0 {
+					fields = append(fields, "body", RedactJSON(body.Bytes()))
+				}
+			}
+
+			if status >= http.StatusInternalServerError {
+				requestLogger.Errorw("request served", fields...)
+				return
+			}
+			requestLogger.Infow("request served", fields...)
+		})
+	}
+}
+
+func isJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && (mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"))
+}
+
+// remoteIP strips the port middleware.RealIP leaves when there is no
+// forwarding header.
+func remoteIP(addr string) string {
+	if ip, _, err := net.SplitHostPort(addr); err == nil {
+		return ip
+	}
+	return addr
+}
+
+type teeBody struct {
+	io.Reader
+	io.Closer
+}
+
+// limitedWriter keeps the first n bytes written to it and discards the rest.
+type limitedWriter struct {
+	buf *bytes.Buffer
+	n   int
+}
+
+func (w *limitedWriter) Write(p []byte) (int, error) {
+	if room := w.n - w.buf.Len(); room > 0 {
+		w.buf.Write(p[:min(len(p), room)])
+	}
+	return len(p), nil
+}
@@ -0,0 +1,96 @@
+package logging
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const redacted = "[REDACTED]"
+
+// sensitiveHeaders carry credentials and are never logged
+var sensitiveHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+	"X-Api-Key":           true,
+	"X-Csrf-Token":        true,
+}
+
+// sensitiveWords mark the JSON fields and URL parameters holding secrets,
+// such as password, refresh_token or token.
+var sensitiveWords = []string{"password", "token", "secret"}
+
+func isSensitive(name string) bool {
+	name = strings.ToLower(name)
+	for _, word := range sensitiveWords {
+		if strings.Contains(name, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactHeaders returns the headers with the values of the credential
+// headers replaced.
+func RedactHeaders(header http.Header) map[string]string {
+	headers := make(map[string]string, len(header))
+	for name, values := range header {
+		if sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+			headers[name] = redacted
+			continue
+		}
+		headers[name] = strings.Join(values, ", ")
+	}
+	return headers
+}
+
+// RedactJSON returns the decoded body with the values of the sensitive
+// fields replaced, at any depth. A body that cannot be decoded, for
+// instance because it was truncated, is not logged at all.
+func RedactJSON(body []byte) any {
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return "[UNREADABLE]"
+	}
+	return redactValue(v)
+}
+
+func redactValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if isSensitive(key) {
+				v[key] = redacted
+				continue
+			}
+			v[key] = redactValue(value)
+		}
+		return v
+	case []any:
+		for i, value := range v {
+			v[i] = redactValue(value)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// RedactPath replaces the sensitive URL parameters of path, such as the
+// activation token of /v1/users/activate/{token}.
+func RedactPath(path string, rctx *chi.Context) string {
+	if rctx == nil {
+		return path
+	}
+
+	for i, key := range rctx.URLParams.Keys {
+		if isSensitive(key) && i < len(rctx.URLParams.Values) && rctx.URLParams.Values[i] != "" {
+			path = strings.ReplaceAll(path, rctx.URLParams.Values[i], redacted)
+		}
+	}
+	return path
+}
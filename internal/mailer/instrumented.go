@@ -0,0 +1,47 @@
+package mailer
+
+import (
+	"context"
+
+	"github.com/orangeMangoDimz/go-social/internal/metrics"
+	"github.com/orangeMangoDimz/go-social/internal/tracing"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// InstrumentedClient traces every email sent through Client and counts its
+// outcome.
+type InstrumentedClient struct {
+	Client
+	sends *prometheus.CounterVec
+}
+
+// NewInstrumentedClient wraps client so its sends are traced and counted in
+// sends, labelled by template and outcome.
+func NewInstrumentedClient(client Client, sends *prometheus.CounterVec) *InstrumentedClient {
+	return &InstrumentedClient{
+		Client: client,
+		sends:  sends,
+	}
+}
+
+func (c *InstrumentedClient) Send(ctx context.Context, templateFile, locale, username, email string, data any, isSandBox bool) (int, error) {
+	ctx, span := tracing.Start(ctx, "mailer.Send")
+	span.SetAttributes(
+		attribute.String("mailer.template", templateFile),
+		attribute.String("mailer.locale", locale),
+		attribute.Bool("mailer.sandbox", isSandBox),
+	)
+
+	status, err := c.Client.Send(ctx, templateFile, locale, username, email, data, isSandBox)
+	span.SetAttributes(attribute.Int("mailer.status_code", status))
+	tracing.End(span, err)
+
+	outcome := metrics.MailSent
+	if err != nil {
+		outcome = metrics.MailFailed
+	}
+	c.sends.WithLabelValues(templateFile, outcome).Inc()
+
+	return status, err
+}
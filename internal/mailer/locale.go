@@ -0,0 +1,69 @@
+package mailer
+
+import (
+	"io/fs"
+	"path"
+
+	"golang.org/x/text/language"
+)
+
+// DefaultLocale is used when none of the user's languages has templates
+const DefaultLocale = "en"
+
+// locales are the directories under templates, DefaultLocale first so the
+// matcher falls back to it.
+var locales, localeMatcher = loadLocales()
+
+func loadLocales() ([]string, language.Matcher) {
+	entries, err := fs.ReadDir(FS, "templates")
+	if err != nil {
+		panic(err)
+	}
+
+	names := []string{DefaultLocale}
+	tags := []language.Tag{language.Make(DefaultLocale)}
+	for _, entry := range entries {
+		if !entry.IsDir() || entry.Name() == DefaultLocale {
+			continue
+		}
+		names = append(names, entry.Name())
+		tags = append(tags, language.Make(entry.Name()))
+	}
+
+	return names, language.NewMatcher(tags)
+}
+
+// Locales lists the locales that have templates.
+func Locales() []string {
+	return append([]string(nil), locales...)
+}
+
+// MatchLocale picks the supported locale closest to the first preference
+// that has one. A preference is a language tag such as "id" or a whole
+// Accept-Language header.
+func MatchLocale(preferences ...string) string {
+	for _, preference := range preferences {
+		if preference == "" {
+			continue
+		}
+
+		tags, _, err := language.ParseAcceptLanguage(preference)
+		if err != nil || len(tags) == 0 {
+			continue
+		}
+
+		if _, index, confidence := localeMatcher.Match(tags...); confidence != language.No {
+			return locales[index]
+		}
+	}
+
+	return DefaultLocale
+}
+
+func templatePath(templateFile, locale string) string {
+	localized := path.Join("templates", locale, templateFile)
+	if _, err := fs.Stat(FS, localized); err == nil {
+		return localized
+	}
+	return path.Join("templates", DefaultLocale, templateFile)
+}
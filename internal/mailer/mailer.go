@@ -1,16 +1,127 @@
 package mailer
 
-import "embed"
+import (
+	"bytes"
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
 
+	"github.com/orangeMangoDimz/go-social/internal/config"
+	"go.uber.org/zap"
+)
+
+const (
+	FromName              = "GopherSocial"
+	UserWelcomeTemplate   = "user_invitation.tmpl"
+	PasswordResetTemplate = "password_reset.tmpl"
+)
+
+// Providers selectable with MAILER_PROVIDER
 const (
-	FromName            = "GopherSocial"
-	maxRetries          = 3
-	UserWelcomeTemplate = "user_invitation.tmpl"
+	ProviderSMTP     = "smtp"
+	ProviderSendGrid = "sendgrid"
+	ProviderMailTrap = "mailtrap"
+	ProviderLog      = "log"
+)
+
+var (
+	ErrNoProvider      = errors.New("no mailer provider configured")
+	ErrUnknownProvider = errors.New("unknown mailer provider")
 )
 
 //go:embed "templates"
 var FS embed.FS
 
 type Client interface {
-	Send(templateFile, username, email string, data any, isSandBox bool) (int, error)
+	Send(ctx context.Context, templateFile, locale, username, email string, data any, isSandBox bool) (int, error)
+}
+
+// NewClient returns the client of the provider selected in cfg.
+func NewClient(cfg config.MailConfig, logger *zap.SugaredLogger) (Client, error) {
+	switch cfg.Provider {
+	case ProviderSMTP:
+		client, err := NewSMTPClient(cfg.SMTP, cfg.FromEmail)
+		if err != nil {
+			return nil, err
+		}
+		return client, nil
+	case ProviderSendGrid:
+		if cfg.SendGrid.ApiKey == "" {
+			return nil, errors.New("SENDGRID API KEY IS REQUIRED")
+		}
+		return NewSendGrid(cfg.SendGrid.ApiKey, cfg.FromEmail), nil
+	case ProviderMailTrap:
+		client, err := NewMailTrapClient(cfg.MailTrap.ApiKey, cfg.FromEmail)
+		if err != nil {
+			return nil, err
+		}
+		return client, nil
+	case ProviderLog:
+		return NewLogClient(logger), nil
+	case "":
+		return nil, ErrNoProvider
+	default:
+		return nil, fmt.Errorf("%w %q", ErrUnknownProvider, cfg.Provider)
+	}
+}
+
+// Message is a rendered email, with its HTML body and the plaintext
+// alternative sent next to it.
+type Message struct {
+	Subject string
+	HTML    string
+	Text    string
+}
+
+// Render builds the email from the template in the given locale, falling back
+// to DefaultLocale when the template has no variant for it.
+//
+// A template defines a "subject" and an HTML "body" block, the body is
+// rendered with html/template so the data is escaped. It can also define a
+// "text" block with the plaintext version, otherwise the plaintext is derived
+// from the HTML body.
+func Render(templateFile, locale string, data any) (*Message, error) {
+	path := templatePath(templateFile, locale)
+
+	textTmpl, err := texttemplate.New(templateFile).Option("missingkey=error").ParseFS(FS, path)
+	if err != nil {
+		return nil, err
+	}
+
+	htmlTmpl, err := htmltemplate.New(templateFile).Option("missingkey=error").ParseFS(FS, path)
+	if err != nil {
+		return nil, err
+	}
+
+	subject := new(bytes.Buffer)
+	if err := textTmpl.ExecuteTemplate(subject, "subject", data); err != nil {
+		return nil, err
+	}
+
+	body := new(bytes.Buffer)
+	if err := htmlTmpl.ExecuteTemplate(body, "body", data); err != nil {
+		return nil, err
+	}
+
+	message := &Message{
+		Subject: strings.TrimSpace(subject.String()),
+		HTML:    body.String(),
+	}
+
+	if textTmpl.Lookup("text") == nil {
+		message.Text = htmlToText(message.HTML)
+		return message, nil
+	}
+
+	text := new(bytes.Buffer)
+	if err := textTmpl.ExecuteTemplate(text, "text", data); err != nil {
+		return nil, err
+	}
+	message.Text = strings.TrimSpace(text.String())
+
+	return message, nil
 }
@@ -1,63 +1,42 @@
 package mailer
 
 import (
-	"bytes"
+	"context"
 	"errors"
-	"text/template"
 
-	gomail "gopkg.in/mail.v2"
+	"github.com/orangeMangoDimz/go-social/internal/config"
 )
 
 type mailtrapClient struct {
-	fromEmail string
-	apiKey    string
+	smtp *SMTPClient
 }
 
+// NewMailTrapClient sends through the Mailtrap SMTP relay.
 func NewMailTrapClient(apiKey, fromEmail string) (mailtrapClient, error) {
 	if apiKey == "" {
 		return mailtrapClient{}, errors.New("MAILTRAP API KEY IS REQUIRED")
 	}
 
+	client, err := NewSMTPClient(config.SMTPConfig{
+		Host:     "live.smtp.mailtrap.io",
+		Port:     587,
+		Username: "api",
+		Password: apiKey,
+		TLSMode:  TLSModeStartTLS,
+	}, fromEmail)
+	if err != nil {
+		return mailtrapClient{}, err
+	}
+
 	return mailtrapClient{
-		fromEmail: fromEmail,
-		apiKey:    apiKey,
+		smtp: client,
 	}, nil
 }
-func (m mailtrapClient) Send(templateFile, username, email string, data any, isSandbox bool) (int, error) {
+
+func (m mailtrapClient) Send(ctx context.Context, templateFile, locale, username, email string, data any, isSandbox bool) (int, error) {
 	if isSandbox {
 		return 200, nil
 	}
 
-	// Template parsing and building
-	tmpl, err := template.ParseFS(FS, "templates/"+templateFile)
-	if err != nil {
-		return -1, err
-	}
-
-	subject := new(bytes.Buffer)
-	err = tmpl.ExecuteTemplate(subject, "subject", data)
-	if err != nil {
-		return -1, err
-	}
-
-	body := new(bytes.Buffer)
-	err = tmpl.ExecuteTemplate(body, "body", data)
-	if err != nil {
-		return -1, err
-	}
-
-	message := gomail.NewMessage()
-	message.SetHeader("From", m.fromEmail)
-	message.SetHeader("To", email)
-	message.SetHeader("Subject", subject.String())
-
-	message.AddAlternative("text/html", body.String())
-
-	dialer := gomail.NewDialer("live.smtp.mailtrap.io", 587, "api", m.apiKey)
-
-	if err := dialer.DialAndSend(message); err != nil {
-		return -1, err
-	}
-
-	return 200, nil
+	return m.smtp.Send(ctx, templateFile, locale, username, email, data, isSandbox)
 }
@@ -0,0 +1,15 @@
+package mailer
+
+import "context"
+
+// MockClient pretends to send every email. Set Err to make every send fail.
+type MockClient struct {
+	Err error
+}
+
+func (m *MockClient) Send(ctx context.Context, templateFile, locale, username, email string, data any, isSandbox bool) (int, error) {
+	if m.Err != nil {
+		return -1, m.Err
+	}
+	return 200, nil
+}
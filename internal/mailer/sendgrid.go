@@ -1,10 +1,8 @@
 package mailer
 
 import (
-	"bytes"
+	"context"
 	"fmt"
-	"text/template"
-	"time"
 
 	"github.com/sendgrid/sendgrid-go"
 	"github.com/sendgrid/sendgrid-go/helpers/mail"
@@ -25,29 +23,17 @@ func NewSendGrid(apiKey, fromEmail string) *SendGridMailer {
 	}
 }
 
-func (m *SendGridMailer) Send(templateFile, username, email string, data any, isSandbox bool) (int, error) {
+func (m *SendGridMailer) Send(ctx context.Context, templateFile, locale, username, email string, data any, isSandbox bool) (int, error) {
 	from := mail.NewEmail(FromName, m.fromEmail)
 	to := mail.NewEmail(username, email)
 
 	// template parsing and building
-	tmpl, err := template.ParseFS(FS, "templates/"+templateFile)
+	rendered, err := Render(templateFile, locale, data)
 	if err != nil {
 		return -1, err
 	}
 
-	subject := new(bytes.Buffer)
-	err = tmpl.ExecuteTemplate(subject, "subject", data)
-	if err != nil {
-		return -1, err
-	}
-
-	body := new(bytes.Buffer)
-	err = tmpl.ExecuteTemplate(body, "body", data)
-	if err != nil {
-		return -1, err
-	}
-
-	message := mail.NewSingleEmail(from, subject.String(), to, "", body.String())
+	message := mail.NewSingleEmail(from, rendered.Subject, to, rendered.Text, rendered.HTML)
 
 	message.SetMailSettings(&mail.MailSettings{
 		SandboxMode: &mail.Setting{
@@ -55,17 +41,14 @@ func (m *SendGridMailer) Send(templateFile, username, email string, data any, is
 		},
 	})
 
-	var retryErr error
-	for i := 0; i < maxRetries; i++ {
-		response, retryErr := m.client.Send(message)
-		if retryErr != nil {
-			// exponential backoff
-			time.Sleep(time.Second * time.Duration(i+1))
-			continue
-		}
-
-		return response.StatusCode, nil
+	// retrying is left to the outbox, which backs off between the attempts
+	response, err := m.client.SendWithContext(ctx, message)
+	if err != nil {
+		return -1, err
+	}
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return response.StatusCode, fmt.Errorf("sendgrid responded with status %d: %s", response.StatusCode, response.Body)
 	}
 
-	return -1, fmt.Errorf("failed to send email after %d attempt, error: %v", maxRetries, retryErr)
+	return response.StatusCode, nil
 }
@@ -0,0 +1,62 @@
+package mailer
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/sendgrid/sendgrid-go"
+)
+
+// newTestSendGrid sends to a server answering every request with status
+func newTestSendGrid(t *testing.T, status int) (*SendGridMailer, *atomic.Int64) {
+	t.Helper()
+
+	var requests atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+
+	request := sendgrid.GetRequest("key", "/v3/mail/send", srv.URL)
+	request.Method = http.MethodPost
+
+	return &SendGridMailer{fromEmail: "hello@example.com", apiKey: "key", client: &sendgrid.Client{Request: request}}, &requests
+}
+
+func TestSendGrid(t *testing.T) {
+	vars := map[string]string{"Username": "gopher", "ActivationURL": "http://localhost:3000/confirm/token"}
+
+	send := func(m *SendGridMailer) (int, error) {
+		return m.Send(context.Background(), UserWelcomeTemplate, DefaultLocale, "gopher", "gopher@example.com", vars, false)
+	}
+
+	t.Run("Should send once when accepted", func(t *testing.T) {
+		m, requests := newTestSendGrid(t, http.StatusAccepted)
+
+		status, err := send(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if status != http.StatusAccepted || requests.Load() != 1 {
+			t.Errorf("expected a single accepted request, got status %d after %d requests", status, requests.Load())
+		}
+	})
+
+	t.Run("Should fail without retrying on a rejected request", func(t *testing.T) {
+		for _, code := range []int{http.StatusBadRequest, http.StatusTooManyRequests, http.StatusInternalServerError} {
+			m, requests := newTestSendGrid(t, code)
+
+			status, err := send(m)
+			if err == nil {
+				t.Errorf("%d: expected an error", code)
+			}
+			if status != code || requests.Load() != 1 {
+				t.Errorf("%d: expected a single request reporting its status, got status %d after %d requests", code, status, requests.Load())
+			}
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/orangeMangoDimz/go-social/internal/config"
+	gomail "gopkg.in/mail.v2"
+)
+
+// TLS modes of the SMTP connection
+const (
+	// TLSModeNone talks plain SMTP, for local sinks such as MailHog
+	TLSModeNone = "none"
+	// TLSModeStartTLS upgrades the connection and refuses servers without STARTTLS
+	TLSModeStartTLS = "starttls"
+	// TLSModeTLS connects over implicit TLS, usually on port 465
+	TLSModeTLS = "tls"
+)
+
+// SMTPClient delivers emails to any SMTP server. Unlike the hosted providers
+// it has no sandbox of its own, so it always sends and leaves it to the
+// server to decide what happens with the message.
+type SMTPClient struct {
+	fromEmail string
+	dialer    *gomail.Dialer
+}
+
+func NewSMTPClient(cfg config.SMTPConfig, fromEmail string) (*SMTPClient, error) {
+	if cfg.Host == "" {
+		return nil, errors.New("SMTP HOST IS REQUIRED")
+	}
+
+	dialer := gomail.NewDialer(cfg.Host, cfg.Port, cfg.Username, cfg.Password)
+
+	switch cfg.TLSMode {
+	case TLSModeNone:
+		dialer.SSL = false
+		dialer.StartTLSPolicy = gomail.NoStartTLS
+	case TLSModeStartTLS:
+		dialer.SSL = false
+		dialer.StartTLSPolicy = gomail.MandatoryStartTLS
+	case TLSModeTLS:
+		dialer.SSL = true
+	default:
+		return nil, fmt.Errorf("unknown SMTP TLS mode %q", cfg.TLSMode)
+	}
+
+	return &SMTPClient{
+		fromEmail: fromEmail,
+		dialer:    dialer,
+	}, nil
+}
+
+func (m *SMTPClient) Send(ctx context.Context, templateFile, locale, username, email string, data any, isSandbox bool) (int, error) {
+	rendered, err := Render(templateFile, locale, data)
+	if err != nil {
+		return -1, err
+	}
+
+	message := gomail.NewMessage()
+	message.SetAddressHeader("From", m.fromEmail, FromName)
+	message.SetAddressHeader("To", email, username)
+	message.SetHeader("Subject", rendered.Subject)
+
+	// clients show the last alternative they support, so HTML goes last
+	message.SetBody("text/plain", rendered.Text)
+	message.AddAlternative("text/html", rendered.HTML)
+
+	if err := m.dialer.DialAndSend(message); err != nil {
+		return -1, err
+	}
+
+	return 200, nil
+}
@@ -0,0 +1,114 @@
+package mailer
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// htmlToText derives the plaintext alternative of an HTML email. Block
+// elements become paragraphs, links keep their target next to the label and
+// everything that is not displayed, such as the head, is dropped.
+func htmlToText(s string) string {
+	var (
+		b        strings.Builder
+		hidden   int
+		href     string
+		linkText strings.Builder
+	)
+
+	z := html.NewTokenizer(strings.NewReader(s))
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return tidyText(b.String())
+
+		case html.TextToken:
+			if hidden > 0 {
+				continue
+			}
+			text := collapseSpace(string(z.Text()))
+			b.WriteString(text)
+			linkText.WriteString(text)
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			switch atom.Lookup(name) {
+			case atom.Head, atom.Title, atom.Style, atom.Script:
+				hidden++
+			case atom.Br:
+				b.WriteString("\n")
+			case atom.P, atom.Div, atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6, atom.Table, atom.Tr:
+				b.WriteString("\n\n")
+			case atom.Li:
+				b.WriteString("\n- ")
+			case atom.A:
+				href = ""
+				linkText.Reset()
+				for hasAttr {
+					var key, val []byte
+					key, val, hasAttr = z.TagAttr()
+					if string(key) == "href" {
+						href = string(val)
+					}
+				}
+			}
+
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			switch atom.Lookup(name) {
+			case atom.Head, atom.Title, atom.Style, atom.Script:
+				hidden = max(0, hidden-1)
+			case atom.P, atom.Div, atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6, atom.Table, atom.Tr:
+				b.WriteString("\n\n")
+			case atom.A:
+				// a link labelled with its own URL is written once
+				if href != "" && strings.TrimSpace(linkText.String()) != href {
+					b.WriteString(" (" + href + ")")
+				}
+				href = ""
+			}
+		}
+	}
+}
+
+// collapseSpace turns every run of whitespace into a single space, the way a
+// browser displays it.
+func collapseSpace(s string) string {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		if s == "" {
+			return ""
+		}
+		return " "
+	}
+
+	text := strings.Join(fields, " ")
+	if strings.TrimLeft(s, " \t\r\n") != s {
+		text = " " + text
+	}
+	if strings.TrimRight(s, " \t\r\n") != s {
+		text += " "
+	}
+	return text
+}
+
+// tidyText trims every line and keeps at most one blank line in a row.
+func tidyText(s string) string {
+	var lines []string
+	blank := false
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			if !blank && len(lines) > 0 {
+				lines = append(lines, "")
+			}
+			blank = true
+			continue
+		}
+		lines = append(lines, line)
+		blank = false
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
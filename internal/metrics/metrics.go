@@ -0,0 +1,89 @@
+// Package metrics defines the Prometheus metrics recorded by the API and the
+// handler exposing them.
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Cache lookup results
+const (
+	CacheHit  = "hit"
+	CacheMiss = "miss"
+)
+
+// Mailer send outcomes
+const (
+	MailSent   = "sent"
+	MailFailed = "failed"
+)
+
+// Metrics are the metrics recorded by the API.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	// HTTPRequests counts the requests served, by method, route pattern and status
+	HTTPRequests *prometheus.CounterVec
+	// HTTPDuration is the time taken to serve a request, by method, route pattern and status
+	HTTPDuration *prometheus.HistogramVec
+	// CacheRequests counts the cache lookups, by cache and result
+	CacheRequests *prometheus.CounterVec
+	// RateLimited counts the requests rejected by the rate limiter, by policy
+	RateLimited *prometheus.CounterVec
+	// MailSends counts the emails handed to the mailer, by template and outcome
+	MailSends *prometheus.CounterVec
+}
+
+// New creates the metrics of the API in a registry of their own, next to the
+// Go runtime and process collectors. The connection pool statistics of db are
+// read on every scrape and left out when db is nil.
+func New(db *sql.DB) *Metrics {
+	r := prometheus.NewRegistry()
+	factory := promauto.With(r)
+
+	m := &Metrics{
+		registry: r,
+		HTTPRequests: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "Total number of HTTP requests served.",
+		}, []string{"method", "route", "status"}),
+		HTTPDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "Time taken to serve HTTP requests in seconds.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		CacheRequests: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "cache_requests_total",
+			Help: "Total number of cache lookups.",
+		}, []string{"cache", "result"}),
+		RateLimited: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "ratelimit_rejected_requests_total",
+			Help: "Total number of requests rejected by the rate limiter.",
+		}, []string{"policy"}),
+		MailSends: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "mailer_sends_total",
+			Help: "Total number of emails handed to the mail provider.",
+		}, []string{"template", "outcome"}),
+	}
+
+	r.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	if db != nil {
+		r.MustRegister(collectors.NewDBStatsCollector(db, "social"))
+	}
+
+	return m
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
@@ -1,7 +1,7 @@
 // Package ratelimiter provides rate limiting functionality for controlling
-// the frequency of requests from clients. It implements a fixed window
-// rate limiting algorithm that tracks request counts per client over
-// specified time windows.
+// the frequency of requests from clients. It implements fixed window,
+// sliding window and token bucket algorithms that track request counts
+// per client over specified time windows.
 package ratelimiter
 
 import (
@@ -15,19 +15,27 @@ import (
 // are denied until the window resets.
 //
 // The limiter uses a simple in-memory map to track client request counts
-// and automatically resets the count for each client after the time window
-// expires. This implementation is suitable for single-instance applications
+// together with the moment their window ends. Expired clients are swept
+// inline while serving requests, so no goroutine is started per client.
+// This implementation is suitable for single-instance applications
 // but does not share state across multiple application instances.
 //
 // Thread-safety is ensured through the use of sync.RWMutex.
 type FixedWindowRateLimiter struct {
 	sync.RWMutex
-	// clients maps client identifiers (typically IP addresses) to their current request count
-	clients map[string]int
+	// clients maps client identifiers (typically IP addresses) to their current window
+	clients map[string]*fixedWindow
 	// limit is the maximum number of requests allowed per time window
 	limit int
 	// window is the duration of the time window for rate limiting
 	window time.Duration
+	// sweeper schedules the removal of expired clients
+	sweeper sweeper
+}
+
+type fixedWindow struct {
+	count   int
+	resetAt time.Time
 }
 
 // NewFixedWindowLimiter creates a new instance of FixedWindowRateLimiter
@@ -46,9 +54,10 @@ type FixedWindowRateLimiter struct {
 //	limiter := NewFixedWindowLimiter(100, time.Minute)
 func NewFixedWindowLimiter(limit int, window time.Duration) *FixedWindowRateLimiter {
 	return &FixedWindowRateLimiter{
-		clients: make(map[string]int),
+		clients: make(map[string]*fixedWindow),
 		limit:   limit,
 		window:  window,
+		sweeper: sweeper{interval: window},
 	}
 }
 
@@ -65,7 +74,7 @@ func NewFixedWindowLimiter(limit int, window time.Duration) *FixedWindowRateLimi
 //
 // Returns:
 //   - bool: true if the request should be allowed, false if rate limited
-//   - time.Duration: time until the client can make requests again (0 if allowed)
+//   - time.Duration: time until the window resets (0 if allowed)
 //
 // Example:
 //
@@ -74,40 +83,53 @@ func NewFixedWindowLimiter(limit int, window time.Duration) *FixedWindowRateLimi
 //	    // Request should be denied, client should retry after retryAfter duration
 //	}
 func (rl *FixedWindowRateLimiter) Allow(ip string) (bool, time.Duration) {
+	res := rl.Take(ip)
+	return res.Allowed, res.RetryAfter
+}
+
+// Take counts a request like Allow and reports the remaining quota of the
+// client and the time until its window resets.
+//
+// Parameters:
+//   - ip: Client identifier (typically an IP address)
+//
+// Returns:
+//   - Result: the decision together with the remaining quota
+func (rl *FixedWindowRateLimiter) Take(ip string) Result {
 	rl.Lock()
 	defer rl.Unlock()
 
-	count, exists := rl.clients[ip]
+	now := time.Now()
+	rl.evictExpired(now)
 
-	if !exists {
-		// First request for this IP, start the reset timer
-		rl.clients[ip] = 1
-		go rl.resetCount(ip)
-		return true, 0
+	client, exists := rl.clients[ip]
+	if !exists || !now.Before(client.resetAt) {
+		// First request for this IP or its window is over, start a new one
+		client = &fixedWindow{resetAt: now.Add(rl.window)}
+		rl.clients[ip] = client
 	}
 
-	if count < rl.limit {
+	reset := client.resetAt.Sub(now)
+	if client.count < rl.limit {
 		// Still within limit, increment and allow
-		rl.clients[ip]++
-		return true, 0
+		client.count++
+		return Result{Allowed: true, Limit: rl.limit, Remaining: rl.limit - client.count, Reset: reset}
 	}
 
 	// Limit exceeded
-	return false, rl.window
+	return Result{Limit: rl.limit, Reset: reset, RetryAfter: reset}
 }
 
-// resetCount removes the client from the tracking map after the time window
-// has elapsed. This method is called as a goroutine to automatically clean up
-// client entries and reset their rate limiting window.
-//
-// This approach ensures that clients get a fresh start after each time window
-// without requiring continuous background cleanup processes.
-//
-// Parameters:
-//   - ip: Client identifier to reset
-func (rl *FixedWindowRateLimiter) resetCount(ip string) {
-	time.Sleep(rl.window)
-	rl.Lock()
-	delete(rl.clients, ip)
-	rl.Unlock()
+// evictExpired removes the clients whose window has ended. It only walks the
+// map once per window, so the cost is spread over many requests.
+func (rl *FixedWindowRateLimiter) evictExpired(now time.Time) {
+	if !rl.sweeper.due(now) {
+		return
+	}
+
+	for ip, client := range rl.clients {
+		if !now.Before(client.resetAt) {
+			delete(rl.clients, ip)
+		}
+	}
 }
@@ -0,0 +1,101 @@
+package ratelimiter
+
+import "time"
+
+// LimiterFactory creates the Limiter enforcing the limit and time frame of
+// a configuration, for instance NewInMemoryLimiter.
+type LimiterFactory func(Config) (Limiter, error)
+
+// Registry holds the limiters of every configured policy. Each policy gets
+// its own limiter, plus a second one for its elevated quota when it has one,
+// so clients are counted separately per policy and per tier.
+type Registry struct {
+	limiters map[string]Limiter
+}
+
+// NewRegistry creates a limiter for each policy of the configuration. The
+// top level RequestPerTimeFrame and TimeFrame form PolicyDefault unless
+// cfg.Policies overrides it.
+//
+// Parameters:
+//   - cfg: Rate limiter configuration
+//   - factory: Creates the limiter of a single policy
+//
+// Returns:
+//   - *Registry: The limiters of every policy
+//   - error: The first error returned by the factory
+//
+// Example:
+//
+//	registry, err := NewRegistry(cfg, NewInMemoryLimiter)
+//	limiter := registry.Limiter(PolicyAuth, false)
+func NewRegistry(cfg Config, factory LimiterFactory) (*Registry, error) {
+	policies := map[string]Policy{
+		PolicyDefault: {
+			RequestPerTimeFrame: cfg.RequestPerTimeFrame,
+			TimeFrame:           cfg.TimeFrame,
+		},
+	}
+	for name, policy := range cfg.Policies {
+		policies[name] = policy
+	}
+
+	registry := &Registry{
+		limiters: make(map[string]Limiter),
+	}
+
+	for name, policy := range policies {
+		limiter, err := factory(policyConfig(cfg, policy.RequestPerTimeFrame, policy.TimeFrame))
+		if err != nil {
+			return nil, err
+		}
+		registry.limiters[registryKey(name, false)] = limiter
+
+		if policy.ElevatedRequestPerTimeFrame > 0 {
+			limiter, err := factory(policyConfig(cfg, policy.ElevatedRequestPerTimeFrame, policy.TimeFrame))
+			if err != nil {
+				return nil, err
+			}
+			registry.limiters[registryKey(name, true)] = limiter
+		}
+	}
+
+	return registry, nil
+}
+
+// Limiter returns the limiter of the named policy. Unknown policies resolve
+// to PolicyDefault and policies without an elevated quota ignore elevated.
+//
+// Parameters:
+//   - policy: Name of the policy
+//   - elevated: Whether the client is entitled to the elevated quota
+//
+// Returns:
+//   - Limiter: The limiter enforcing the policy
+func (r *Registry) Limiter(policy string, elevated bool) Limiter {
+	if elevated {
+		if limiter, ok := r.limiters[registryKey(policy, true)]; ok {
+			return limiter
+		}
+	}
+
+	if limiter, ok := r.limiters[registryKey(policy, false)]; ok {
+		return limiter
+	}
+
+	return r.Limiter(PolicyDefault, elevated)
+}
+
+func policyConfig(cfg Config, limit int, timeFrame time.Duration) Config {
+	cfg.RequestPerTimeFrame = limit
+	cfg.TimeFrame = timeFrame
+	cfg.Policies = nil
+	return cfg
+}
+
+func registryKey(policy string, elevated bool) string {
+	if elevated {
+		return policy + ":elevated"
+	}
+	return policy
+}
@@ -4,7 +4,11 @@
 // implementations should follow.
 package ratelimiter
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
 // Limiter defines the interface that all rate limiter implementations must satisfy.
 // This interface provides a common contract for different rate limiting algorithms
@@ -31,8 +35,77 @@ type Limiter interface {
 	//   - Token bucket algorithm
 	//   - Leaky bucket algorithm
 	Allow(string) (bool, time.Duration)
+
+	// Take counts a request exactly like Allow but reports the full state of
+	// the client's quota, which is what the RateLimit response headers expose.
+	//
+	// Parameters:
+	//   - string: Client identifier (typically an IP address, user ID, or API key)
+	//
+	// Returns:
+	//   - Result: the decision together with the remaining quota
+	Take(string) Result
 }
 
+// Result describes the outcome of a rate limiting decision.
+type Result struct {
+	// Allowed reports whether the request may proceed
+	Allowed bool
+
+	// Limit is the number of requests allowed per time frame
+	Limit int
+
+	// Remaining is the number of requests the client can still make right now
+	Remaining int
+
+	// Reset is the time until the quota is replenished. When the request is
+	// denied it is the time until the next request will be allowed.
+	Reset time.Duration
+
+	// RetryAfter is the time to wait before retrying (0 if the request is allowed)
+	RetryAfter time.Duration
+}
+
+// Available values for Config.Backend
+const (
+	BackendMemory = "memory"
+	BackendRedis  = "redis"
+)
+
+// Available values for Config.Algorithm
+const (
+	AlgorithmFixedWindow   = "fixed-window"
+	AlgorithmSlidingWindow = "sliding-window"
+	AlgorithmTokenBucket   = "token-bucket"
+)
+
+// Names of the policies attached to the route groups
+const (
+	// PolicyDefault applies to reads and anything without a more specific policy
+	PolicyDefault = "default"
+	// PolicyAuth applies to the credential endpoints, targets of brute force
+	PolicyAuth = "auth"
+	// PolicyWrite applies to requests creating or changing content
+	PolicyWrite = "write"
+)
+
+// Policy is a named quota applied to a group of routes.
+type Policy struct {
+	// RequestPerTimeFrame is the maximum number of requests per TimeFrame
+	RequestPerTimeFrame int
+
+	// TimeFrame is the duration of the rate limiting window
+	TimeFrame time.Duration
+
+	// ElevatedRequestPerTimeFrame is the quota of admin users. Zero keeps
+	// admins on RequestPerTimeFrame.
+	ElevatedRequestPerTimeFrame int
+}
+
+// ErrUnknownAlgorithm is returned when Config.Algorithm names an algorithm
+// that has no implementation.
+var ErrUnknownAlgorithm = errors.New("unknown rate limiter algorithm")
+
 // Config holds the configuration parameters for rate limiting functionality.
 // This struct is used to configure rate limiter instances with the desired
 // limits and time frames, and to enable or disable rate limiting entirely.
@@ -50,9 +123,62 @@ type Config struct {
 	// Common values include time.Minute, time.Hour, or custom durations.
 	TimeFrame time.Duration
 
+	// Algorithm selects how requests are counted, one of AlgorithmFixedWindow,
+	// AlgorithmSlidingWindow or AlgorithmTokenBucket. An empty value means
+	// AlgorithmFixedWindow.
+	Algorithm string
+
+	// Backend selects where the request counts are kept. BackendMemory keeps
+	// them in the process, which is enough for a single instance, while
+	// BackendRedis shares them across every instance of the application.
+	Backend string
+
+	// Policies holds named quotas that route groups opt into. A policy that
+	// is not listed falls back to RequestPerTimeFrame and TimeFrame.
+	Policies map[string]Policy
+
 	// Enabled determines whether rate limiting is active. When set to false,
 	// rate limiting is disabled and all requests are allowed through.
 	// This is useful for development environments or when temporarily
 	// disabling rate limiting without changing the application code.
 	Enabled bool
 }
+
+// NewInMemoryLimiter creates the in-process Limiter for the algorithm
+// selected in the configuration.
+//
+// Parameters:
+//   - cfg: Rate limiter configuration
+//
+// Returns:
+//   - Limiter: A new rate limiter instance
+//   - error: ErrUnknownAlgorithm if cfg.Algorithm is not supported
+func NewInMemoryLimiter(cfg Config) (Limiter, error) {
+	switch cfg.Algorithm {
+	case "", AlgorithmFixedWindow:
+		return NewFixedWindowLimiter(cfg.RequestPerTimeFrame, cfg.TimeFrame), nil
+	case AlgorithmSlidingWindow:
+		return NewSlidingWindowLimiter(cfg.RequestPerTimeFrame, cfg.TimeFrame), nil
+	case AlgorithmTokenBucket:
+		return NewTokenBucketLimiter(cfg.RequestPerTimeFrame, cfg.TimeFrame), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownAlgorithm, cfg.Algorithm)
+	}
+}
+
+// sweeper decides when a limiter should walk its clients to drop the expired
+// ones. The sweep happens inline on a request at most once per interval,
+// which keeps memory bounded without a goroutine per client.
+type sweeper struct {
+	interval time.Duration
+	last     time.Time
+}
+
+// due reports whether a sweep should run now and, if so, records it.
+func (s *sweeper) due(now time.Time) bool {
+	if now.Sub(s.last) < s.interval {
+		return false
+	}
+	s.last = now
+	return true
+}
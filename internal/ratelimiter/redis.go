@@ -0,0 +1,290 @@
+package ratelimiter
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// redisKeyPrefix namespaces the rate limiter counters in Redis
+	redisKeyPrefix = "ratelimit"
+
+	// redisTimeout bounds every round trip to Redis so a slow server
+	// cannot stall the request it is guarding
+	redisTimeout = 100 * time.Millisecond
+
+	// redisCooldown is how long the limiter stays on the fallback after
+	// Redis failed, instead of paying the timeout on every request
+	redisCooldown = 5 * time.Second
+)
+
+// The scripts below run atomically inside Redis, so every API instance sees
+// the same state. They share one calling convention:
+//
+// KEYS[1] - state key of the client
+// ARGV[1] - maximum number of requests per window
+// ARGV[2] - window length in milliseconds
+//
+// and return {allowed, retry_after_ms, remaining, reset_ms} where allowed is
+// 1 or 0, with the same meaning as the fields of Result. Timestamps
+// come from the Redis clock so instances with skewed clocks still agree.
+
+// fixedWindowScript counts the requests of the client and starts its window
+// on the first hit.
+var fixedWindowScript = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+
+local count = redis.call("INCR", KEYS[1])
+local ttl = redis.call("PTTL", KEYS[1])
+if count == 1 or ttl < 0 then
+	redis.call("PEXPIRE", KEYS[1], window)
+	ttl = window
+end
+
+if count > limit then
+	return {0, ttl, 0, ttl}
+end
+return {1, 0, limit - count, ttl}
+`)
+
+// slidingWindowScript keeps the counts of the current and previous fixed
+// windows in a hash and weights the previous one by its overlap with the
+// sliding window. Mirrors SlidingWindowRateLimiter.
+var slidingWindowScript = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+
+local t = redis.call("TIME")
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+local start = now - (now % window)
+
+local state = redis.call("HMGET", KEYS[1], "start", "curr", "prev")
+local last = tonumber(state[1])
+local curr = tonumber(state[2]) or 0
+local prev = tonumber(state[3]) or 0
+if last == nil or last < start - window then
+	curr, prev = 0, 0
+elseif last == start - window then
+	prev = curr
+	curr = 0
+end
+
+local elapsed = now - start
+local free = limit - 1
+local allowed, retry, remaining, reset = 1, 0, 0, window - elapsed
+if prev * (window - elapsed) / window + curr <= free then
+	curr = curr + 1
+	remaining = math.max(0, math.floor(limit - (prev * (window - elapsed) / window + curr)))
+elseif curr <= free then
+	allowed = 0
+	retry = math.ceil((window - elapsed) - (free - curr) * window / prev)
+	reset = retry
+else
+	allowed = 0
+	retry = math.ceil((window - elapsed) + math.max(0, window - free * window / curr))
+	reset = retry
+end
+
+redis.call("HSET", KEYS[1], "start", start, "curr", curr, "prev", prev)
+redis.call("PEXPIRE", KEYS[1], 2 * window)
+return {allowed, retry, remaining, reset}
+`)
+
+// tokenBucketScript refills the bucket of the client according to the time
+// elapsed since its last request and takes one token. Mirrors
+// TokenBucketRateLimiter.
+var tokenBucketScript = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local rate = limit / window
+
+local t = redis.call("TIME")
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+
+local state = redis.call("HMGET", KEYS[1], "tokens", "last")
+local tokens = tonumber(state[1]) or limit
+local last = tonumber(state[2]) or now
+tokens = math.min(limit, tokens + (now - last) * rate)
+
+local allowed, retry, remaining, reset = 1, 0, 0, 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	remaining = math.floor(tokens)
+	reset = math.ceil((limit - tokens) / rate)
+else
+	allowed = 0
+	retry = math.ceil((1 - tokens) / rate)
+	reset = retry
+end
+
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "last", now)
+redis.call("PEXPIRE", KEYS[1], window)
+return {allowed, retry, remaining, reset}
+`)
+
+// RedisRateLimiter implements the configured rate limiting algorithm with its
+// state in Redis. Unlike the in-memory limiters, the state is shared
+// by every application instance pointing at the same Redis, so the limit holds
+// for the whole deployment instead of per replica.
+//
+// When Redis cannot be reached the limiter degrades to the fallback Limiter
+// and keeps using it for a short cooldown before trying Redis again.
+type RedisRateLimiter struct {
+	// rdb runs the Lua scripts, a *redis.Client in production
+	rdb redis.Scripter
+	// script implements the configured algorithm
+	script *redis.Script
+	// prefix namespaces the keys of the algorithm, whose state layouts differ
+	prefix string
+	// fallback handles requests while Redis is unavailable
+	fallback Limiter
+	// limit is the maximum number of requests allowed per time window
+	limit int
+	// window is the duration of the time window for rate limiting
+	window time.Duration
+
+	mu sync.Mutex
+	// downUntil is the moment Redis will be tried again after a failure
+	downUntil time.Time
+}
+
+// NewRedisLimiter creates a new instance of RedisRateLimiter for the
+// algorithm, limit and time window of the configuration.
+//
+// Parameters:
+//   - rdb: Redis client used to run the Lua scripts
+//   - cfg: Rate limiter configuration
+//   - fallback: Limiter used while Redis is unavailable
+//
+// Returns:
+//   - *RedisRateLimiter: A new rate limiter instance
+//   - error: ErrUnknownAlgorithm if cfg.Algorithm is not supported
+//
+// Example:
+//
+//	// Allow 100 requests per minute across every instance
+//	cfg := Config{RequestPerTimeFrame: 100, TimeFrame: time.Minute}
+//	limiter, err := NewRedisLimiter(rdb, cfg, NewFixedWindowLimiter(100, time.Minute))
+func NewRedisLimiter(rdb redis.Scripter, cfg Config, fallback Limiter) (*RedisRateLimiter, error) {
+	algorithm := cfg.Algorithm
+	if algorithm == "" {
+		algorithm = AlgorithmFixedWindow
+	}
+
+	script, err := redisScript(algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RedisRateLimiter{
+		rdb:      rdb,
+		script:   script,
+		prefix:   fmt.Sprintf("%s:%s", redisKeyPrefix, algorithm),
+		fallback: fallback,
+		limit:    cfg.RequestPerTimeFrame,
+		window:   cfg.TimeFrame,
+	}, nil
+}
+
+// NewRedisFactory returns a LimiterFactory creating Redis limiters that fall
+// back to an in-memory limiter of the same configuration.
+//
+// Parameters:
+//   - rdb: Redis client used to run the Lua scripts
+//
+// Returns:
+//   - LimiterFactory: Factory to pass to NewRegistry
+func NewRedisFactory(rdb redis.Scripter) LimiterFactory {
+	return func(cfg Config) (Limiter, error) {
+		fallback, err := NewInMemoryLimiter(cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		limiter, err := NewRedisLimiter(rdb, cfg, fallback)
+		if err != nil {
+			return nil, err
+		}
+
+		return limiter, nil
+	}
+}
+
+func redisScript(algorithm string) (*redis.Script, error) {
+	switch algorithm {
+	case AlgorithmFixedWindow:
+		return fixedWindowScript, nil
+	case AlgorithmSlidingWindow:
+		return slidingWindowScript, nil
+	case AlgorithmTokenBucket:
+		return tokenBucketScript, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownAlgorithm, algorithm)
+	}
+}
+
+// Allow checks if a request from the specified client should be allowed
+// based on the state stored in Redis. If Redis returns an error the
+// decision is delegated to the fallback limiter.
+//
+// Parameters:
+//   - ip: Client identifier (typically an IP address)
+//
+// Returns:
+//   - bool: true if the request should be allowed, false if rate limited
+//   - time.Duration: time until the request would be allowed (0 if allowed)
+func (rl *RedisRateLimiter) Allow(ip string) (bool, time.Duration) {
+	res := rl.Take(ip)
+	return res.Allowed, res.RetryAfter
+}
+
+// Take counts a request like Allow and reports the remaining quota stored
+// in Redis, or the one of the fallback limiter while Redis is unavailable.
+//
+// Parameters:
+//   - ip: Client identifier (typically an IP address)
+//
+// Returns:
+//   - Result: the decision together with the remaining quota
+func (rl *RedisRateLimiter) Take(ip string) Result {
+	if rl.isDown() {
+		return rl.fallback.Take(ip)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	key := fmt.Sprintf("%s:%s", rl.prefix, ip)
+	res, err := rl.script.Run(ctx, rl.rdb, []string{key}, rl.limit, rl.window.Milliseconds()).Int64Slice()
+	if err != nil || len(res) != 4 {
+		rl.markDown()
+		return rl.fallback.Take(ip)
+	}
+
+	return Result{
+		Allowed:    res[0] == 1,
+		Limit:      rl.limit,
+		Remaining:  int(res[2]),
+		Reset:      time.Duration(res[3]) * time.Millisecond,
+		RetryAfter: time.Duration(res[1]) * time.Millisecond,
+	}
+}
+
+func (rl *RedisRateLimiter) isDown() bool {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	return time.Now().Before(rl.downUntil)
+}
+
+func (rl *RedisRateLimiter) markDown() {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.downUntil = time.Now().Add(redisCooldown)
+}
@@ -0,0 +1,197 @@
+package ratelimiter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// testRedis runs the scripts in miniredis, whose clock and key expiry the
+// tests move forward by hand.
+type testRedis struct {
+	*miniredis.Miniredis
+	rdb *redis.Client
+	now time.Time
+}
+
+func newTestRedis(t *testing.T) *testRedis {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+
+	// start on a window boundary so the expected values are easy to follow
+	now := time.UnixMilli(1_700_000_000_000)
+	mr.SetTime(now)
+
+	return &testRedis{Miniredis: mr, rdb: rdb, now: now}
+}
+
+// advance moves both the clock read by TIME and the key expiry forward.
+func (r *testRedis) advance(d time.Duration) {
+	r.now = r.now.Add(d)
+	r.SetTime(r.now)
+	r.FastForward(d)
+}
+
+func (r *testRedis) limiter(t *testing.T, algorithm string, limit int, window time.Duration) *RedisRateLimiter {
+	t.Helper()
+
+	cfg := Config{RequestPerTimeFrame: limit, TimeFrame: window, Algorithm: algorithm}
+	fallback, err := NewInMemoryLimiter(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	limiter, err := NewRedisLimiter(r.rdb, cfg, fallback)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return limiter
+}
+
+func checkResult(t *testing.T, got, want Result) {
+	t.Helper()
+
+	if got != want {
+		t.Errorf("expected %+v; got %+v", want, got)
+	}
+}
+
+func TestRedisFixedWindow(t *testing.T) {
+	const limit, window = 3, 5 * time.Second
+
+	t.Run("Should allow the limit and deny until the window ends", func(t *testing.T) {
+		r := newTestRedis(t)
+		limiter := r.limiter(t, AlgorithmFixedWindow, limit, window)
+
+		for remaining := limit - 1; remaining >= 0; remaining-- {
+			checkResult(t, limiter.Take("client"), Result{Allowed: true, Limit: limit, Remaining: remaining, Reset: window})
+		}
+		checkResult(t, limiter.Take("client"), Result{Limit: limit, Reset: window, RetryAfter: window})
+
+		r.advance(2 * time.Second)
+		checkResult(t, limiter.Take("client"), Result{Limit: limit, Reset: 3 * time.Second, RetryAfter: 3 * time.Second})
+
+		r.advance(3 * time.Second)
+		checkResult(t, limiter.Take("client"), Result{Allowed: true, Limit: limit, Remaining: limit - 1, Reset: window})
+	})
+
+	t.Run("Should count every client on its own", func(t *testing.T) {
+		r := newTestRedis(t)
+		limiter := r.limiter(t, AlgorithmFixedWindow, limit, window)
+
+		for range limit {
+			limiter.Take("client")
+		}
+		checkResult(t, limiter.Take("other"), Result{Allowed: true, Limit: limit, Remaining: limit - 1, Reset: window})
+	})
+}
+
+func TestRedisFallback(t *testing.T) {
+	const limit, window = 2, 5 * time.Second
+
+	t.Run("Should use the fallback while Redis fails", func(t *testing.T) {
+		r := newTestRedis(t)
+		limiter := r.limiter(t, AlgorithmFixedWindow, limit, window)
+		r.SetError("LOADING Redis is loading the dataset in memory")
+
+		for range limit {
+			if res := limiter.Take("client"); !res.Allowed {
+				t.Fatalf("expected the fallback to allow the request; got %+v", res)
+			}
+		}
+		if res := limiter.Take("client"); res.Allowed || res.RetryAfter <= 0 {
+			t.Errorf("expected the fallback to deny the request with a retry-after; got %+v", res)
+		}
+
+		if keys := r.Keys(); len(keys) != 0 {
+			t.Errorf("expected no state in Redis; got %v", keys)
+		}
+	})
+
+	t.Run("Should stay on the fallback for the cooldown", func(t *testing.T) {
+		r := newTestRedis(t)
+		limiter := r.limiter(t, AlgorithmFixedWindow, limit, window)
+
+		r.SetError("LOADING Redis is loading the dataset in memory")
+		limiter.Take("client")
+		r.SetError("")
+		limiter.Take("client")
+
+		if keys := r.Keys(); len(keys) != 0 {
+			t.Errorf("expected Redis not to be tried again yet; got %v", keys)
+		}
+	})
+}
+
+func TestRedisSlidingWindow(t *testing.T) {
+	const limit, window = 4, 10 * time.Second
+
+	t.Run("Should weight the previous window by its overlap", func(t *testing.T) {
+		r := newTestRedis(t)
+		limiter := r.limiter(t, AlgorithmSlidingWindow, limit, window)
+
+		for remaining := limit - 1; remaining >= 0; remaining-- {
+			checkResult(t, limiter.Take("client"), Result{Allowed: true, Limit: limit, Remaining: remaining, Reset: window})
+		}
+
+		// the 4 requests become the previous window, they weigh 3 once a
+		// quarter of the next window has passed
+		wait := window + window/4
+		checkResult(t, limiter.Take("client"), Result{Limit: limit, Reset: wait, RetryAfter: wait})
+
+		r.advance(wait)
+		checkResult(t, limiter.Take("client"), Result{Allowed: true, Limit: limit, Remaining: 0, Reset: window - window/4})
+
+		// 3 + 1 requests, the previous window has to weigh 2 at most
+		checkResult(t, limiter.Take("client"), Result{Limit: limit, Reset: window / 4, RetryAfter: window / 4})
+	})
+
+	t.Run("Should forget the counts after two windows", func(t *testing.T) {
+		r := newTestRedis(t)
+		limiter := r.limiter(t, AlgorithmSlidingWindow, limit, window)
+
+		for range limit {
+			limiter.Take("client")
+		}
+
+		r.advance(2 * window)
+		checkResult(t, limiter.Take("client"), Result{Allowed: true, Limit: limit, Remaining: limit - 1, Reset: window})
+	})
+}
+
+func TestRedisTokenBucket(t *testing.T) {
+	// a token is refilled every 2 seconds
+	const limit, window = 4, 8 * time.Second
+	const refill = window / limit
+
+	t.Run("Should allow a burst and refill a token at a time", func(t *testing.T) {
+		r := newTestRedis(t)
+		limiter := r.limiter(t, AlgorithmTokenBucket, limit, window)
+
+		for remaining := limit - 1; remaining >= 0; remaining-- {
+			used := time.Duration(limit-remaining) * refill
+			checkResult(t, limiter.Take("client"), Result{Allowed: true, Limit: limit, Remaining: remaining, Reset: used})
+		}
+		checkResult(t, limiter.Take("client"), Result{Limit: limit, Reset: refill, RetryAfter: refill})
+
+		r.advance(refill / 2)
+		checkResult(t, limiter.Take("client"), Result{Limit: limit, Reset: refill / 2, RetryAfter: refill / 2})
+
+		r.advance(refill / 2)
+		checkResult(t, limiter.Take("client"), Result{Allowed: true, Limit: limit, Remaining: 0, Reset: window})
+	})
+
+	t.Run("Should not refill past the limit", func(t *testing.T) {
+		r := newTestRedis(t)
+		limiter := r.limiter(t, AlgorithmTokenBucket, limit, window)
+
+		limiter.Take("client")
+		r.advance(window / 2)
+		checkResult(t, limiter.Take("client"), Result{Allowed: true, Limit: limit, Remaining: limit - 1, Reset: refill})
+	})
+}
@@ -0,0 +1,158 @@
+package ratelimiter
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// SlidingWindowRateLimiter implements a sliding window counter algorithm.
+// It keeps the request count of the current and of the previous fixed window
+// and weights the previous one by how much of it still overlaps the sliding
+// window ending now. This removes the burst of up to twice the limit that a
+// fixed window allows around its boundary, while only storing two counters
+// per client.
+//
+// Thread-safety is ensured through the use of sync.Mutex.
+type SlidingWindowRateLimiter struct {
+	sync.Mutex
+	// clients maps client identifiers (typically IP addresses) to their counters
+	clients map[string]*slidingWindow
+	// limit is the maximum number of requests allowed per sliding window
+	limit int
+	// window is the duration of the sliding window
+	window time.Duration
+	// sweeper schedules the removal of expired clients
+	sweeper sweeper
+}
+
+type slidingWindow struct {
+	// start is the beginning of the current fixed window
+	start time.Time
+	curr  int
+	prev  int
+}
+
+// NewSlidingWindowLimiter creates a new instance of SlidingWindowRateLimiter
+// with the specified limit and window.
+//
+// Parameters:
+//   - limit: Maximum number of requests allowed in any window of the given length
+//   - window: Length of the sliding window (e.g., time.Minute, time.Hour)
+//
+// Returns:
+//   - *SlidingWindowRateLimiter: A new rate limiter instance
+//
+// Example:
+//
+//	// Allow 100 requests in any rolling minute
+//	limiter := NewSlidingWindowLimiter(100, time.Minute)
+func NewSlidingWindowLimiter(limit int, window time.Duration) *SlidingWindowRateLimiter {
+	return &SlidingWindowRateLimiter{
+		clients: make(map[string]*slidingWindow),
+		limit:   limit,
+		window:  window,
+		sweeper: sweeper{interval: window},
+	}
+}
+
+// Allow checks if a request from the specified client fits in the sliding
+// window ending now.
+//
+// Parameters:
+//   - ip: Client identifier (typically an IP address)
+//
+// Returns:
+//   - bool: true if the request should be allowed, false if rate limited
+//   - time.Duration: time until the request would fit in the window (0 if allowed)
+func (rl *SlidingWindowRateLimiter) Allow(ip string) (bool, time.Duration) {
+	res := rl.Take(ip)
+	return res.Allowed, res.RetryAfter
+}
+
+// Take counts a request like Allow and reports how many more requests fit
+// in the sliding window and the time until the current fixed window ends.
+//
+// Parameters:
+//   - ip: Client identifier (typically an IP address)
+//
+// Returns:
+//   - Result: the decision together with the remaining quota
+func (rl *SlidingWindowRateLimiter) Take(ip string) Result {
+	rl.Lock()
+	defer rl.Unlock()
+
+	now := time.Now()
+	rl.evictExpired(now)
+
+	start := now.Truncate(rl.window)
+	client, exists := rl.clients[ip]
+	if !exists {
+		client = &slidingWindow{start: start}
+		rl.clients[ip] = client
+	}
+	client.advance(start, rl.window)
+
+	elapsed := now.Sub(start)
+	allowed, retryAfter := slidingWindowDecision(client.prev, client.curr, rl.limit, elapsed, rl.window)
+	if !allowed {
+		return Result{Limit: rl.limit, Reset: retryAfter, RetryAfter: retryAfter}
+	}
+
+	client.curr++
+	used := float64(client.prev)*float64(rl.window-elapsed)/float64(rl.window) + float64(client.curr)
+	remaining := max(0, int(math.Floor(float64(rl.limit)-used)))
+
+	return Result{Allowed: true, Limit: rl.limit, Remaining: remaining, Reset: rl.window - elapsed}
+}
+
+// advance moves the counters forward when one or more windows have passed
+// since the client was last seen.
+func (c *slidingWindow) advance(start time.Time, window time.Duration) {
+	switch {
+	case c.start.Equal(start):
+	case c.start.Add(window).Equal(start):
+		c.prev, c.curr = c.curr, 0
+	default:
+		c.prev, c.curr = 0, 0
+	}
+	c.start = start
+}
+
+// slidingWindowDecision weights the previous window by its overlap with the
+// sliding window and, when the request does not fit, works out how long it
+// takes for enough of the previous window to slide out.
+func slidingWindowDecision(prev, curr, limit int, elapsed, window time.Duration) (bool, time.Duration) {
+	w := float64(window)
+	e := float64(elapsed)
+	free := float64(limit - 1)
+
+	if float64(prev)*(w-e)/w+float64(curr) <= free {
+		return true, 0
+	}
+
+	var wait float64
+	if curr <= limit-1 {
+		// Room left in the current window once the previous one fades enough
+		wait = (w - e) - (free-float64(curr))*w/float64(prev)
+	} else {
+		// Wait for the next window, where the current count becomes the previous one
+		wait = (w - e) + math.Max(0, w-free*w/float64(curr))
+	}
+
+	return false, time.Duration(math.Ceil(wait))
+}
+
+// evictExpired removes the clients that have not been seen for two windows,
+// at which point both counters are irrelevant.
+func (rl *SlidingWindowRateLimiter) evictExpired(now time.Time) {
+	if !rl.sweeper.due(now) {
+		return
+	}
+
+	for ip, client := range rl.clients {
+		if !now.Before(client.start.Add(2 * rl.window)) {
+			delete(rl.clients, ip)
+		}
+	}
+}
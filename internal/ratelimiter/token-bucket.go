@@ -0,0 +1,125 @@
+package ratelimiter
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// TokenBucketRateLimiter implements a token bucket algorithm. Every client
+// owns a bucket holding up to limit tokens, refilled continuously at
+// limit tokens per window. Each request takes one token, so clients can
+// burst up to the bucket size and are then paced at the refill rate.
+//
+// Thread-safety is ensured through the use of sync.Mutex.
+type TokenBucketRateLimiter struct {
+	sync.Mutex
+	// clients maps client identifiers (typically IP addresses) to their bucket
+	clients map[string]*bucket
+	// limit is the bucket capacity and the number of tokens refilled per window
+	limit int
+	// window is the time it takes to refill an empty bucket
+	window time.Duration
+	// sweeper schedules the removal of full buckets
+	sweeper sweeper
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewTokenBucketLimiter creates a new instance of TokenBucketRateLimiter
+// with the specified capacity and refill window.
+//
+// Parameters:
+//   - limit: Bucket capacity, also the number of tokens refilled per window
+//   - window: Time it takes to refill an empty bucket
+//
+// Returns:
+//   - *TokenBucketRateLimiter: A new rate limiter instance
+//
+// Example:
+//
+//	// Bursts of up to 100 requests, refilled at 100 requests per minute
+//	limiter := NewTokenBucketLimiter(100, time.Minute)
+func NewTokenBucketLimiter(limit int, window time.Duration) *TokenBucketRateLimiter {
+	return &TokenBucketRateLimiter{
+		clients: make(map[string]*bucket),
+		limit:   limit,
+		window:  window,
+		sweeper: sweeper{interval: window},
+	}
+}
+
+// Allow takes a token from the bucket of the specified client.
+//
+// Parameters:
+//   - ip: Client identifier (typically an IP address)
+//
+// Returns:
+//   - bool: true if the request should be allowed, false if rate limited
+//   - time.Duration: time until the next token is available (0 if allowed)
+func (rl *TokenBucketRateLimiter) Allow(ip string) (bool, time.Duration) {
+	res := rl.Take(ip)
+	return res.Allowed, res.RetryAfter
+}
+
+// Take takes a token like Allow and reports the tokens left in the bucket
+// and the time until it is full again.
+//
+// Parameters:
+//   - ip: Client identifier (typically an IP address)
+//
+// Returns:
+//   - Result: the decision together with the remaining quota
+func (rl *TokenBucketRateLimiter) Take(ip string) Result {
+	rl.Lock()
+	defer rl.Unlock()
+
+	now := time.Now()
+	rl.evictExpired(now)
+
+	b, exists := rl.clients[ip]
+	if !exists {
+		b = &bucket{tokens: float64(rl.limit), last: now}
+		rl.clients[ip] = b
+	}
+
+	rate := rl.rate()
+	b.tokens = math.Min(float64(rl.limit), b.tokens+float64(now.Sub(b.last))*rate)
+	b.last = now
+
+	if b.tokens < 1 {
+		retryAfter := time.Duration(math.Ceil((1 - b.tokens) / rate))
+		return Result{Limit: rl.limit, Reset: retryAfter, RetryAfter: retryAfter}
+	}
+
+	b.tokens--
+	return Result{
+		Allowed:   true,
+		Limit:     rl.limit,
+		Remaining: int(b.tokens),
+		Reset:     time.Duration(math.Ceil((float64(rl.limit) - b.tokens) / rate)),
+	}
+}
+
+// rate returns the refill rate in tokens per nanosecond
+func (rl *TokenBucketRateLimiter) rate() float64 {
+	return float64(rl.limit) / float64(rl.window)
+}
+
+// evictExpired removes the buckets that are full again, which behave exactly
+// like a bucket for a client that was never seen.
+func (rl *TokenBucketRateLimiter) evictExpired(now time.Time) {
+	if !rl.sweeper.due(now) {
+		return
+	}
+
+	rate := rl.rate()
+	for ip, b := range rl.clients {
+		if b.tokens+float64(now.Sub(b.last))*rate >= float64(rl.limit) {
+			delete(rl.clients, ip)
+		}
+	}
+}
@@ -8,12 +8,13 @@ import (
 	"net/http"
 	"time"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/golang-jwt/jwt"
 	"github.com/google/uuid"
 	"github.com/orangeMangoDimz/go-social/internal/config"
+	outboxEntity "github.com/orangeMangoDimz/go-social/internal/entities/outbox"
 	payloadEntity "github.com/orangeMangoDimz/go-social/internal/entities/payload"
 	usersEntity "github.com/orangeMangoDimz/go-social/internal/entities/users"
+	"github.com/orangeMangoDimz/go-social/internal/logging"
 	"github.com/orangeMangoDimz/go-social/internal/mailer"
 	"github.com/orangeMangoDimz/go-social/internal/server/http/protocol"
 	"github.com/orangeMangoDimz/go-social/internal/service"
@@ -22,34 +23,35 @@ import (
 )
 
 type httpHandler struct {
-	userService   service.UsersService
-	authenticator Authenticator
-	logger        zap.SugaredLogger
-	mailer        mailer.Client
-	config        config.Config
+	userService    service.UsersService
+	sessionService service.SessionService
+	authenticator  Authenticator
+	logger         zap.SugaredLogger
+	config         config.Config
 }
 
-func newHTTPHandler(userService service.UsersService, logger zap.SugaredLogger, mailer mailer.Client, config config.Config, authenticator Authenticator) *httpHandler {
+func newHTTPHandler(userService service.UsersService, sessionService service.SessionService, logger zap.SugaredLogger, config config.Config, authenticator Authenticator) *httpHandler {
 	return &httpHandler{
-		userService:   userService,
-		authenticator: authenticator,
-		logger:        logger,
-		mailer:        mailer,
-		config:        config,
+		userService:    userService,
+		sessionService: sessionService,
+		authenticator:  authenticator,
+		logger:         logger,
+		config:         config,
 	}
 }
 
 // registerUserHandler godoc
 //
 //	@Summary		Register a new user
-//	@Description	Create a new user account with username, email and password. Returns user information with an activation token.
+//	@Description	Create a new user account with username, email and password. Returns user information with an activation token. The activation email is queued and sent in the background, in the language from the payload or the Accept-Language header.
 //	@Tags			authentication
 //	@Accept			json
 //	@Produce		json
-//	@Param			payload	body		github_com_orangeMangoDimz_go-social_internal_entities_payload.RegisterUserPayload	true	"User registration data"
-//	@Success		201		{object}	github_com_orangeMangoDimz_go-social_internal_entities_payload.UserWithToken		"User created successfully, activation required"
-//	@Failure		400		{object}	map[string]string																	"Bad request - validation error or duplicate email/username"
-//	@Failure		500		{object}	map[string]string																	"Internal server error"
+//	@Param			payload			body		github_com_orangeMangoDimz_go-social_internal_entities_payload.RegisterUserPayload	true	"User registration data"
+//	@Param			Accept-Language	header		string																				false	"Preferred email language when the payload has none"	example(id-ID,id;q=0.9)
+//	@Success		201				{object}	github_com_orangeMangoDimz_go-social_internal_entities_payload.UserWithToken		"User created successfully, activation required"
+//	@Failure		400				{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Bad request - validation error or duplicate email/username"
+//	@Failure		500				{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Internal server error"
 //	@Router			/authentication/user [post]
 func (h *httpHandler) registerUserHandler(w http.ResponseWriter, r *http.Request) {
 	var payload payloadEntity.RegisterUserPayload
@@ -66,6 +68,7 @@ func (h *httpHandler) registerUserHandler(w http.ResponseWriter, r *http.Request
 	user := &usersEntity.User{
 		Username: payload.Username,
 		Email:    payload.Email,
+		Language: mailer.MatchLocale(payload.Language, r.Header.Get("Accept-Language")),
 		Role: usersEntity.Role{
 			Name: "user",
 		},
@@ -84,8 +87,15 @@ func (h *httpHandler) registerUserHandler(w http.ResponseWriter, r *http.Request
 	hash := sha256.Sum256([]byte(plainToken))
 	hashToken := hex.EncodeToString(hash[:])
 
+	// The welcome email is queued with the user and sent by the outbox worker
+	email, err := h.activationEmail(user, plainToken)
+	if err != nil {
+		protocol.InternalServerError(w, r, err)
+		return
+	}
+
 	// Store the user
-	err := h.userService.CreateAndInvite(ctx, user, hashToken, h.config.Mail.Exp)
+	err = h.userService.CreateAndInvite(ctx, user, hashToken, h.config.Mail.Exp, email)
 	if err != nil {
 		switch {
 		case errors.Is(err, storage.ErrDuplicateEmail):
@@ -98,59 +108,109 @@ func (h *httpHandler) registerUserHandler(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	// send the email with plain token
 	userWithToken := &payloadEntity.UserWithToken{
 		User:  user,
 		Token: plainToken,
 	}
 
-	activationURL := fmt.Sprintf("%s/confirm/%s", h.config.FrontendURL, plainToken)
+	if err := protocol.JsonResponse(w, http.StatusCreated, userWithToken); err != nil {
+		protocol.InternalServerError(w, r, err)
+	}
+}
 
-	isProdEnv := h.config.Env == "production"
-	vars := struct {
-		Username      string
-		ActivationURL string
-	}{
-		Username:      user.Username,
-		ActivationURL: activationURL,
+// resendActivationHandler godoc
+//
+//	@Summary		Resend the activation email
+//	@Description	Send a new activation link to an account that has not been activated yet. Earlier links stop working. A new link is sent at most once per cooldown period, and the response is the same whether or not such an account exists.
+//	@Tags			authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body	github_com_orangeMangoDimz_go-social_internal_entities_payload.ResendActivationPayload	true	"Account email"
+//	@Success		202		"Activation link sent if the account is waiting for activation"
+//	@Failure		400		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Bad request - validation error"
+//	@Failure		500		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Internal server error"
+//	@Router			/authentication/activation/resend [post]
+func (h *httpHandler) resendActivationHandler(w http.ResponseWriter, r *http.Request) {
+	var payload payloadEntity.ResendActivationPayload
+	if err := protocol.ReadJSON(w, r, &payload); err != nil {
+		protocol.BadRequestResponse(w, r, err)
+		return
 	}
 
-	// Send email
-	time.Sleep(time.Second * 5)
-	status, err := h.mailer.Send(mailer.UserWelcomeTemplate, user.Username, user.Email, vars, !isProdEnv)
-	if err != nil {
-		h.logger.Errorw("error sending welcome email", "error", err)
+	if err := protocol.ValidateStruct(payload); err != nil {
+		protocol.BadRequestResponse(w, r, err)
+		return
+	}
 
-		// rollback user creation if email fails (SAGA pattern)
-		err := h.userService.Delete(ctx, user.ID)
-		if err != nil {
-			h.logger.Errorw("error deleting user", "error", err)
+	ctx := r.Context()
+	user, err := h.userService.GetPendingByEmail(ctx, payload.Email)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			// do not reveal whether the email exists
+			if err := protocol.JsonResponse(w, http.StatusAccepted, nil); err != nil {
+				protocol.InternalServerError(w, r, err)
+			}
+		default:
+			protocol.InternalServerError(w, r, err)
 		}
+		return
+	}
+
+	plainToken := uuid.New().String()
 
+	// Hash the token
+	hash := sha256.Sum256([]byte(plainToken))
+	hashToken := hex.EncodeToString(hash[:])
+
+	email, err := h.activationEmail(user, plainToken)
+	if err != nil {
 		protocol.InternalServerError(w, r, err)
 		return
 	}
 
-	h.logger.Infow("Email sent", "status code", status)
+	err = h.userService.ReissueInvitation(ctx, user.ID, hashToken, h.config.Mail.Exp, h.config.Invitations.ResendCooldown, email)
+	if err != nil {
+		switch {
+		// activated in the meantime, or asked again too soon: answer like any other request
+		case errors.Is(err, storage.ErrNotFound), errors.Is(err, storage.ErrInvitationCooldown):
+			logging.FromContext(r.Context(), &h.logger).Infow("activation email not resent", "user_id", user.ID, "reason", err)
+		default:
+			protocol.InternalServerError(w, r, err)
+			return
+		}
+	}
 
-	if err := protocol.JsonResponse(w, http.StatusCreated, userWithToken); err != nil {
+	if err := protocol.JsonResponse(w, http.StatusAccepted, nil); err != nil {
 		protocol.InternalServerError(w, r, err)
 	}
+}
+
+// activationEmail builds the welcome email carrying the activation link for plainToken.
+func (h *httpHandler) activationEmail(user *usersEntity.User, plainToken string) (*outboxEntity.Email, error) {
+	vars := struct {
+		Username      string
+		ActivationURL string
+	}{
+		Username:      user.Username,
+		ActivationURL: fmt.Sprintf("%s/confirm/%s", h.config.FrontendURL, plainToken),
+	}
 
+	return outboxEntity.NewEmail(mailer.UserWelcomeTemplate, user.Language, user.Username, user.Email, vars)
 }
 
 // createTokenHandler godoc
 //
 //	@Summary		Login and get authentication token
-//	@Description	Authenticate user with email and password, returns a JWT token for API access
+//	@Description	Authenticate user with email and password, returns a short-lived JWT token for API access and a refresh token
 //	@Tags			authentication
 //	@Accept			json
 //	@Produce		json
 //	@Param			payload	body		github_com_orangeMangoDimz_go-social_internal_entities_payload.CreateUserTokenPayload	true	"User login credentials"
 //	@Success		201		{object}	github_com_orangeMangoDimz_go-social_internal_entities_payload.TokenResponse			"JWT token created successfully"
-//	@Failure		400		{object}	map[string]string																		"Bad request - validation error"
-//	@Failure		401		{object}	map[string]string																		"Unauthorized - invalid credentials"
-//	@Failure		500		{object}	map[string]string																		"Internal server error"
+//	@Failure		400		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse		"Bad request - validation error"
+//	@Failure		401		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse		"Unauthorized - invalid credentials"
+//	@Failure		500		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse		"Internal server error"
 //	@Router			/authentication/token [post]
 func (h *httpHandler) createTokenHandler(w http.ResponseWriter, r *http.Request) {
 	// parse payload credentials
@@ -160,8 +220,7 @@ func (h *httpHandler) createTokenHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	var validate = validator.New()
-	if err := validate.Struct(payload); err != nil {
+	if err := protocol.ValidateStruct(payload); err != nil {
 		protocol.BadRequestResponse(w, r, err)
 		return
 	}
@@ -185,24 +244,237 @@ func (h *httpHandler) createTokenHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// generate the token -> add claims
+	// open a session, every token issued from this login belongs to it
+	session, refreshToken, err := h.sessionService.Create(ctx, user.ID)
+	if err != nil {
+		protocol.InternalServerError(w, r, err)
+		return
+	}
+
+	token, err := h.generateAccessToken(user.ID, session.ID)
+	if err != nil {
+		protocol.InternalServerError(w, r, err)
+		return
+	}
+
+	// send it to the client
+	response := payloadEntity.TokenResponse{Token: token, RefreshToken: refreshToken}
+	if err := protocol.JsonResponse(w, http.StatusCreated, response); err != nil {
+		protocol.InternalServerError(w, r, err)
+	}
+}
+
+// refreshTokenHandler godoc
+//
+//	@Summary		Refresh the authentication token
+//	@Description	Exchange a refresh token for a new access token and a new refresh token. Each refresh token can only be used once, reusing one revokes the whole session.
+//	@Tags			authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body		github_com_orangeMangoDimz_go-social_internal_entities_payload.RefreshTokenPayload	true	"Refresh token"
+//	@Success		201		{object}	github_com_orangeMangoDimz_go-social_internal_entities_payload.TokenResponse		"New token pair"
+//	@Failure		400		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Bad request - validation error"
+//	@Failure		401		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Unauthorized - invalid, expired, reused or revoked refresh token"
+//	@Failure		500		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Internal server error"
+//	@Router			/authentication/refresh [post]
+func (h *httpHandler) refreshTokenHandler(w http.ResponseWriter, r *http.Request) {
+	var payload payloadEntity.RefreshTokenPayload
+	if err := protocol.ReadJSON(w, r, &payload); err != nil {
+		protocol.BadRequestResponse(w, r, err)
+		return
+	}
+
+	if err := protocol.ValidateStruct(payload); err != nil {
+		protocol.BadRequestResponse(w, r, err)
+		return
+	}
+
+	ctx := r.Context()
+	session, refreshToken, err := h.sessionService.Refresh(ctx, payload.RefreshToken)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrTokenReused):
+			logging.FromContext(r.Context(), &h.logger).Warnw("Refresh token reused, session revoked", "error", err)
+			protocol.UnauthorizedErrorResponse(w, r, err)
+		case errors.Is(err, storage.ErrNotFound), errors.Is(err, storage.ErrSessionRevoked):
+			protocol.UnauthorizedErrorResponse(w, r, err)
+		default:
+			protocol.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	token, err := h.generateAccessToken(session.UserID, session.ID)
+	if err != nil {
+		protocol.InternalServerError(w, r, err)
+		return
+	}
+
+	response := payloadEntity.TokenResponse{Token: token, RefreshToken: refreshToken}
+	if err := protocol.JsonResponse(w, http.StatusCreated, response); err != nil {
+		protocol.InternalServerError(w, r, err)
+	}
+}
+
+// logoutHandler godoc
+//
+//	@Summary		Logout
+//	@Description	Revoke the session of the current access token together with all of its refresh tokens. Requires JWT authentication.
+//	@Tags			authentication
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		204	"Session revoked"
+//	@Failure		401	{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Unauthorized - invalid or missing token"
+//	@Failure		500	{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Internal server error"
+//	@Router			/authentication/logout [post]
+func (h *httpHandler) logoutHandler(w http.ResponseWriter, r *http.Request) {
+	sessionID := protocol.GetSessionIDFromContext(r)
+
+	ctx := r.Context()
+	if err := h.sessionService.Revoke(ctx, sessionID); err != nil {
+		protocol.InternalServerError(w, r, err)
+		return
+	}
+
+	if err := protocol.JsonResponse(w, http.StatusNoContent, nil); err != nil {
+		protocol.InternalServerError(w, r, err)
+	}
+}
+
+func (h *httpHandler) generateAccessToken(userID int64, sessionID string) (string, error) {
 	claims := jwt.MapClaims{
-		"sub": user.ID,
+		"sub": userID,
+		"sid": sessionID,
 		"exp": time.Now().Add(h.config.Auth.Token.Exp).Unix(),
 		"iat": time.Now().Unix(),
 		"nbf": time.Now().Unix(),
 		"iss": h.config.Auth.Token.Iss,
 		"aud": h.config.Auth.Token.Iss,
 	}
-	token, err := h.authenticator.GenerateToken(claims)
+
+	return h.authenticator.GenerateToken(claims)
+}
+
+// forgotPasswordHandler godoc
+//
+//	@Summary		Request a password reset
+//	@Description	Send a single-use password reset link to the email address. The email is queued and sent in the background. The response is the same whether or not an account exists for that address.
+//	@Tags			authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body	github_com_orangeMangoDimz_go-social_internal_entities_payload.ForgotPasswordPayload	true	"Account email"
+//	@Success		202		"Reset link sent if the account exists"
+//	@Failure		400		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Bad request - validation error"
+//	@Failure		500		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Internal server error"
+//	@Router			/authentication/password/forgot [post]
+func (h *httpHandler) forgotPasswordHandler(w http.ResponseWriter, r *http.Request) {
+	var payload payloadEntity.ForgotPasswordPayload
+	if err := protocol.ReadJSON(w, r, &payload); err != nil {
+		protocol.BadRequestResponse(w, r, err)
+		return
+	}
+
+	if err := protocol.ValidateStruct(payload); err != nil {
+		protocol.BadRequestResponse(w, r, err)
+		return
+	}
+
+	ctx := r.Context()
+	user, err := h.userService.GetByEmail(ctx, payload.Email)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			// do not reveal whether the email exists
+			if err := protocol.JsonResponse(w, http.StatusAccepted, nil); err != nil {
+				protocol.InternalServerError(w, r, err)
+			}
+		default:
+			protocol.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	plainToken := uuid.New().String()
+
+	// Hash the token
+	hash := sha256.Sum256([]byte(plainToken))
+	hashToken := hex.EncodeToString(hash[:])
+
+	// The reset email is queued with the token and sent by the outbox worker
+	email, err := h.passwordResetEmail(user, plainToken)
 	if err != nil {
 		protocol.InternalServerError(w, r, err)
 		return
 	}
 
-	// send it to the client
-	response := payloadEntity.TokenResponse{Token: token}
-	if err := protocol.JsonResponse(w, http.StatusCreated, response); err != nil {
+	if err := h.userService.CreatePasswordReset(ctx, user.ID, hashToken, h.config.Mail.PasswordResetExp, email); err != nil {
+		protocol.InternalServerError(w, r, err)
+		return
+	}
+
+	if err := protocol.JsonResponse(w, http.StatusAccepted, nil); err != nil {
+		protocol.InternalServerError(w, r, err)
+	}
+}
+
+// passwordResetEmail builds the email carrying the password reset link for plainToken.
+func (h *httpHandler) passwordResetEmail(user *usersEntity.User, plainToken string) (*outboxEntity.Email, error) {
+	vars := struct {
+		Username string
+		ResetURL string
+	}{
+		Username: user.Username,
+		ResetURL: fmt.Sprintf("%s/reset-password/%s", h.config.FrontendURL, plainToken),
+	}
+
+	return outboxEntity.NewEmail(mailer.PasswordResetTemplate, user.Language, user.Username, user.Email, vars)
+}
+
+// resetPasswordHandler godoc
+//
+//	@Summary		Reset the password
+//	@Description	Set a new password using the token received by email. Every existing session of the user is revoked.
+//	@Tags			authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body	github_com_orangeMangoDimz_go-social_internal_entities_payload.ResetPasswordPayload	true	"Reset token and new password"
+//	@Success		204		"Password changed"
+//	@Failure		400		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Bad request - validation error"
+//	@Failure		404		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Token not found or expired"
+//	@Failure		500		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Internal server error"
+//	@Router			/authentication/password/reset [post]
+func (h *httpHandler) resetPasswordHandler(w http.ResponseWriter, r *http.Request) {
+	var payload payloadEntity.ResetPasswordPayload
+	if err := protocol.ReadJSON(w, r, &payload); err != nil {
+		protocol.BadRequestResponse(w, r, err)
+		return
+	}
+
+	if err := protocol.ValidateStruct(payload); err != nil {
+		protocol.BadRequestResponse(w, r, err)
+		return
+	}
+
+	// Hash the new password
+	var password usersEntity.Password
+	if err := password.Set(payload.Password); err != nil {
+		protocol.InternalServerError(w, r, err)
+		return
+	}
+
+	ctx := r.Context()
+	if err := h.userService.ResetPassword(ctx, payload.Token, &password); err != nil {
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			protocol.NotFoundResponse(w, r, err)
+		default:
+			protocol.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	if err := protocol.JsonResponse(w, http.StatusNoContent, nil); err != nil {
 		protocol.InternalServerError(w, r, err)
 	}
 }
@@ -3,16 +3,32 @@ package authHandler
 import (
 	"github.com/go-chi/chi/v5"
 	"github.com/orangeMangoDimz/go-social/internal/config"
-	"github.com/orangeMangoDimz/go-social/internal/mailer"
+	"github.com/orangeMangoDimz/go-social/internal/ratelimiter"
+	middlewareHandler "github.com/orangeMangoDimz/go-social/internal/server/http/middleware"
 	"github.com/orangeMangoDimz/go-social/internal/service"
 	"go.uber.org/zap"
 )
 
-func RegisterRoute(userService service.UsersService, logger zap.SugaredLogger, mailer mailer.Client, config config.Config, authenticator Authenticator) func(chi.Router) {
+func RegisterRoute(
+	middlewareProvider middlewareHandler.MiddlewareProvider,
+	userService service.UsersService,
+	sessionService service.SessionService,
+	logger zap.SugaredLogger,
+	config config.Config,
+	authenticator Authenticator,
+) func(chi.Router) {
 	return func(r chi.Router) {
-		handler := newHTTPHandler(userService, logger, mailer, config, authenticator)
+		handler := newHTTPHandler(userService, sessionService, logger, config, authenticator)
+		r.Use(middlewareProvider.RateLimiterMiddleware(ratelimiter.PolicyAuth))
 		r.Post("/user", handler.registerUserHandler)
 		// Add other auth routes here as needed
 		r.Post("/token", handler.createTokenHandler)
+		r.Post("/refresh", handler.refreshTokenHandler)
+		r.Post("/activation/resend", handler.resendActivationHandler)
+		r.With(middlewareProvider.AuthTokenMiddleware).Post("/logout", handler.logoutHandler)
+		r.Route("/password", func(r chi.Router) {
+			r.Post("/forgot", handler.forgotPasswordHandler)
+			r.Post("/reset", handler.resetPasswordHandler)
+		})
 	}
 }
@@ -4,18 +4,26 @@ import (
 	"net/http"
 
 	"github.com/orangeMangoDimz/go-social/internal/config"
+	"github.com/orangeMangoDimz/go-social/internal/health"
+	"github.com/orangeMangoDimz/go-social/internal/logging"
+	middlewareHandler "github.com/orangeMangoDimz/go-social/internal/server/http/middleware"
 	"github.com/orangeMangoDimz/go-social/internal/server/http/protocol"
+	"go.uber.org/zap"
 )
 
 type httpHandler struct {
-	config  config.Config
-	version string
+	middlewareProvider middlewareHandler.MiddlewareProvider
+	config             config.Config
+	version            string
+	logger             zap.SugaredLogger
 }
 
-func newHTTPHandler(config config.Config, version string) *httpHandler {
+func newHTTPHandler(middlewareProvider middlewareHandler.MiddlewareProvider, config config.Config, version string, logger zap.SugaredLogger) *httpHandler {
 	return &httpHandler{
-		config:  config,
-		version: version,
+		middlewareProvider: middlewareProvider,
+		config:             config,
+		version:            version,
+		logger:             logger,
 	}
 }
 
@@ -26,8 +34,8 @@ func newHTTPHandler(config config.Config, version string) *httpHandler {
 //	@Tags			health
 //	@Accept			json
 //	@Produce		json
-//	@Success		200	{object}	map[string]string	"Health status information"
-//	@Failure		500	{object}	map[string]string	"Internal server error"
+//	@Success		200	{object}	map[string]string																	"Health status information"
+//	@Failure		500	{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Internal server error"
 //	@Router			/health [get]
 func (h *httpHandler) healthCheckHandler(w http.ResponseWriter, r *http.Request) {
 	data := map[string]string{
@@ -37,6 +45,53 @@ func (h *httpHandler) healthCheckHandler(w http.ResponseWriter, r *http.Request)
 	}
 
 	if err := protocol.JsonResponse(w, http.StatusOK, data); err != nil {
-		protocol.WriteJSONError(w, http.StatusInternalServerError, err.Error())
+		protocol.InternalServerError(w, r, err)
+	}
+}
+
+// livenessHandler godoc
+//
+//	@Summary		Liveness probe
+//	@Description	Reports that the process is up and able to answer requests. It does not check any dependency, so a failing database does not get the instance restarted.
+//	@Tags			health
+//	@Produce		json
+//	@Success		200	{object}	map[string]string	"The process is alive"
+//	@Router			/health/live [get]
+func (h *httpHandler) livenessHandler(w http.ResponseWriter, r *http.Request) {
+	data := map[string]string{
+		"status": "ok",
+	}
+
+	if err := protocol.JsonResponse(w, http.StatusOK, data); err != nil {
+		protocol.InternalServerError(w, r, err)
+	}
+}
+
+// readinessHandler godoc
+//
+//	@Summary		Readiness probe
+//	@Description	Checks Postgres, the schema migration version and Redis when it is enabled, each with its own timeout, and reports the status and latency of each of them. Fails while the server is shutting down so load balancers stop sending traffic.
+//	@Tags			health
+//	@Produce		json
+//	@Success		200	{object}	github_com_orangeMangoDimz_go-social_internal_health.Report	"Every dependency is up"
+//	@Failure		503	{object}	github_com_orangeMangoDimz_go-social_internal_health.Report	"A dependency is down or the server is shutting down"
+//	@Router			/health/ready [get]
+func (h *httpHandler) readinessHandler(w http.ResponseWriter, r *http.Request) {
+	report := h.middlewareProvider.Readiness(r.Context())
+
+	status := http.StatusOK
+	if !report.Ready() {
+		status = http.StatusServiceUnavailable
+	}
+
+	// the reasons stay in the logs, the endpoint is not authenticated
+	for name, result := range report.Checks {
+		if result.Status != health.StatusUp {
+			logging.FromContext(r.Context(), &h.logger).Warnw("readiness check failed", "check", name, "status", result.Status, "latency_ms", result.LatencyMs, "error", result.Err())
+		}
+	}
+
+	if err := protocol.JsonResponse(w, status, report); err != nil {
+		protocol.InternalServerError(w, r, err)
 	}
 }
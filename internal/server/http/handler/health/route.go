@@ -3,12 +3,17 @@ package healthHandler
 import (
 	"github.com/go-chi/chi/v5"
 	"github.com/orangeMangoDimz/go-social/internal/config"
+	"github.com/orangeMangoDimz/go-social/internal/ratelimiter"
 	middlewareHandler "github.com/orangeMangoDimz/go-social/internal/server/http/middleware"
+	"go.uber.org/zap"
 )
 
-func RegisterRoute(middlewareProvider middlewareHandler.MiddlewareProvider, config config.Config, version string) func(chi.Router) {
+func RegisterRoute(middlewareProvider middlewareHandler.MiddlewareProvider, config config.Config, version string, logger zap.SugaredLogger) func(chi.Router) {
 	return func(r chi.Router) {
-		handler := newHTTPHandler(config, version)
-		r.With(middlewareProvider.BasicAuthMiddleware()).Get("/health", handler.healthCheckHandler)
+		handler := newHTTPHandler(middlewareProvider, config, version, logger)
+		r.With(middlewareProvider.RateLimiterMiddleware(ratelimiter.PolicyDefault), middlewareProvider.BasicAuthMiddleware()).Get("/health", handler.healthCheckHandler)
+		// Probed by orchestrators and load balancers, so neither authenticated nor rate limited
+		r.Get("/health/live", handler.livenessHandler)
+		r.Get("/health/ready", handler.readinessHandler)
 	}
 }
@@ -0,0 +1,331 @@
+package postsHandler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	commentsEntity "github.com/orangeMangoDimz/go-social/internal/entities/comments"
+	payloadEntity "github.com/orangeMangoDimz/go-social/internal/entities/payload"
+	usersEntity "github.com/orangeMangoDimz/go-social/internal/entities/users"
+	"github.com/orangeMangoDimz/go-social/internal/logging"
+	"github.com/orangeMangoDimz/go-social/internal/server/http/protocol"
+	"github.com/orangeMangoDimz/go-social/internal/storage"
+	"github.com/orangeMangoDimz/go-social/internal/storage/postgres/pagination"
+)
+
+// createCommentHandler godoc
+//
+//	@Summary		Comment on a post
+//	@Description	Add a new comment to a specific post, or reply to one of its comments by setting parent_id. Replies can be nested up to a configured depth. Requires JWT authentication.
+//	@Tags			comments
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			postID	path		int																					true	"Post ID"	example(1)
+//	@Param			payload	body		github_com_orangeMangoDimz_go-social_internal_entities_payload.CreateCommentPayload	true	"Comment creation data"
+//	@Success		201		{object}	github_com_orangeMangoDimz_go-social_internal_entities_comments.Comment				"Created comment"
+//	@Failure		400		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Bad request, unknown parent comment or replies nested too deeply"
+//	@Failure		401		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Unauthorized - invalid or missing token"
+//	@Failure		404		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Post not found"
+//	@Failure		500		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Internal server error"
+//	@Router			/posts/{postID}/comments [post]
+func (h *httpHandler) createCommentHandler(w http.ResponseWriter, r *http.Request) {
+	post := protocol.GetPostFromContext(r)
+	if post == nil {
+		protocol.NotFoundResponse(w, r, storage.ErrNotFound)
+		return
+	}
+
+	var payload payloadEntity.CreateCommentPayload
+	if err := protocol.ReadJSON(w, r, &payload); err != nil {
+		protocol.BadRequestResponse(w, r, err)
+		return
+	}
+
+	if err := protocol.ValidateStruct(payload); err != nil {
+		protocol.BadRequestResponse(w, r, err)
+		return
+	}
+
+	user := protocol.GetUserFromContext(r)
+
+	comment := commentsEntity.Comment{
+		PostID:   post.ID,
+		ParentID: payload.ParentID,
+		UserID:   user.ID,
+		Content:  payload.Content,
+		User: usersEntity.User{
+			ID:       user.ID,
+			Username: user.Username,
+		},
+	}
+
+	ctx := r.Context()
+	if err := h.CommentService.Create(ctx, &comment); err != nil {
+		switch {
+		case errors.Is(err, commentsEntity.ErrInvalidParent), errors.Is(err, commentsEntity.ErrMaxDepthExceeded):
+			protocol.BadRequestResponse(w, r, err)
+		case errors.Is(err, storage.ErrNotFound):
+			protocol.NotFoundResponse(w, r, err)
+		default:
+			logging.FromContext(r.Context(), &h.logger).Errorw("Failed to create comment", "post_id", post.ID, "error", err)
+			protocol.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	if err := protocol.JsonResponse(w, http.StatusCreated, &comment); err != nil {
+		protocol.InternalServerError(w, r, err)
+		return
+	}
+}
+
+// getCommentsHandler godoc
+//
+//	@Summary		List comments of a post
+//	@Description	Get a paginated list of the top-level comments of a specific post, newest first. Each comment carries its reply count, replies are listed with GET /posts/{postID}/comments/{commentID}/replies. Requires JWT authentication.
+//	@Tags			comments
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			postID	path		int																					true	"Post ID"								example(1)
+//	@Param			limit	query		int																					false	"Number of comments per page (1-20)"	default(20)	example(10)
+//	@Param			offset	query		int																					false	"Number of comments to skip"			default(0)	example(0)
+//	@Param			cursor	query		string																				false	"Cursor from the previous page"
+//	@Param			sort	query		string																				false	"Sort order (asc/desc)"	default(desc)	Enums(asc, desc)
+//	@Success		200		{array}		github_com_orangeMangoDimz_go-social_internal_entities_comments.Comment				"Top-level comments of the post"
+//	@Failure		400		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Bad request"
+//	@Failure		401		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Unauthorized - invalid or missing token"
+//	@Failure		404		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Post not found"
+//	@Failure		500		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Internal server error"
+//	@Router			/posts/{postID}/comments [get]
+func (h *httpHandler) getCommentsHandler(w http.ResponseWriter, r *http.Request) {
+	post := protocol.GetPostFromContext(r)
+	if post == nil {
+		protocol.NotFoundResponse(w, r, storage.ErrNotFound)
+		return
+	}
+
+	fq := pagination.PaginatedQuery{
+		Limit:  20,
+		Offset: 0,
+		Sort:   "desc",
+	}
+
+	fq, err := fq.Parse(r)
+	if err != nil {
+		protocol.BadRequestResponse(w, r, err)
+		return
+	}
+
+	if err := protocol.ValidateStruct(fq); err != nil {
+		protocol.BadRequestResponse(w, r, err)
+		return
+	}
+
+	ctx := r.Context()
+	comments, err := h.CommentService.GetByPostID(ctx, post.ID, fq)
+	if err != nil {
+		protocol.InternalServerError(w, r, err)
+		return
+	}
+
+	if err := protocol.PaginatedJsonResponse(w, http.StatusOK, comments, pagination.NextCursor(comments, fq, commentKeyset)); err != nil {
+		protocol.InternalServerError(w, r, err)
+		return
+	}
+}
+
+// getRepliesHandler godoc
+//
+//	@Summary		List replies to a comment
+//	@Description	Get a paginated list of the direct replies to a comment, oldest first. Each reply carries its own reply count. Requires JWT authentication.
+//	@Tags			comments
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			postID		path		int																					true	"Post ID"							example(1)
+//	@Param			commentID	path		int																					true	"Comment ID"						example(1)
+//	@Param			limit		query		int																					false	"Number of replies per page (1-20)"	default(20)	example(10)
+//	@Param			offset		query		int																					false	"Number of replies to skip"			default(0)	example(0)
+//	@Param			cursor		query		string																				false	"Cursor from the previous page"
+//	@Param			sort		query		string																				false	"Sort order (asc/desc)"	default(asc)	Enums(asc, desc)
+//	@Success		200			{array}		github_com_orangeMangoDimz_go-social_internal_entities_comments.Comment				"Replies to the comment"
+//	@Failure		400			{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Bad request"
+//	@Failure		401			{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Unauthorized - invalid or missing token"
+//	@Failure		404			{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Comment not found"
+//	@Failure		500			{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Internal server error"
+//	@Router			/posts/{postID}/comments/{commentID}/replies [get]
+func (h *httpHandler) getRepliesHandler(w http.ResponseWriter, r *http.Request) {
+	comment := protocol.GetCommentFromContext(r)
+	if comment == nil {
+		protocol.NotFoundResponse(w, r, storage.ErrNotFound)
+		return
+	}
+
+	// replies read as a conversation, so they default to oldest first
+	fq := pagination.PaginatedQuery{
+		Limit:  20,
+		Offset: 0,
+		Sort:   "asc",
+	}
+
+	fq, err := fq.Parse(r)
+	if err != nil {
+		protocol.BadRequestResponse(w, r, err)
+		return
+	}
+
+	if err := protocol.ValidateStruct(fq); err != nil {
+		protocol.BadRequestResponse(w, r, err)
+		return
+	}
+
+	ctx := r.Context()
+	replies, err := h.CommentService.GetReplies(ctx, comment.ID, fq)
+	if err != nil {
+		protocol.InternalServerError(w, r, err)
+		return
+	}
+
+	if err := protocol.PaginatedJsonResponse(w, http.StatusOK, replies, pagination.NextCursor(replies, fq, commentKeyset)); err != nil {
+		protocol.InternalServerError(w, r, err)
+		return
+	}
+}
+
+// updateCommentHandler godoc
+//
+//	@Summary		Update a comment
+//	@Description	Edit the content of a comment. Only the author, moderators and admins can edit a comment. Requires JWT authentication.
+//	@Tags			comments
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			postID		path		int																					true	"Post ID"		example(1)
+//	@Param			commentID	path		int																					true	"Comment ID"	example(1)
+//	@Param			payload		body		github_com_orangeMangoDimz_go-social_internal_entities_payload.UpdateCommentPayload	true	"Comment update data"
+//	@Success		200			{object}	github_com_orangeMangoDimz_go-social_internal_entities_comments.Comment				"Updated comment"
+//	@Failure		400			{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Bad request"
+//	@Failure		401			{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Unauthorized - invalid or missing token"
+//	@Failure		403			{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Forbidden - not the comment author"
+//	@Failure		404			{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Comment not found"
+//	@Failure		500			{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Internal server error"
+//	@Router			/posts/{postID}/comments/{commentID} [patch]
+func (h *httpHandler) updateCommentHandler(w http.ResponseWriter, r *http.Request) {
+	comment := protocol.GetCommentFromContext(r)
+	if comment == nil {
+		protocol.NotFoundResponse(w, r, storage.ErrNotFound)
+		return
+	}
+
+	var payload payloadEntity.UpdateCommentPayload
+	if err := protocol.ReadJSON(w, r, &payload); err != nil {
+		protocol.BadRequestResponse(w, r, err)
+		return
+	}
+
+	if err := protocol.ValidateStruct(payload); err != nil {
+		protocol.BadRequestResponse(w, r, err)
+		return
+	}
+
+	comment.Content = payload.Content
+
+	ctx := r.Context()
+	if err := h.CommentService.Update(ctx, comment); err != nil {
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			protocol.NotFoundResponse(w, r, err)
+		default:
+			logging.FromContext(r.Context(), &h.logger).Errorw("Failed to update comment", "comment_id", comment.ID, "error", err)
+			protocol.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	if err := protocol.JsonResponse(w, http.StatusOK, comment); err != nil {
+		protocol.InternalServerError(w, r, err)
+		return
+	}
+}
+
+// deleteCommentHandler godoc
+//
+//	@Summary		Delete a comment
+//	@Description	Delete a comment. Only the author and admins can delete a comment. Requires JWT authentication.
+//	@Tags			comments
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			postID		path	int	true	"Post ID"		example(1)
+//	@Param			commentID	path	int	true	"Comment ID"	example(1)
+//	@Success		204			"Comment successfully deleted"
+//	@Failure		401			{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Unauthorized - invalid or missing token"
+//	@Failure		403			{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Forbidden - not the comment author"
+//	@Failure		404			{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Comment not found"
+//	@Failure		500			{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Internal server error"
+//	@Router			/posts/{postID}/comments/{commentID} [delete]
+func (h *httpHandler) deleteCommentHandler(w http.ResponseWriter, r *http.Request) {
+	comment := protocol.GetCommentFromContext(r)
+	if comment == nil {
+		protocol.NotFoundResponse(w, r, storage.ErrNotFound)
+		return
+	}
+
+	ctx := r.Context()
+	if err := h.CommentService.Delete(ctx, comment.ID); err != nil {
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			protocol.NotFoundResponse(w, r, err)
+		default:
+			logging.FromContext(r.Context(), &h.logger).Errorw("Failed to delete comment", "comment_id", comment.ID, "error", err)
+			protocol.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	if err := protocol.JsonResponse(w, http.StatusNoContent, nil); err != nil {
+		protocol.InternalServerError(w, r, err)
+		return
+	}
+}
+
+func (h *httpHandler) commentContextMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(chi.URLParam(r, "commentID"), 10, 64)
+		if err != nil {
+			protocol.BadRequestResponse(w, r, err)
+			return
+		}
+		ctx := r.Context()
+
+		comment, err := h.CommentService.GetByID(ctx, id)
+		if err != nil {
+			switch {
+			case errors.Is(err, storage.ErrNotFound):
+				protocol.NotFoundResponse(w, r, err)
+			default:
+				protocol.InternalServerError(w, r, err)
+			}
+			return
+		}
+
+		// the comment has to belong to the post in the URL
+		post := protocol.GetPostFromContext(r)
+		if post == nil || comment.PostID != post.ID {
+			protocol.NotFoundResponse(w, r, storage.ErrNotFound)
+			return
+		}
+
+		ctx = context.WithValue(ctx, protocol.CommentCtx, comment)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func commentKeyset(c commentsEntity.Comment) (string, int64) {
+	return c.CreatedAt, c.ID
+}
@@ -7,9 +7,9 @@ import (
 	"strconv"
 
 	"github.com/go-chi/chi/v5"
-	"github.com/go-playground/validator/v10"
 	payloadEntity "github.com/orangeMangoDimz/go-social/internal/entities/payload"
 	postsEntity "github.com/orangeMangoDimz/go-social/internal/entities/posts"
+	tagsEntity "github.com/orangeMangoDimz/go-social/internal/entities/tags"
 	"github.com/orangeMangoDimz/go-social/internal/server/http/protocol"
 	"github.com/orangeMangoDimz/go-social/internal/service"
 	"github.com/orangeMangoDimz/go-social/internal/storage"
@@ -18,32 +18,34 @@ import (
 )
 
 type httpHandler struct {
-	PostService    service.PostsService
-	CommentService service.CommentService
-	logger         zap.SugaredLogger
+	PostService     service.PostsService
+	CommentService  service.CommentService
+	ReactionService service.ReactionService
+	logger          zap.SugaredLogger
 }
 
-func newHTTPHandler(postService service.PostsService, commentService service.CommentService, logger zap.SugaredLogger) *httpHandler {
+func newHTTPHandler(postService service.PostsService, commentService service.CommentService, reactionService service.ReactionService, logger zap.SugaredLogger) *httpHandler {
 	return &httpHandler{
-		PostService:    postService,
-		CommentService: commentService,
-		logger:         logger,
+		PostService:     postService,
+		CommentService:  commentService,
+		ReactionService: reactionService,
+		logger:          logger,
 	}
 }
 
 // createPostHandler godoc
 //
 //	@Summary		Create a new post
-//	@Description	Create a new post with title, content and optional tags. Requires JWT authentication.
+//	@Description	Create a new post with title, content and optional tags. Tags are lowercased, stripped of a leading # and deduplicated, up to 10 tags of 50 letters, digits, _ or -. Requires JWT authentication.
 //	@Tags			posts
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
 //	@Param			payload	body		github_com_orangeMangoDimz_go-social_internal_entities_payload.CreatePOstPayload	true	"Post creation data"
 //	@Success		200		{object}	github_com_orangeMangoDimz_go-social_internal_entities_posts.Post					"Created post"
-//	@Failure		400		{object}	map[string]string																	"Bad request"
-//	@Failure		401		{object}	map[string]string																	"Unauthorized - invalid or missing token"
-//	@Failure		500		{object}	map[string]string																	"Internal server error"
+//	@Failure		400		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Bad request"
+//	@Failure		401		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Unauthorized - invalid or missing token"
+//	@Failure		500		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Internal server error"
 //	@Router			/posts [post]
 func (h *httpHandler) createPostHandler(w http.ResponseWriter, r *http.Request) {
 	var payload payloadEntity.CreatePOstPayload
@@ -53,8 +55,13 @@ func (h *httpHandler) createPostHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	var validate = validator.New()
-	if err := validate.Struct(payload); err != nil {
+	if err := protocol.ValidateStruct(payload); err != nil {
+		protocol.BadRequestResponse(w, r, err)
+		return
+	}
+
+	tags, err := tagsEntity.NormalizeAll(payload.Tags)
+	if err != nil {
 		protocol.BadRequestResponse(w, r, err)
 		return
 	}
@@ -64,7 +71,7 @@ func (h *httpHandler) createPostHandler(w http.ResponseWriter, r *http.Request)
 	post := postsEntity.Post{
 		Title:   payload.Title,
 		Content: payload.Content,
-		Tags:    payload.Tags,
+		Tags:    tags,
 		UserId:  user.ID,
 	}
 
@@ -83,15 +90,15 @@ func (h *httpHandler) createPostHandler(w http.ResponseWriter, r *http.Request)
 // getPostHandler godoc
 //
 //	@Summary		Get post by ID
-//	@Description	Get detailed information about a specific post including comments
+//	@Description	Get detailed information about a specific post including reaction counts and the viewer's reactions. Comments are listed separately with GET /posts/{postID}/comments.
 //	@Tags			posts
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			postID	path		int																	true	"Post ID"	example(1)
-//	@Success		200		{object}	github_com_orangeMangoDimz_go-social_internal_entities_posts.Post	"Post information with comments"
-//	@Failure		404		{object}	map[string]string													"Post not found"
-//	@Failure		500		{object}	map[string]string													"Internal server error"
+//	@Param			postID	path		int																					true	"Post ID"	example(1)
+//	@Success		200		{object}	github_com_orangeMangoDimz_go-social_internal_entities_posts.Post					"Post information"
+//	@Failure		404		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Post not found"
+//	@Failure		500		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Internal server error"
 //	@Router			/posts/{postID} [get]
 func (h *httpHandler) getPostHandler(w http.ResponseWriter, r *http.Request) {
 	post := protocol.GetPostFromContext(r)
@@ -101,13 +108,14 @@ func (h *httpHandler) getPostHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	ctx := r.Context()
-	comment, err := h.CommentService.GetByPostID(ctx, post.ID)
+	user := protocol.GetUserFromContext(r)
+	reactions, err := h.ReactionService.GetSummary(ctx, post.ID, user.ID)
 	if err != nil {
 		protocol.InternalServerError(w, r, err)
 		return
 	}
 
-	post.Comments = comment
+	post.Reactions = reactions
 	if err := protocol.JsonResponse(w, http.StatusOK, post); err != nil {
 		protocol.InternalServerError(w, r, err)
 		return
@@ -118,21 +126,24 @@ func (h *httpHandler) getPostHandler(w http.ResponseWriter, r *http.Request) {
 //
 //	@Summary		Get user's post feed
 //	@Description	Get a paginated feed of posts from followed users and own posts. Requires JWT authentication.
+//	@Description	When timelines are enabled, the feed goes back as far as the newest TIMELINE_MAX_ENTRIES posts of followed users.
+//	@Description	Pages can be walked with offset or, preferably, with the opaque cursor returned as next_cursor.
 //	@Tags			feed
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			limit	query		int																	false	"Number of posts per page (1-20)"	default(20)		example(10)
-//	@Param			offset	query		int																	false	"Number of posts to skip"			default(0)		example(0)
-//	@Param			sort	query		string																false	"Sort order (asc/desc)"				default(desc)	Enums(asc, desc)
-//	@Param			search	query		string																false	"Search in title and content"		example("golang")
-//	@Param			tags	query		string																false	"Comma-separated list of tags"		example("golang,programming")
-//	@Param			since	query		string																false	"Posts created after this date"		example("2024-01-01 00:00:00")
-//	@Param			until	query		string																false	"Posts created before this date"	example("2024-12-31 23:59:59")
-//	@Success		200		{array}		github_com_orangeMangoDimz_go-social_internal_entities_posts.Feed	"User's post feed"
-//	@Failure		400		{object}	map[string]string													"Bad request"
-//	@Failure		401		{object}	map[string]string													"Unauthorized - invalid or missing token"
-//	@Failure		500		{object}	map[string]string													"Internal server error"
+//	@Param			limit	query		int																					false	"Number of posts per page (1-20)"	default(20)	example(10)
+//	@Param			offset	query		int																					false	"Number of posts to skip"			default(0)	example(0)
+//	@Param			cursor	query		string																				false	"Cursor from the previous page"
+//	@Param			sort	query		string																				false	"Sort order (asc/desc)"				default(desc)	Enums(asc, desc)
+//	@Param			search	query		string																				false	"Search in title and content"		example("golang")
+//	@Param			tags	query		string																				false	"Comma-separated list of tags"		example("golang,programming")
+//	@Param			since	query		string																				false	"Posts created after this date"		example("2024-01-01 00:00:00")
+//	@Param			until	query		string																				false	"Posts created before this date"	example("2024-12-31 23:59:59")
+//	@Success		200		{array}		github_com_orangeMangoDimz_go-social_internal_entities_posts.Feed					"User's post feed"
+//	@Failure		400		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Bad request"
+//	@Failure		401		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Unauthorized - invalid or missing token"
+//	@Failure		500		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Internal server error"
 //	@Router			/posts/feed [get]
 func (h *httpHandler) getUserPostFeed(w http.ResponseWriter, r *http.Request) {
 	fq := pagination.PaginatedQuery{
@@ -147,8 +158,7 @@ func (h *httpHandler) getUserPostFeed(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var validate = validator.New()
-	if err := validate.Struct(fq); err != nil {
+	if err := protocol.ValidateStruct(fq); err != nil {
 		protocol.BadRequestResponse(w, r, err)
 		return
 	}
@@ -163,7 +173,27 @@ func (h *httpHandler) getUserPostFeed(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := protocol.JsonResponse(w, http.StatusOK, feed); err != nil {
+	// attach the reactions of the whole page with a single query
+	postIDs := make([]int64, len(feed))
+	for i, f := range feed {
+		postIDs[i] = f.ID
+	}
+
+	reactions, err := h.ReactionService.GetSummaries(ctx, postIDs, userID)
+	if err != nil {
+		protocol.InternalServerError(w, r, err)
+		return
+	}
+
+	for i := range feed {
+		feed[i].Reactions = reactions[feed[i].ID]
+	}
+
+	nextCursor := pagination.NextCursor(feed, fq, func(f postsEntity.Feed) (string, int64) {
+		return f.CreatedAt, f.ID
+	})
+
+	if err := protocol.PaginatedJsonResponse(w, http.StatusOK, feed, nextCursor); err != nil {
 		protocol.InternalServerError(w, r, err)
 		return
 	}
@@ -173,7 +203,7 @@ func (h *httpHandler) getUserPostFeed(w http.ResponseWriter, r *http.Request) {
 // updatePostHandler godoc
 //
 //	@Summary		Update a post
-//	@Description	Update the title and/or content of an existing post. Requires JWT authentication.
+//	@Description	Update the title, content and/or tags of an existing post. Tags are normalized like on creation. Requires JWT authentication.
 //	@Tags			posts
 //	@Accept			json
 //	@Produce		json
@@ -181,10 +211,10 @@ func (h *httpHandler) getUserPostFeed(w http.ResponseWriter, r *http.Request) {
 //	@Param			postID	path		int																					true	"Post ID"	example(1)
 //	@Param			payload	body		github_com_orangeMangoDimz_go-social_internal_entities_payload.UpdatePostPayload	true	"Post update data"
 //	@Success		200		{object}	github_com_orangeMangoDimz_go-social_internal_entities_posts.Post					"Updated post"
-//	@Failure		400		{object}	map[string]string																	"Bad request"
-//	@Failure		401		{object}	map[string]string																	"Unauthorized - invalid or missing token"
-//	@Failure		404		{object}	map[string]string																	"Post not found"
-//	@Failure		500		{object}	map[string]string																	"Internal server error"
+//	@Failure		400		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Bad request"
+//	@Failure		401		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Unauthorized - invalid or missing token"
+//	@Failure		404		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Post not found"
+//	@Failure		500		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Internal server error"
 //	@Router			/posts/{postID} [patch]
 func (h *httpHandler) updatePostHandler(w http.ResponseWriter, r *http.Request) {
 	post := protocol.GetPostFromContext(r)
@@ -199,8 +229,7 @@ func (h *httpHandler) updatePostHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	var validate = validator.New()
-	if err := validate.Struct(payload); err != nil {
+	if err := protocol.ValidateStruct(payload); err != nil {
 		protocol.BadRequestResponse(w, r, err)
 		return
 	}
@@ -211,6 +240,14 @@ func (h *httpHandler) updatePostHandler(w http.ResponseWriter, r *http.Request)
 	if payload.Content != nil {
 		post.Content = *payload.Content
 	}
+	if payload.Tags != nil {
+		tags, err := tagsEntity.NormalizeAll(*payload.Tags)
+		if err != nil {
+			protocol.BadRequestResponse(w, r, err)
+			return
+		}
+		post.Tags = tags
+	}
 
 	ctx := r.Context()
 	err := h.PostService.Update(ctx, post)
@@ -241,9 +278,9 @@ func (h *httpHandler) updatePostHandler(w http.ResponseWriter, r *http.Request)
 //	@Security		BearerAuth
 //	@Param			postID	path	int	true	"Post ID"	example(1)
 //	@Success		204		"Post successfully deleted"
-//	@Failure		401		{object}	map[string]string	"Unauthorized - invalid or missing token"
-//	@Failure		404		{object}	map[string]string	"Post not found"
-//	@Failure		500		{object}	map[string]string	"Internal server error"
+//	@Failure		401		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Unauthorized - invalid or missing token"
+//	@Failure		404		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Post not found"
+//	@Failure		500		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Internal server error"
 //	@Router			/posts/{postID} [delete]
 func (h *httpHandler) deletePostHandler(w http.ResponseWriter, r *http.Request) {
 	post := protocol.GetPostFromContext(r)
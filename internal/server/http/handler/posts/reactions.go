@@ -0,0 +1,200 @@
+package postsHandler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	reactionsEntity "github.com/orangeMangoDimz/go-social/internal/entities/reactions"
+	"github.com/orangeMangoDimz/go-social/internal/logging"
+	"github.com/orangeMangoDimz/go-social/internal/server/http/protocol"
+	"github.com/orangeMangoDimz/go-social/internal/storage"
+	"github.com/orangeMangoDimz/go-social/internal/storage/postgres/pagination"
+)
+
+// reactHandler godoc
+//
+//	@Summary		React to a post
+//	@Description	Leave a reaction of the given kind on a post. Reacting twice with the same kind has no effect. Requires JWT authentication.
+//	@Tags			reactions
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			postID	path		int																					true	"Post ID"		example(1)
+//	@Param			kind	path		string																				true	"Reaction kind"	Enums(like, love, laugh, wow, sad, angry)
+//	@Success		200		{object}	github_com_orangeMangoDimz_go-social_internal_entities_reactions.Summary			"Reactions of the post"
+//	@Failure		400		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Unknown reaction kind"
+//	@Failure		401		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Unauthorized - invalid or missing token"
+//	@Failure		404		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Post not found"
+//	@Failure		500		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Internal server error"
+//	@Router			/posts/{postID}/reactions/{kind} [put]
+func (h *httpHandler) reactHandler(w http.ResponseWriter, r *http.Request) {
+	post := protocol.GetPostFromContext(r)
+	if post == nil {
+		protocol.NotFoundResponse(w, r, storage.ErrNotFound)
+		return
+	}
+
+	kind, err := reactionKind(r)
+	if err != nil {
+		protocol.BadRequestResponse(w, r, err)
+		return
+	}
+
+	user := protocol.GetUserFromContext(r)
+
+	reaction := reactionsEntity.Reaction{
+		PostID:   post.ID,
+		UserID:   user.ID,
+		Username: user.Username,
+		Kind:     kind,
+	}
+
+	ctx := r.Context()
+	if err := h.ReactionService.React(ctx, &reaction); err != nil {
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			protocol.NotFoundResponse(w, r, err)
+		default:
+			logging.FromContext(r.Context(), &h.logger).Errorw("Failed to react to post", "post_id", post.ID, "kind", kind, "error", err)
+			protocol.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	summary, err := h.ReactionService.GetSummary(ctx, post.ID, user.ID)
+	if err != nil {
+		protocol.InternalServerError(w, r, err)
+		return
+	}
+
+	if err := protocol.JsonResponse(w, http.StatusOK, summary); err != nil {
+		protocol.InternalServerError(w, r, err)
+		return
+	}
+}
+
+// unreactHandler godoc
+//
+//	@Summary		Remove a reaction from a post
+//	@Description	Remove the reaction of the given kind the current user left on a post. Requires JWT authentication.
+//	@Tags			reactions
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			postID	path	int		true	"Post ID"		example(1)
+//	@Param			kind	path	string	true	"Reaction kind"	Enums(like, love, laugh, wow, sad, angry)
+//	@Success		204		"Reaction successfully removed"
+//	@Failure		400		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Unknown reaction kind"
+//	@Failure		401		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Unauthorized - invalid or missing token"
+//	@Failure		404		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Post or reaction not found"
+//	@Failure		500		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Internal server error"
+//	@Router			/posts/{postID}/reactions/{kind} [delete]
+func (h *httpHandler) unreactHandler(w http.ResponseWriter, r *http.Request) {
+	post := protocol.GetPostFromContext(r)
+	if post == nil {
+		protocol.NotFoundResponse(w, r, storage.ErrNotFound)
+		return
+	}
+
+	kind, err := reactionKind(r)
+	if err != nil {
+		protocol.BadRequestResponse(w, r, err)
+		return
+	}
+
+	user := protocol.GetUserFromContext(r)
+
+	ctx := r.Context()
+	if err := h.ReactionService.Unreact(ctx, post.ID, user.ID, kind); err != nil {
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			protocol.NotFoundResponse(w, r, err)
+		default:
+			logging.FromContext(r.Context(), &h.logger).Errorw("Failed to remove reaction", "post_id", post.ID, "kind", kind, "error", err)
+			protocol.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	if err := protocol.JsonResponse(w, http.StatusNoContent, nil); err != nil {
+		protocol.InternalServerError(w, r, err)
+		return
+	}
+}
+
+// getReactionsHandler godoc
+//
+//	@Summary		List who reacted to a post
+//	@Description	Get a paginated list of the users who left a reaction of the given kind on a post, most recent first. Requires JWT authentication.
+//	@Tags			reactions
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			postID	path		int																					true	"Post ID"							example(1)
+//	@Param			kind	path		string																				true	"Reaction kind"						Enums(like, love, laugh, wow, sad, angry)
+//	@Param			limit	query		int																					false	"Number of users per page (1-20)"	default(20)	example(10)
+//	@Param			offset	query		int																					false	"Number of users to skip"			default(0)	example(0)
+//	@Param			cursor	query		string																				false	"Cursor from the previous page"
+//	@Param			sort	query		string																				false	"Sort order (asc/desc)"	default(desc)	Enums(asc, desc)
+//	@Success		200		{array}		github_com_orangeMangoDimz_go-social_internal_entities_reactions.Reaction			"Reactions of the post"
+//	@Failure		400		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Bad request"
+//	@Failure		401		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Unauthorized - invalid or missing token"
+//	@Failure		404		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Post not found"
+//	@Failure		500		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Internal server error"
+//	@Router			/posts/{postID}/reactions/{kind} [get]
+func (h *httpHandler) getReactionsHandler(w http.ResponseWriter, r *http.Request) {
+	post := protocol.GetPostFromContext(r)
+	if post == nil {
+		protocol.NotFoundResponse(w, r, storage.ErrNotFound)
+		return
+	}
+
+	kind, err := reactionKind(r)
+	if err != nil {
+		protocol.BadRequestResponse(w, r, err)
+		return
+	}
+
+	fq := pagination.PaginatedQuery{
+		Limit:  20,
+		Offset: 0,
+		Sort:   "desc",
+	}
+
+	fq, err = fq.Parse(r)
+	if err != nil {
+		protocol.BadRequestResponse(w, r, err)
+		return
+	}
+
+	if err := protocol.ValidateStruct(fq); err != nil {
+		protocol.BadRequestResponse(w, r, err)
+		return
+	}
+
+	ctx := r.Context()
+	reactions, err := h.ReactionService.GetByPostID(ctx, post.ID, kind, fq)
+	if err != nil {
+		protocol.InternalServerError(w, r, err)
+		return
+	}
+
+	nextCursor := pagination.NextCursor(reactions, fq, func(r reactionsEntity.Reaction) (string, int64) {
+		return r.CreatedAt, r.UserID
+	})
+
+	if err := protocol.PaginatedJsonResponse(w, http.StatusOK, reactions, nextCursor); err != nil {
+		protocol.InternalServerError(w, r, err)
+		return
+	}
+}
+
+func reactionKind(r *http.Request) (string, error) {
+	kind := chi.URLParam(r, "kind")
+	if !reactionsEntity.IsValidKind(kind) {
+		return "", fmt.Errorf("unknown reaction kind %q", kind)
+	}
+	return kind, nil
+}
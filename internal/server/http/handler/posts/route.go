@@ -2,6 +2,7 @@ package postsHandler
 
 import (
 	"github.com/go-chi/chi/v5"
+	"github.com/orangeMangoDimz/go-social/internal/ratelimiter"
 	middlewareHandler "github.com/orangeMangoDimz/go-social/internal/server/http/middleware"
 	"github.com/orangeMangoDimz/go-social/internal/service"
 	"go.uber.org/zap"
@@ -11,21 +12,40 @@ func RegisterRoute(
 	middlewareProvider middlewareHandler.MiddlewareProvider,
 	postService service.PostsService,
 	commentService service.CommentService,
+	reactionService service.ReactionService,
 	logger zap.SugaredLogger,
 ) func(chi.Router) {
 	return func(r chi.Router) {
-		handler := newHTTPHandler(postService, commentService, logger)
+		handler := newHTTPHandler(postService, commentService, reactionService, logger)
+		read := middlewareProvider.RateLimiterMiddleware(ratelimiter.PolicyDefault)
+		write := middlewareProvider.RateLimiterMiddleware(ratelimiter.PolicyWrite)
+
 		r.Use(middlewareProvider.AuthTokenMiddleware)
-		r.Post("/", handler.createPostHandler)
+		r.With(write).Post("/", handler.createPostHandler)
 		r.Route("/{postID}", func(r chi.Router) {
 			r.Use(handler.postContextMiddleware)
-			r.Get("/", handler.getPostHandler)
-			r.Patch("/", middlewareProvider.CheckPostOwnership("moderator", handler.updatePostHandler))
-			r.Delete("/", middlewareProvider.CheckPostOwnership("admin", handler.deletePostHandler))
+			r.With(read).Get("/", handler.getPostHandler)
+			r.With(write).Patch("/", middlewareProvider.CheckPostOwnership("moderator", handler.updatePostHandler))
+			r.With(write).Delete("/", middlewareProvider.CheckPostOwnership("admin", handler.deletePostHandler))
+			r.Route("/comments", func(r chi.Router) {
+				r.With(write).Post("/", handler.createCommentHandler)
+				r.With(read).Get("/", handler.getCommentsHandler)
+				r.Route("/{commentID}", func(r chi.Router) {
+					r.Use(handler.commentContextMiddleware)
+					r.With(read).Get("/replies", handler.getRepliesHandler)
+					r.With(write).Patch("/", middlewareProvider.CheckCommentOwnership("moderator", handler.updateCommentHandler))
+					r.With(write).Delete("/", middlewareProvider.CheckCommentOwnership("admin", handler.deleteCommentHandler))
+				})
+			})
+			r.Route("/reactions/{kind}", func(r chi.Router) {
+				r.With(write).Put("/", handler.reactHandler)
+				r.With(write).Delete("/", handler.unreactHandler)
+				r.With(read).Get("/", handler.getReactionsHandler)
+			})
 		})
 		r.Group(func(r chi.Router) {
 			r.Use(middlewareProvider.AuthTokenMiddleware)
-			r.Get("/feed", handler.getUserPostFeed)
+			r.With(read).Get("/feed", handler.getUserPostFeed)
 		})
 	}
 }
@@ -0,0 +1,97 @@
+package searchHandler
+
+import (
+	"net/http"
+	"strconv"
+
+	searchEntity "github.com/orangeMangoDimz/go-social/internal/entities/search"
+	"github.com/orangeMangoDimz/go-social/internal/server/http/protocol"
+	"github.com/orangeMangoDimz/go-social/internal/service"
+	"go.uber.org/zap"
+)
+
+type httpHandler struct {
+	searchService service.SearchService
+	logger        zap.SugaredLogger
+}
+
+func newHTTPHandler(searchService service.SearchService, logger zap.SugaredLogger) *httpHandler {
+	return &httpHandler{
+		searchService: searchService,
+		logger:        logger,
+	}
+}
+
+// searchHandler godoc
+//
+//	@Summary		Search posts, comments or users
+//	@Description	Full-text search over posts, weighting titles above content, over comments, or fuzzy search over usernames. Results are ranked by relevance, and posts and comments come with an HTML-escaped snippet where the matches are wrapped in <mark>. Requires JWT authentication.
+//	@Tags			search
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			q		query		string																				true	"Search terms, supports quoted phrases, or and -exclusions"	minlength(2)	maxlength(100)
+//	@Param			type	query		string																				false	"Kind of document to search"								default(posts)	Enums(posts, comments, users)
+//	@Param			limit	query		int																					false	"Number of results per page (1-20)"							default(10)
+//	@Param			offset	query		int																					false	"Number of results to skip (max 1000)"						default(0)
+//	@Success		200		{array}		github_com_orangeMangoDimz_go-social_internal_entities_search.Result				"Ranked results"
+//	@Failure		400		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Bad request"
+//	@Failure		401		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Unauthorized - invalid or missing token"
+//	@Failure		500		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Internal server error"
+//	@Router			/search [get]
+func (h *httpHandler) searchHandler(w http.ResponseWriter, r *http.Request) {
+	q, err := parseQuery(r)
+	if err != nil {
+		protocol.BadRequestResponse(w, r, err)
+		return
+	}
+
+	if err := protocol.ValidateStruct(q); err != nil {
+		protocol.BadRequestResponse(w, r, err)
+		return
+	}
+
+	results, err := h.searchService.Search(r.Context(), q)
+	if err != nil {
+		protocol.InternalServerError(w, r, err)
+		return
+	}
+
+	if err := protocol.JsonResponse(w, http.StatusOK, results); err != nil {
+		protocol.InternalServerError(w, r, err)
+		return
+	}
+}
+
+func parseQuery(r *http.Request) (searchEntity.Query, error) {
+	qs := r.URL.Query()
+
+	q := searchEntity.Query{
+		Q:      qs.Get("q"),
+		Type:   searchEntity.TypePosts,
+		Limit:  10,
+		Offset: 0,
+	}
+
+	if t := qs.Get("type"); t != "" {
+		q.Type = t
+	}
+
+	if limit := qs.Get("limit"); limit != "" {
+		l, err := strconv.Atoi(limit)
+		if err != nil {
+			return q, err
+		}
+		q.Limit = l
+	}
+
+	if offset := qs.Get("offset"); offset != "" {
+		o, err := strconv.Atoi(offset)
+		if err != nil {
+			return q, err
+		}
+		q.Offset = o
+	}
+
+	return q, nil
+}
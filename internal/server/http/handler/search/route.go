@@ -0,0 +1,23 @@
+package searchHandler
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/orangeMangoDimz/go-social/internal/ratelimiter"
+	middlewareHandler "github.com/orangeMangoDimz/go-social/internal/server/http/middleware"
+	"github.com/orangeMangoDimz/go-social/internal/service"
+	"go.uber.org/zap"
+)
+
+func RegisterRoute(
+	middlewareProvider middlewareHandler.MiddlewareProvider,
+	searchService service.SearchService,
+	logger zap.SugaredLogger,
+) func(chi.Router) {
+	return func(r chi.Router) {
+		handler := newHTTPHandler(searchService, logger)
+		read := middlewareProvider.RateLimiterMiddleware(ratelimiter.PolicyDefault)
+
+		r.Use(middlewareProvider.AuthTokenMiddleware)
+		r.With(read).Get("/", handler.searchHandler)
+	}
+}
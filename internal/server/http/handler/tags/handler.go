@@ -0,0 +1,151 @@
+package tagsHandler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	postsEntity "github.com/orangeMangoDimz/go-social/internal/entities/posts"
+	tagsEntity "github.com/orangeMangoDimz/go-social/internal/entities/tags"
+	"github.com/orangeMangoDimz/go-social/internal/server/http/protocol"
+	"github.com/orangeMangoDimz/go-social/internal/service"
+	tagsService "github.com/orangeMangoDimz/go-social/internal/service/domain/tags"
+	"github.com/orangeMangoDimz/go-social/internal/storage/postgres/pagination"
+	"go.uber.org/zap"
+)
+
+type httpHandler struct {
+	TagService      service.TagService
+	ReactionService service.ReactionService
+	logger          zap.SugaredLogger
+}
+
+func newHTTPHandler(tagService service.TagService, reactionService service.ReactionService, logger zap.SugaredLogger) *httpHandler {
+	return &httpHandler{
+		TagService:      tagService,
+		ReactionService: reactionService,
+		logger:          logger,
+	}
+}
+
+// getTagPostsHandler godoc
+//
+//	@Summary		Get the posts of a tag
+//	@Description	Get a paginated feed of the posts of every user tagged with a tag, not only of followed users. The tag is normalized, so #GoLang and golang are the same. Requires JWT authentication.
+//	@Description	Pages can be walked with offset or, preferably, with the opaque cursor returned as next_cursor.
+//	@Tags			tags
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			tag		path		string																				true	"Tag"								example(golang)
+//	@Param			limit	query		int																					false	"Number of posts per page (1-20)"	default(20)	example(10)
+//	@Param			offset	query		int																					false	"Number of posts to skip"			default(0)	example(0)
+//	@Param			cursor	query		string																				false	"Cursor from the previous page"
+//	@Param			sort	query		string																				false	"Sort order (asc/desc)"				default(desc)	Enums(asc, desc)
+//	@Param			since	query		string																				false	"Posts created after this date"		example("2024-01-01 00:00:00")
+//	@Param			until	query		string																				false	"Posts created before this date"	example("2024-12-31 23:59:59")
+//	@Success		200		{array}		github_com_orangeMangoDimz_go-social_internal_entities_posts.Feed					"Posts of the tag"
+//	@Failure		400		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Bad request"
+//	@Failure		401		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Unauthorized - invalid or missing token"
+//	@Failure		500		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Internal server error"
+//	@Router			/tags/{tag}/posts [get]
+func (h *httpHandler) getTagPostsHandler(w http.ResponseWriter, r *http.Request) {
+	tag, err := tagsEntity.Normalize(chi.URLParam(r, "tag"))
+	if err != nil {
+		protocol.BadRequestResponse(w, r, err)
+		return
+	}
+
+	fq := pagination.PaginatedQuery{
+		Limit:  20,
+		Offset: 0,
+		Sort:   "desc",
+	}
+
+	fq, err = fq.Parse(r)
+	if err != nil {
+		protocol.BadRequestResponse(w, r, err)
+		return
+	}
+
+	if err := protocol.ValidateStruct(fq); err != nil {
+		protocol.BadRequestResponse(w, r, err)
+		return
+	}
+
+	ctx := r.Context()
+	feed, err := h.TagService.GetPosts(ctx, tag, fq)
+	if err != nil {
+		protocol.InternalServerError(w, r, err)
+		return
+	}
+
+	// attach the reactions of the whole page with a single query
+	postIDs := make([]int64, len(feed))
+	for i, f := range feed {
+		postIDs[i] = f.ID
+	}
+
+	user := protocol.GetUserFromContext(r)
+	reactions, err := h.ReactionService.GetSummaries(ctx, postIDs, user.ID)
+	if err != nil {
+		protocol.InternalServerError(w, r, err)
+		return
+	}
+
+	for i := range feed {
+		feed[i].Reactions = reactions[feed[i].ID]
+	}
+
+	nextCursor := pagination.NextCursor(feed, fq, func(f postsEntity.Feed) (string, int64) {
+		return f.CreatedAt, f.ID
+	})
+
+	if err := protocol.PaginatedJsonResponse(w, http.StatusOK, feed, nextCursor); err != nil {
+		protocol.InternalServerError(w, r, err)
+		return
+	}
+}
+
+// getTrendingHandler godoc
+//
+//	@Summary		Get the trending tags
+//	@Description	Get the tags used by the most distinct authors over the trending window, 24 hours by default. The ranking is cached for a few minutes. Requires JWT authentication.
+//	@Tags			tags
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			limit	query		int																					false	"Number of tags (1-20)"	default(10)	example(10)
+//	@Success		200		{array}		github_com_orangeMangoDimz_go-social_internal_entities_tags.TrendingTag				"Trending tags, most used first"
+//	@Failure		400		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Bad request"
+//	@Failure		401		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Unauthorized - invalid or missing token"
+//	@Failure		500		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Internal server error"
+//	@Router			/tags/trending [get]
+func (h *httpHandler) getTrendingHandler(w http.ResponseWriter, r *http.Request) {
+	limit := 10
+	if l := r.URL.Query().Get("limit"); l != "" {
+		n, err := strconv.Atoi(l)
+		if err != nil {
+			protocol.BadRequestResponse(w, r, err)
+			return
+		}
+		limit = n
+	}
+
+	if limit < 1 || limit > tagsService.MaxTrending {
+		protocol.BadRequestResponse(w, r, fmt.Errorf("limit must be between 1 and %d", tagsService.MaxTrending))
+		return
+	}
+
+	trending, err := h.TagService.GetTrending(r.Context(), limit)
+	if err != nil {
+		protocol.InternalServerError(w, r, err)
+		return
+	}
+
+	if err := protocol.JsonResponse(w, http.StatusOK, trending); err != nil {
+		protocol.InternalServerError(w, r, err)
+		return
+	}
+}
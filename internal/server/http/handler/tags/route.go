@@ -0,0 +1,25 @@
+package tagsHandler
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/orangeMangoDimz/go-social/internal/ratelimiter"
+	middlewareHandler "github.com/orangeMangoDimz/go-social/internal/server/http/middleware"
+	"github.com/orangeMangoDimz/go-social/internal/service"
+	"go.uber.org/zap"
+)
+
+func RegisterRoute(
+	middlewareProvider middlewareHandler.MiddlewareProvider,
+	tagService service.TagService,
+	reactionService service.ReactionService,
+	logger zap.SugaredLogger,
+) func(chi.Router) {
+	return func(r chi.Router) {
+		handler := newHTTPHandler(tagService, reactionService, logger)
+		read := middlewareProvider.RateLimiterMiddleware(ratelimiter.PolicyDefault)
+
+		r.Use(middlewareProvider.AuthTokenMiddleware)
+		r.With(read).Get("/trending", handler.getTrendingHandler)
+		r.With(read).Get("/{tag}/posts", handler.getTagPostsHandler)
+	}
+}
@@ -1,27 +1,42 @@
 package usersHandler
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/go-chi/chi/v5"
+	postsEntity "github.com/orangeMangoDimz/go-social/internal/entities/posts"
+	usersEntity "github.com/orangeMangoDimz/go-social/internal/entities/users"
+	"github.com/orangeMangoDimz/go-social/internal/logging"
 	"github.com/orangeMangoDimz/go-social/internal/server/http/protocol"
 	"github.com/orangeMangoDimz/go-social/internal/service"
 	"github.com/orangeMangoDimz/go-social/internal/storage"
+	"github.com/orangeMangoDimz/go-social/internal/storage/postgres/pagination"
 	"go.uber.org/zap"
 )
 
 type httpHandler struct {
 	userService     service.UsersService
 	followerService service.FollowerService
+	postService     service.PostsService
+	reactionService service.ReactionService
 	logger          zap.SugaredLogger
 }
 
-func newHTTPHandler(userService service.UsersService, followerService service.FollowerService, logger zap.SugaredLogger) *httpHandler {
+func newHTTPHandler(
+	userService service.UsersService,
+	followerService service.FollowerService,
+	postService service.PostsService,
+	reactionService service.ReactionService,
+	logger zap.SugaredLogger,
+) *httpHandler {
 	return &httpHandler{
 		userService:     userService,
 		followerService: followerService,
+		postService:     postService,
+		reactionService: reactionService,
 		logger:          logger,
 	}
 }
@@ -29,21 +44,21 @@ func newHTTPHandler(userService service.UsersService, followerService service.Fo
 // GetUserHandler godoc
 //
 //	@Summary		Get user by ID
-//	@Description	Get detailed information about a specific user. Requires JWT authentication.
+//	@Description	Get detailed information about a specific user with follower, following and post counts, and whether the viewer follows the user or is followed back. Requires JWT authentication.
 //	@Tags			users
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			userID	path		int																	true	"User ID"	example(1)
-//	@Success		200		{object}	github_com_orangeMangoDimz_go-social_internal_entities_users.User	"User information"
-//	@Failure		401		{object}	map[string]string													"Unauthorized - invalid or missing token"
-//	@Failure		404		{object}	map[string]string													"User not found"
-//	@Failure		500		{object}	map[string]string													"Internal server error"
+//	@Param			userID	path		int																					true	"User ID"	example(1)
+//	@Success		200		{object}	github_com_orangeMangoDimz_go-social_internal_entities_users.Profile				"User profile"
+//	@Failure		401		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Unauthorized - invalid or missing token"
+//	@Failure		404		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"User not found"
+//	@Failure		500		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Internal server error"
 //	@Router			/users/{userID} [get]
 func (h *httpHandler) GetUserHandler(w http.ResponseWriter, r *http.Request) {
 	userID, err := strconv.ParseInt(chi.URLParam(r, "userID"), 10, 64)
 	if err != nil {
-		h.logger.Warn("Error parsing userID")
+		logging.FromContext(r.Context(), &h.logger).Warn("Error parsing userID")
 		protocol.BadRequestResponse(w, r, err)
 		return
 	}
@@ -53,17 +68,227 @@ func (h *httpHandler) GetUserHandler(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		switch {
 		case errors.Is(err, storage.ErrNotFound):
-			h.logger.Warnf("User with user_id %d not found", userID)
+			logging.FromContext(r.Context(), &h.logger).Warnf("User with user_id %d not found", userID)
 			protocol.NotFoundResponse(w, r, err)
 		default:
-			h.logger.Errorw("Failed to get user data", "user_id", userID, "error", err)
+			logging.FromContext(r.Context(), &h.logger).Errorw("Failed to get user data", "user_id", userID, "error", err)
 			protocol.InternalServerError(w, r, err)
 		}
 		return
 	}
 
-	if err := protocol.JsonResponse(w, http.StatusOK, user); err != nil {
-		h.logger.Errorw("Failed to send response", "error", err)
+	viewer := protocol.GetUserFromContext(r)
+	stats, err := h.followerService.GetStats(ctx, user.ID, viewer.ID)
+	if err != nil {
+		logging.FromContext(r.Context(), &h.logger).Errorw("Failed to get user stats", "user_id", userID, "error", err)
+		protocol.InternalServerError(w, r, err)
+		return
+	}
+
+	profile := usersEntity.Profile{
+		User:           *user,
+		FollowerCount:  stats.FollowerCount,
+		FollowingCount: stats.FollowingCount,
+		PostCount:      stats.PostCount,
+		IsFollowing:    stats.IsFollowing,
+		FollowsYou:     stats.FollowsYou,
+	}
+
+	if err := protocol.JsonResponse(w, http.StatusOK, profile); err != nil {
+		logging.FromContext(r.Context(), &h.logger).Errorw("Failed to send response", "error", err)
+		protocol.InternalServerError(w, r, err)
+		return
+	}
+}
+
+// getFollowersHandler godoc
+//
+//	@Summary		List followers of a user
+//	@Description	Get a paginated list of the users following a user, most recent follow first. Requires JWT authentication.
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			userID	path		int																					true	"User ID"							example(1)
+//	@Param			limit	query		int																					false	"Number of users per page (1-20)"	default(20)	example(10)
+//	@Param			offset	query		int																					false	"Number of users to skip"			default(0)	example(0)
+//	@Param			cursor	query		string																				false	"Cursor from the previous page"
+//	@Param			sort	query		string																				false	"Sort order (asc/desc)"	default(desc)	Enums(asc, desc)
+//	@Success		200		{array}		github_com_orangeMangoDimz_go-social_internal_entities_users.Connection				"Followers"
+//	@Failure		400		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Bad request"
+//	@Failure		401		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Unauthorized - invalid or missing token"
+//	@Failure		404		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"User not found"
+//	@Failure		500		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Internal server error"
+//	@Router			/users/{userID}/followers [get]
+func (h *httpHandler) getFollowersHandler(w http.ResponseWriter, r *http.Request) {
+	h.listConnections(w, r, h.followerService.GetFollowers)
+}
+
+// getFollowingHandler godoc
+//
+//	@Summary		List users followed by a user
+//	@Description	Get a paginated list of the users a user follows, most recent follow first. Requires JWT authentication.
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			userID	path		int																					true	"User ID"							example(1)
+//	@Param			limit	query		int																					false	"Number of users per page (1-20)"	default(20)	example(10)
+//	@Param			offset	query		int																					false	"Number of users to skip"			default(0)	example(0)
+//	@Param			cursor	query		string																				false	"Cursor from the previous page"
+//	@Param			sort	query		string																				false	"Sort order (asc/desc)"	default(desc)	Enums(asc, desc)
+//	@Success		200		{array}		github_com_orangeMangoDimz_go-social_internal_entities_users.Connection				"Followed users"
+//	@Failure		400		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Bad request"
+//	@Failure		401		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Unauthorized - invalid or missing token"
+//	@Failure		404		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"User not found"
+//	@Failure		500		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Internal server error"
+//	@Router			/users/{userID}/following [get]
+func (h *httpHandler) getFollowingHandler(w http.ResponseWriter, r *http.Request) {
+	h.listConnections(w, r, h.followerService.GetFollowing)
+}
+
+// getUserPostsHandler godoc
+//
+//	@Summary		List posts of a user
+//	@Description	Get a paginated list of the posts written by a user, for their profile page. Filterable like the feed. Requires JWT authentication.
+//	@Description	Pages can be walked with offset or, preferably, with the opaque cursor returned as next_cursor.
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			userID	path		int																					true	"User ID"							example(1)
+//	@Param			limit	query		int																					false	"Number of posts per page (1-20)"	default(20)	example(10)
+//	@Param			offset	query		int																					false	"Number of posts to skip"			default(0)	example(0)
+//	@Param			cursor	query		string																				false	"Cursor from the previous page"
+//	@Param			sort	query		string																				false	"Sort order (asc/desc)"				default(desc)	Enums(asc, desc)
+//	@Param			search	query		string																				false	"Search in title and content"		example("golang")
+//	@Param			tags	query		string																				false	"Comma-separated list of tags"		example("golang,programming")
+//	@Param			since	query		string																				false	"Posts created after this date"		example("2024-01-01 00:00:00")
+//	@Param			until	query		string																				false	"Posts created before this date"	example("2024-12-31 23:59:59")
+//	@Success		200		{array}		github_com_orangeMangoDimz_go-social_internal_entities_posts.Feed					"Posts of the user"
+//	@Failure		400		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Bad request"
+//	@Failure		401		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Unauthorized - invalid or missing token"
+//	@Failure		404		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"User not found"
+//	@Failure		500		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Internal server error"
+//	@Router			/users/{userID}/posts [get]
+func (h *httpHandler) getUserPostsHandler(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.ParseInt(chi.URLParam(r, "userID"), 10, 64)
+	if err != nil {
+		protocol.BadRequestResponse(w, r, err)
+		return
+	}
+
+	fq := pagination.PaginatedQuery{
+		Limit:  20,
+		Offset: 0,
+		Sort:   "desc",
+	}
+
+	fq, err = fq.Parse(r)
+	if err != nil {
+		protocol.BadRequestResponse(w, r, err)
+		return
+	}
+
+	if err := protocol.ValidateStruct(fq); err != nil {
+		protocol.BadRequestResponse(w, r, err)
+		return
+	}
+
+	ctx := r.Context()
+	if _, err := h.userService.GetById(ctx, userID); err != nil {
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			protocol.NotFoundResponse(w, r, err)
+		default:
+			protocol.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	posts, err := h.postService.GetUserPosts(ctx, userID, fq)
+	if err != nil {
+		logging.FromContext(r.Context(), &h.logger).Errorw("Failed to list user posts", "user_id", userID, "error", err)
+		protocol.InternalServerError(w, r, err)
+		return
+	}
+
+	// attach the reactions of the whole page with a single query
+	postIDs := make([]int64, len(posts))
+	for i, p := range posts {
+		postIDs[i] = p.ID
+	}
+
+	viewer := protocol.GetUserFromContext(r)
+	reactions, err := h.reactionService.GetSummaries(ctx, postIDs, viewer.ID)
+	if err != nil {
+		protocol.InternalServerError(w, r, err)
+		return
+	}
+
+	for i := range posts {
+		posts[i].Reactions = reactions[posts[i].ID]
+	}
+
+	nextCursor := pagination.NextCursor(posts, fq, func(p postsEntity.Feed) (string, int64) {
+		return p.CreatedAt, p.ID
+	})
+
+	if err := protocol.PaginatedJsonResponse(w, http.StatusOK, posts, nextCursor); err != nil {
+		protocol.InternalServerError(w, r, err)
+		return
+	}
+}
+
+type listConnectionsFunc func(context.Context, int64, pagination.PaginatedQuery) ([]usersEntity.Connection, error)
+
+func (h *httpHandler) listConnections(w http.ResponseWriter, r *http.Request, list listConnectionsFunc) {
+	userID, err := strconv.ParseInt(chi.URLParam(r, "userID"), 10, 64)
+	if err != nil {
+		protocol.BadRequestResponse(w, r, err)
+		return
+	}
+
+	fq := pagination.PaginatedQuery{
+		Limit:  20,
+		Offset: 0,
+		Sort:   "desc",
+	}
+
+	fq, err = fq.Parse(r)
+	if err != nil {
+		protocol.BadRequestResponse(w, r, err)
+		return
+	}
+
+	if err := protocol.ValidateStruct(fq); err != nil {
+		protocol.BadRequestResponse(w, r, err)
+		return
+	}
+
+	ctx := r.Context()
+	if _, err := h.userService.GetById(ctx, userID); err != nil {
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			protocol.NotFoundResponse(w, r, err)
+		default:
+			protocol.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	connections, err := list(ctx, userID, fq)
+	if err != nil {
+		logging.FromContext(r.Context(), &h.logger).Errorw("Failed to list connections", "user_id", userID, "error", err)
+		protocol.InternalServerError(w, r, err)
+		return
+	}
+
+	nextCursor := pagination.NextCursor(connections, fq, func(c usersEntity.Connection) (string, int64) {
+		return c.FollowedAt, c.ID
+	})
+
+	if err := protocol.PaginatedJsonResponse(w, http.StatusOK, connections, nextCursor); err != nil {
 		protocol.InternalServerError(w, r, err)
 		return
 	}
@@ -79,24 +304,24 @@ func (h *httpHandler) GetUserHandler(w http.ResponseWriter, r *http.Request) {
 //	@Security		BearerAuth
 //	@Param			userID	path	int	true	"ID of the user to follow"	example(1)
 //	@Success		204		"Successfully followed user"
-//	@Failure		400		{object}	map[string]string	"Bad request"
-//	@Failure		401		{object}	map[string]string	"Unauthorized - invalid or missing token"
-//	@Failure		404		{object}	map[string]string	"User not found"
-//	@Failure		409		{object}	map[string]string	"Already following this user"
-//	@Failure		500		{object}	map[string]string	"Internal server error"
+//	@Failure		400		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Bad request"
+//	@Failure		401		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Unauthorized - invalid or missing token"
+//	@Failure		404		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"User not found"
+//	@Failure		409		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Already following this user"
+//	@Failure		500		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Internal server error"
 //	@Router			/users/{userID}/follow [put]
 func (h *httpHandler) FollowUserHandler(w http.ResponseWriter, r *http.Request) {
 	// user to follow
 	followerUser := protocol.GetUserFromContext(r)
 	if followerUser == nil {
-		h.logger.Warnf("User with user_id %d not found", followerUser.ID)
+		logging.FromContext(r.Context(), &h.logger).Warnf("User with user_id %d not found", followerUser.ID)
 		protocol.NotFoundResponse(w, r, storage.ErrNotFound)
 		return
 	}
 
 	followedID, err := strconv.ParseInt(chi.URLParam(r, "userID"), 10, 64)
 	if err != nil {
-		h.logger.Warn("Error parsing userID")
+		logging.FromContext(r.Context(), &h.logger).Warn("Error parsing userID")
 		protocol.BadRequestResponse(w, r, err)
 		return
 	}
@@ -106,17 +331,17 @@ func (h *httpHandler) FollowUserHandler(w http.ResponseWriter, r *http.Request)
 	if err := h.followerService.Follow(ctx, followerUser.ID, followedID); err != nil {
 		switch {
 		case errors.Is(err, storage.ErrUniqueViolation):
-			h.logger.Warnw("Conflict on user followers", "error", err)
+			logging.FromContext(r.Context(), &h.logger).Warnw("Conflict on user followers", "error", err)
 			protocol.ConflictResponse(w, r, errors.New("YOU HAVE FOLLOWED THIS USER"))
 		default:
-			h.logger.Errorw("Failed to follow user", "error", err)
+			logging.FromContext(r.Context(), &h.logger).Errorw("Failed to follow user", "error", err)
 			protocol.InternalServerError(w, r, err)
 		}
 		return
 	}
 
 	if err := protocol.JsonResponse(w, http.StatusNoContent, nil); err != nil {
-		h.logger.Errorw("Failed to send response", "error", err)
+		logging.FromContext(r.Context(), &h.logger).Errorw("Failed to send response", "error", err)
 		protocol.InternalServerError(w, r, err)
 		return
 	}
@@ -132,11 +357,11 @@ func (h *httpHandler) FollowUserHandler(w http.ResponseWriter, r *http.Request)
 //	@Security		BearerAuth
 //	@Param			userID	path	int	true	"ID of the user to unfollow"	example(1)
 //	@Success		204		"Successfully unfollowed user"
-//	@Failure		400		{object}	map[string]string	"Bad request"
-//	@Failure		401		{object}	map[string]string	"Unauthorized - invalid or missing token"
-//	@Failure		404		{object}	map[string]string	"User not found"
-//	@Failure		409		{object}	map[string]string	"Already unfollowed this user"
-//	@Failure		500		{object}	map[string]string	"Internal server error"
+//	@Failure		400		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Bad request"
+//	@Failure		401		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Unauthorized - invalid or missing token"
+//	@Failure		404		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"User not found"
+//	@Failure		409		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Already unfollowed this user"
+//	@Failure		500		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Internal server error"
 //	@Router			/users/{userID}/unfollow [put]
 func (h *httpHandler) unfollowUserHandler(w http.ResponseWriter, r *http.Request) {
 	// user to follow
@@ -180,8 +405,8 @@ func (h *httpHandler) unfollowUserHandler(w http.ResponseWriter, r *http.Request
 //	@Produce		json
 //	@Param			token	path	string	true	"Activation token"	example("550e8400-e29b-41d4-a716-446655440000")
 //	@Success		202		"User account activated successfully"
-//	@Failure		404		{object}	map[string]string	"Token not found or invalid"
-//	@Failure		500		{object}	map[string]string	"Internal server error"
+//	@Failure		404		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Token not found or invalid"
+//	@Failure		500		{object}	github_com_orangeMangoDimz_go-social_internal_server_http_protocol.ErrorResponse	"Internal server error"
 //	@Router			/users/activate/{token} [put]
 func (h *httpHandler) activateUserHandler(w http.ResponseWriter, r *http.Request) {
 	token := chi.URLParam(r, "token")
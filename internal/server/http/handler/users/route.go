@@ -2,6 +2,7 @@ package usersHandler
 
 import (
 	"github.com/go-chi/chi/v5"
+	"github.com/orangeMangoDimz/go-social/internal/ratelimiter"
 	middlewareHandler "github.com/orangeMangoDimz/go-social/internal/server/http/middleware"
 	"github.com/orangeMangoDimz/go-social/internal/service"
 	"go.uber.org/zap"
@@ -11,16 +12,24 @@ func RegisterRoute(
 	middlewareProvider middlewareHandler.MiddlewareProvider,
 	userService service.UsersService,
 	followerService service.FollowerService,
+	postService service.PostsService,
+	reactionService service.ReactionService,
 	logger zap.SugaredLogger,
 ) func(chi.Router) {
 	return func(r chi.Router) {
-		handler := newHTTPHandler(userService, followerService, logger)
-		r.Put("/activate/{token}", handler.activateUserHandler)
+		handler := newHTTPHandler(userService, followerService, postService, reactionService, logger)
+		read := middlewareProvider.RateLimiterMiddleware(ratelimiter.PolicyDefault)
+		write := middlewareProvider.RateLimiterMiddleware(ratelimiter.PolicyWrite)
+
+		r.With(middlewareProvider.RateLimiterMiddleware(ratelimiter.PolicyAuth)).Put("/activate/{token}", handler.activateUserHandler)
 		r.Route("/{userID}", func(r chi.Router) {
 			r.Use(middlewareProvider.AuthTokenMiddleware)
-			r.Get("/", handler.GetUserHandler)
-			r.Put("/follow", handler.FollowUserHandler)
-			r.Put("/unfollow", handler.unfollowUserHandler)
+			r.With(read).Get("/", handler.GetUserHandler)
+			r.With(write).Put("/follow", handler.FollowUserHandler)
+			r.With(write).Put("/unfollow", handler.unfollowUserHandler)
+			r.With(read).Get("/followers", handler.getFollowersHandler)
+			r.With(read).Get("/following", handler.getFollowingHandler)
+			r.With(read).Get("/posts", handler.getUserPostsHandler)
 		})
 
 	}
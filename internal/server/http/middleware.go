@@ -5,14 +5,23 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"math"
+	"net"
 	"net/http"
+	"runtime/debug"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
 	"github.com/golang-jwt/jwt"
 	usersEntity "github.com/orangeMangoDimz/go-social/internal/entities/users"
+	"github.com/orangeMangoDimz/go-social/internal/health"
+	"github.com/orangeMangoDimz/go-social/internal/logging"
 	"github.com/orangeMangoDimz/go-social/internal/server/http/protocol"
 	"github.com/orangeMangoDimz/go-social/internal/storage"
+	"github.com/orangeMangoDimz/go-social/internal/tracing"
 )
 
 func (app *Application) AuthTokenMiddleware(next http.Handler) http.Handler {
@@ -45,18 +54,49 @@ func (app *Application) AuthTokenMiddleware(next http.Handler) http.Handler {
 			return
 		}
 
-		ctx := r.Context()
-		user, err := app.Services.UsersService.GetById(ctx, userID)
+		// every access token is bound to a session that can be revoked
+		sessionID, ok := claims["sid"].(string)
+		if !ok || sessionID == "" {
+			protocol.UnauthorizedErrorResponse(w, r, fmt.Errorf("token has no session"))
+			return
+		}
+
+		ctx, span := tracing.Start(r.Context(), "AuthTokenMiddleware")
+		user, err := app.sessionUser(ctx, sessionID, userID)
+		tracing.End(span, err)
 		if err != nil {
-			protocol.InternalServerError(w, r, err)
+			switch {
+			case errors.Is(err, storage.ErrSessionRevoked):
+				protocol.UnauthorizedErrorResponse(w, r, err)
+			default:
+				protocol.InternalServerError(w, r, err)
+			}
 			return
 		}
+
+		ctx = logging.WithUserID(r.Context(), user.ID)
 		ctx = context.WithValue(ctx, protocol.UserCtx, user)
+		ctx = context.WithValue(ctx, protocol.SessionCtx, sessionID)
 		next.ServeHTTP(w, r.WithContext(ctx))
 
 	})
 }
 
+// sessionUser returns the user of an access token, or storage.ErrSessionRevoked
+// when its session is no longer active.
+func (app *Application) sessionUser(ctx context.Context, sessionID string, userID int64) (*usersEntity.User, error) {
+	active, err := app.Services.SessionService.IsActive(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !active {
+		return nil, storage.ErrSessionRevoked
+	}
+
+	return app.GetUser(ctx, userID)
+}
+
 // BasicAuthMiddleware applies HTTP Basic auth to protected endpoints.
 func (app *Application) BasicAuthMiddleware() func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -125,6 +165,34 @@ func (app *Application) CheckPostOwnership(role string, next http.HandlerFunc) h
 	})
 }
 
+func (app *Application) CheckCommentOwnership(role string, next http.HandlerFunc) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user := protocol.GetUserFromContext(r)
+		comment := protocol.GetCommentFromContext(r)
+
+		// check if it is user comment
+		if comment.UserID == user.ID {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// role precedence check
+		ctx := r.Context()
+		allowed, err := app.checkRolePrecedence(ctx, user, role)
+		if err != nil {
+			protocol.InternalServerError(w, r, err)
+			return
+		}
+
+		if !allowed {
+			protocol.ForbiddenResponse(w, r)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
 func (app *Application) checkRolePrecedence(ctx context.Context, user *usersEntity.User, roleName string) (bool, error) {
 	role, err := app.Services.RoleService.GetByName(ctx, roleName)
 	if err != nil {
@@ -134,44 +202,128 @@ func (app *Application) checkRolePrecedence(ctx context.Context, user *usersEnti
 	return user.Role.Level >= role.Level, nil
 }
 
+// GetUser returns the user, from the cache unless it missed. The lookups are
+// counted by the instrumented cache storage.
 func (app *Application) GetUser(ctx context.Context, userID int64) (*usersEntity.User, error) {
-	app.Logger.Infow("checking cache for user", "id", userID)
-	user, err := app.CacheStorage.Users.Get(ctx, userID)
-	if err != nil {
-		return nil, err
-	}
+	return app.Services.UsersService.GetById(ctx, userID)
+}
 
-	if user == nil {
-		app.Logger.Infow("cache miss, fetching from DB", "id", userID)
-		user, err := app.Services.UsersService.GetById(ctx, userID)
-		if err != nil {
-			switch {
-			case errors.Is(err, storage.ErrNotFound):
-				return nil, storage.ErrNotFound
-			default:
-				return nil, err
+// RateLimiterMiddleware enforces the named rate limit policy. Authenticated
+// requests are counted per user and anonymous ones per client IP, so it has
+// to run after AuthTokenMiddleware on protected routes. Admins get the
+// elevated quota of the policy when it defines one.
+func (app *Application) RateLimiterMiddleware(policy string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !app.Config.RateLimiter.Enabled {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			client, elevated, err := app.rateLimitClient(r)
+			if err != nil {
+				protocol.InternalServerError(w, r, err)
+				return
 			}
+
+			res := app.RateLimiters.Limiter(policy, elevated).Take(policy + ":" + client)
+
+			w.Header().Set("RateLimit-Limit", strconv.Itoa(res.Limit))
+			w.Header().Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+			w.Header().Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(res.Reset)))
+
+			if !res.Allowed {
+				app.Metrics.RateLimited.WithLabelValues(policy).Inc()
+				protocol.RateLimitExceededResponse(w, r, res.RetryAfter)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// MetricsMiddleware records the count and latency of every request, labelled
+// by the route pattern that served it rather than the raw path so the number
+// of series stays bounded.
+func (app *Application) MetricsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+		next.ServeHTTP(ww, r)
+
+		// chi fills in the pattern while routing the request
+		route := "unmatched"
+		if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+			route = rctx.RoutePattern()
 		}
 
-		if err := app.CacheStorage.Users.Set(ctx, user); err != nil {
-			return nil, err
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
 		}
-	} else {
-		app.Logger.Infow("cache hit for user", "id", userID)
-	}
 
-	return user, nil
+		labels := []string{r.Method, route, strconv.Itoa(status)}
+		app.Metrics.HTTPRequests.WithLabelValues(labels...).Inc()
+		app.Metrics.HTTPDuration.WithLabelValues(labels...).Observe(time.Since(start).Seconds())
+	})
 }
 
-func (app *Application) RateLimiterMiddleware(next http.Handler) http.Handler {
+// RecovererMiddleware turns a panic into the usual internal error response and
+// logs it with its stack, like any other 5xx.
+func (app *Application) RecovererMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if app.Config.RateLimiter.Enabled {
-			if allow, retryAfter := app.RateLimiter.Allow(r.RemoteAddr); !allow {
-				protocol.RateLimitExceededResponse(w, r, retryAfter.String())
+		defer func() {
+			rec := recover()
+			if rec == nil {
 				return
 			}
-		}
+			// the client went away, let net/http abort the response
+			if rec == http.ErrAbortHandler {
+				panic(rec)
+			}
+
+			err, ok := rec.(error)
+			if !ok {
+				err = fmt.Errorf("%v", rec)
+			}
+			logging.FromContext(r.Context(), app.Logger).Errorw("panic recovered", "panic", rec, "stack", string(debug.Stack()))
+			protocol.InternalServerError(w, r, fmt.Errorf("panic: %w", err))
+		}()
 
 		next.ServeHTTP(w, r)
 	})
 }
+
+// Readiness runs the health checks of the dependencies. It reports shutting
+// down without running them once the server is draining.
+func (app *Application) Readiness(ctx context.Context) health.Report {
+	if app.shuttingDown.Load() {
+		return health.Report{Status: health.StatusShuttingDown, Checks: map[string]health.Result{}}
+	}
+
+	return health.Run(ctx, app.HealthChecks)
+}
+
+// rateLimitClient identifies who a request is counted against and whether
+// they are entitled to elevated quotas, which takes the level of an admin
+func (app *Application) rateLimitClient(r *http.Request) (string, bool, error) {
+	if user := protocol.GetUserFromContext(r); user != nil {
+		elevated, err := app.checkRolePrecedence(r.Context(), user, "admin")
+		return fmt.Sprintf("user:%d", user.ID), elevated, err
+	}
+
+	// middleware.RealIP already replaced RemoteAddr with the forwarded
+	// address when there is one, otherwise it still carries the port
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+
+	return "ip:" + ip, false, nil
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -5,12 +5,15 @@ import (
 	"net/http"
 
 	usersEntity "github.com/orangeMangoDimz/go-social/internal/entities/users"
+	"github.com/orangeMangoDimz/go-social/internal/health"
 )
 
 type MiddlewareProvider interface {
 	AuthTokenMiddleware(next http.Handler) http.Handler
 	BasicAuthMiddleware() func(http.Handler) http.Handler
 	CheckPostOwnership(role string, next http.HandlerFunc) http.HandlerFunc
+	CheckCommentOwnership(role string, next http.HandlerFunc) http.HandlerFunc
 	GetUser(ctx context.Context, userID int64) (*usersEntity.User, error)
-	RateLimiterMiddleware(next http.Handler) http.Handler
+	RateLimiterMiddleware(policy string) func(http.Handler) http.Handler
+	Readiness(ctx context.Context) health.Report
 }
@@ -3,15 +3,20 @@ package protocol
 import (
 	"net/http"
 
+	commentsEntity "github.com/orangeMangoDimz/go-social/internal/entities/comments"
 	postsEntity "github.com/orangeMangoDimz/go-social/internal/entities/posts"
 	usersEntity "github.com/orangeMangoDimz/go-social/internal/entities/users"
 )
 
 type userKey string
 type postKey string
+type commentKey string
+type sessionKey string
 
 const UserCtx userKey = "user"
 const PostCtx postKey = "post"
+const CommentCtx commentKey = "comment"
+const SessionCtx sessionKey = "session"
 
 func GetUserFromContext(r *http.Request) *usersEntity.User {
 	user, ok := r.Context().Value(UserCtx).(*usersEntity.User)
@@ -28,3 +33,19 @@ func GetPostFromContext(r *http.Request) *postsEntity.Post {
 	}
 	return post
 }
+
+func GetCommentFromContext(r *http.Request) *commentsEntity.Comment {
+	comment, ok := r.Context().Value(CommentCtx).(*commentsEntity.Comment)
+	if !ok {
+		return nil
+	}
+	return comment
+}
+
+func GetSessionIDFromContext(r *http.Request) string {
+	sessionID, ok := r.Context().Value(SessionCtx).(string)
+	if !ok {
+		return ""
+	}
+	return sessionID
+}
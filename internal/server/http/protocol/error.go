@@ -1,37 +1,243 @@
 package protocol
 
-import "net/http"
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-playground/validator/v10"
+	commentsEntity "github.com/orangeMangoDimz/go-social/internal/entities/comments"
+	tagsEntity "github.com/orangeMangoDimz/go-social/internal/entities/tags"
+	"github.com/orangeMangoDimz/go-social/internal/logging"
+	"github.com/orangeMangoDimz/go-social/internal/storage"
+	"go.uber.org/zap"
+)
+
+// Error codes, clients can rely on them staying the same
+const (
+	CodeBadRequest        = "bad_request"
+	CodeMalformedJSON     = "malformed_json"
+	CodeValidationFailed  = "validation_failed"
+	CodeInvalidParameter  = "invalid_parameter"
+	CodeUnauthorized      = "unauthorized"
+	CodeSessionRevoked    = "session_revoked"
+	CodeTokenReused       = "token_reused"
+	CodeForbidden         = "forbidden"
+	CodeNotFound          = "not_found"
+	CodeConflict          = "conflict"
+	CodeDuplicateEmail    = "duplicate_email"
+	CodeDuplicateUsername = "duplicate_username"
+	CodeInvalidParent     = "invalid_parent_comment"
+	CodeMaxDepthExceeded  = "max_depth_exceeded"
+	CodeInvalidTag        = "invalid_tag"
+	CodeTooManyTags       = "too_many_tags"
+	CodeRateLimited       = "rate_limited"
+	CodeInternal          = "internal_error"
+)
+
+// errorCodes gives the errors clients need to tell apart their own code
+var errorCodes = []struct {
+	err  error
+	code string
+}{
+	{storage.ErrDuplicateEmail, CodeDuplicateEmail},
+	{storage.ErrDuplicateUsername, CodeDuplicateUsername},
+	{storage.ErrSessionRevoked, CodeSessionRevoked},
+	{storage.ErrTokenReused, CodeTokenReused},
+	{commentsEntity.ErrInvalidParent, CodeInvalidParent},
+	{commentsEntity.ErrMaxDepthExceeded, CodeMaxDepthExceeded},
+	{tagsEntity.ErrInvalidTag, CodeInvalidTag},
+	{tagsEntity.ErrTooManyTags, CodeTooManyTags},
+}
+
+// Error is the body of every error response
+//
+//	@Description	Machine-readable error
+type Error struct {
+	Code      string       `json:"code" example:"validation_failed"`                      // Stable error code
+	Message   string       `json:"message" example:"the request has invalid fields"`      // Human readable description
+	RequestID string       `json:"request_id,omitempty" example:"host/AbCdEf1234-000001"` // ID of the request, to be quoted when reporting an issue
+	Fields    []FieldError `json:"fields,omitempty"`                                      // Invalid fields, for validation errors
+	status    int
+}
+
+// FieldError describes why a single field was rejected
+//
+//	@Description	Validation error of a single field
+type FieldError struct {
+	Field   string `json:"field" example:"email"`                   // JSON name of the field
+	Rule    string `json:"rule" example:"email"`                    // Validation rule that failed
+	Param   string `json:"param,omitempty" example:""`              // Parameter of the rule, such as the maximum length
+	Message string `json:"message" example:"must be a valid email"` // Human readable description
+}
+
+// ErrorResponse is the envelope of every error response
+//
+//	@Description	Error envelope
+type ErrorResponse struct {
+	Error *Error `json:"error"`
+}
+
+func (e *Error) Error() string {
+	return e.Code + ": " + e.Message
+}
+
+// Status returns the HTTP status of the error.
+func (e *Error) Status() int {
+	return e.status
+}
+
+// NewError builds an error response with the code of err when it is a known
+// error, or fallback otherwise.
+func NewError(status int, err error, fallback, message string) *Error {
+	return &Error{
+		Code:    codeOf(err, fallback),
+		Message: message,
+		status:  status,
+	}
+}
+
+func codeOf(err error, fallback string) string {
+	for _, c := range errorCodes {
+		if errors.Is(err, c.err) {
+			return c.code
+		}
+	}
+	return fallback
+}
+
+// WriteError writes apiErr tagged with the ID of the request, as an RFC 7807
+// document when the client asks for one and in the usual envelope otherwise.
+func WriteError(w http.ResponseWriter, r *http.Request, apiErr *Error) error {
+	apiErr.RequestID = middleware.GetReqID(r.Context())
+
+	w.Header().Add("Vary", "Accept")
+	if wantsProblem(r) {
+		return writeProblem(w, r, apiErr)
+	}
+	return WriteJSON(w, apiErr.status, &ErrorResponse{Error: apiErr})
+}
+
+// InternalServerError logs the cause and hides it from the client.
 func InternalServerError(w http.ResponseWriter, r *http.Request, err error) {
-	WriteJSONError(w, http.StatusInternalServerError, "Something went wrong")
+	logging.FromContext(r.Context(), zap.S()).Errorw("internal server error",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"error", err,
+	)
+	WriteError(w, r, NewError(http.StatusInternalServerError, nil, CodeInternal, "something went wrong"))
 }
 
+// BadRequestResponse describes what is wrong with the request without
+// leaking decoder or validator internals.
 func BadRequestResponse(w http.ResponseWriter, r *http.Request, err error) {
-	WriteJSONError(w, http.StatusBadRequest, err.Error())
+	var (
+		validationErrs validator.ValidationErrors
+		jsonErr        *MalformedJSONError
+		numErr         *strconv.NumError
+	)
+
+	apiErr := NewError(http.StatusBadRequest, err, CodeBadRequest, err.Error())
+	switch {
+	case errors.As(err, &validationErrs):
+		apiErr.Code = CodeValidationFailed
+		apiErr.Message = "the request has invalid fields"
+		apiErr.Fields = fieldErrors(validationErrs)
+	case errors.As(err, &jsonErr):
+		apiErr.Code = CodeMalformedJSON
+		apiErr.Message = jsonErr.Reason
+	case errors.As(err, &numErr):
+		apiErr.Code = CodeInvalidParameter
+		apiErr.Message = fmt.Sprintf("%q is not a valid number", numErr.Num)
+	}
+	WriteError(w, r, apiErr)
 }
 
 func ConflictResponse(w http.ResponseWriter, r *http.Request, err error) {
-	WriteJSONError(w, http.StatusConflict, err.Error())
+	WriteError(w, r, NewError(http.StatusConflict, err, CodeConflict, err.Error()))
 }
 
 func NotFoundResponse(w http.ResponseWriter, r *http.Request, err error) {
-	WriteJSONError(w, http.StatusNotFound, "not found")
+	WriteError(w, r, NewError(http.StatusNotFound, nil, CodeNotFound, "not found"))
 }
 
 func UnauthorizedErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
-	WriteJSONError(w, http.StatusUnauthorized, "unauthorized")
+	WriteError(w, r, NewError(http.StatusUnauthorized, err, CodeUnauthorized, "unauthorized"))
 }
 
 func ForbiddenResponse(w http.ResponseWriter, r *http.Request) {
-	WriteJSONError(w, http.StatusForbidden, "forbidden")
+	WriteError(w, r, NewError(http.StatusForbidden, nil, CodeForbidden, "forbidden"))
 }
 
 func UnauthorizedBasicErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
 	w.Header().Set("WWW-Authenticate", `Basic realm="restricted", charset="UTF-8"`)
-	WriteJSONError(w, http.StatusUnauthorized, "unauthorized")
+	WriteError(w, r, NewError(http.StatusUnauthorized, nil, CodeUnauthorized, "unauthorized"))
+}
+
+func RateLimitExceededResponse(w http.ResponseWriter, r *http.Request, retryAfter time.Duration) {
+	// Retry-After only accepts whole seconds, round up so clients don't retry too early
+	seconds := strconv.Itoa(int(math.Ceil(retryAfter.Seconds())))
+	w.Header().Set("Retry-After", seconds)
+	WriteError(w, r, NewError(http.StatusTooManyRequests, nil, CodeRateLimited, "rate limit exceeded, retry after: "+seconds+"s"))
+}
+
+func fieldErrors(errs validator.ValidationErrors) []FieldError {
+	fields := make([]FieldError, 0, len(errs))
+	for _, fe := range errs {
+		fields = append(fields, FieldError{
+			Field:   fieldPath(fe),
+			Rule:    fe.Tag(),
+			Param:   fe.Param(),
+			Message: ruleMessage(fe),
+		})
+	}
+	return fields
+}
+
+// fieldPath drops the name of the top level struct, so "Payload.tags[0]"
+// becomes "tags[0]".
+func fieldPath(fe validator.FieldError) string {
+	ns := fe.Namespace()
+	if i := strings.Index(ns, "."); i >= 0 {
+		return ns[i+1:]
+	}
+	return fe.Field()
+}
+
+func ruleMessage(fe validator.FieldError) string {
+	param := fe.Param()
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid email"
+	case "min":
+		return "must have at least " + param + " " + unit(fe.Kind())
+	case "max":
+		return "must have at most " + param + " " + unit(fe.Kind())
+	case "gte":
+		return "must be greater than or equal to " + param
+	case "lte":
+		return "must be less than or equal to " + param
+	case "oneof":
+		return "must be one of: " + param
+	default:
+		return "failed the " + fe.Tag() + " rule"
+	}
 }
 
-func RateLimitExceededResponse(w http.ResponseWriter, r *http.Request, retryAfter string) {
-	w.Header().Set("Retry-After", retryAfter)
-	WriteJSONError(w, http.StatusTooManyRequests, "rate limit exceeded, retry after: "+retryAfter)
+// unit names what min and max count for a field of the given kind.
+func unit(kind reflect.Kind) string {
+	switch kind {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return "items"
+	default:
+		return "characters"
+	}
 }
@@ -2,7 +2,12 @@ package protocol
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"reflect"
+	"strings"
 
 	"github.com/go-playground/validator/v10"
 )
@@ -11,6 +16,29 @@ var validate *validator.Validate
 
 func init() {
 	validate = validator.New(validator.WithRequiredStructEnabled())
+	// report fields under the name clients send them with
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+}
+
+// MalformedJSONError is returned by ReadJSON when the body cannot be decoded.
+// Reason is safe to show to clients.
+type MalformedJSONError struct {
+	Reason string
+	err    error
+}
+
+func (e *MalformedJSONError) Error() string {
+	return e.Reason
+}
+
+func (e *MalformedJSONError) Unwrap() error {
+	return e.err
 }
 
 func WriteJSON(w http.ResponseWriter, status int, data any) error {
@@ -26,14 +54,56 @@ func ReadJSON(w http.ResponseWriter, r *http.Request, data any) error {
 
 	decoder := json.NewDecoder(r.Body)
 	decoder.DisallowUnknownFields()
-	return decoder.Decode(data)
+	if err := decoder.Decode(data); err != nil {
+		return &MalformedJSONError{Reason: decodeReason(err), err: err}
+	}
+	return nil
 }
 
-func WriteJSONError(w http.ResponseWriter, status int, message string) error {
-	type envelope struct {
-		Error string `json:"error"`
+// decodeReason describes a decoding error without the Go types involved.
+func decodeReason(err error) string {
+	var (
+		syntaxErr   *json.SyntaxError
+		typeErr     *json.UnmarshalTypeError
+		maxBytesErr *http.MaxBytesError
+	)
+
+	switch {
+	case errors.Is(err, io.EOF):
+		return "the request body is empty"
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return "the request body is not valid JSON"
+	case errors.As(err, &syntaxErr):
+		return fmt.Sprintf("the request body is not valid JSON (at offset %d)", syntaxErr.Offset)
+	case errors.As(err, &typeErr):
+		if typeErr.Field != "" {
+			return fmt.Sprintf("field %q must be of type %s", typeErr.Field, jsonType(typeErr.Type.Kind()))
+		}
+		return "the request body must be a JSON object"
+	case errors.As(err, &maxBytesErr):
+		return fmt.Sprintf("the request body must not be larger than %d bytes", maxBytesErr.Limit)
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		return "unknown field " + strings.TrimPrefix(err.Error(), "json: unknown field ")
+	default:
+		return "the request body is not valid JSON"
+	}
+}
+
+func jsonType(kind reflect.Kind) string {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	default:
+		return "string"
 	}
-	return WriteJSON(w, status, &envelope{Error: message})
 }
 
 func JsonResponse(w http.ResponseWriter, status int, data any) error {
@@ -44,6 +114,15 @@ func JsonResponse(w http.ResponseWriter, status int, data any) error {
 	return WriteJSON(w, status, &envelope{Data: data})
 }
 
+func PaginatedJsonResponse(w http.ResponseWriter, status int, data any, nextCursor string) error {
+	type envelope struct {
+		Data       any    `json:"data"`
+		NextCursor string `json:"next_cursor,omitempty"`
+	}
+
+	return WriteJSON(w, status, &envelope{Data: data, NextCursor: nextCursor})
+}
+
 func ValidateStruct(s interface{}) error {
 	return validate.Struct(s)
 }
@@ -0,0 +1,77 @@
+package protocol
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ProblemContentType is the media type of RFC 7807 documents
+const ProblemContentType = "application/problem+json"
+
+// ProblemTypeBase prefixes the error code to build the type of a problem
+var ProblemTypeBase = "urn:go-social:problem:"
+
+// Problem is the RFC 7807 form of Error, sent to clients that ask for
+// application/problem+json
+//
+//	@Description	RFC 7807 problem details
+type Problem struct {
+	Type      string       `json:"type" example:"urn:go-social:problem:validation_failed"` // URI identifying the kind of problem
+	Title     string       `json:"title" example:"Bad Request"`                            // Summary of the status
+	Status    int          `json:"status" example:"400"`                                   // HTTP status
+	Detail    string       `json:"detail" example:"the request has invalid fields"`        // Explanation of this occurrence
+	Instance  string       `json:"instance" example:"/v1/authentication/user"`             // Path of the request
+	Code      string       `json:"code" example:"validation_failed"`                       // Stable error code
+	RequestID string       `json:"request_id,omitempty" example:"host/AbCdEf1234-000001"`  // ID of the request
+	Errors    []FieldError `json:"errors,omitempty"`                                       // Invalid fields, for validation errors
+}
+
+func newProblem(r *http.Request, apiErr *Error) *Problem {
+	return &Problem{
+		Type:      ProblemTypeBase + apiErr.Code,
+		Title:     http.StatusText(apiErr.status),
+		Status:    apiErr.status,
+		Detail:    apiErr.Message,
+		Instance:  r.URL.Path,
+		Code:      apiErr.Code,
+		RequestID: apiErr.RequestID,
+		Errors:    apiErr.Fields,
+	}
+}
+
+func writeProblem(w http.ResponseWriter, r *http.Request, apiErr *Error) error {
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(apiErr.status)
+	return json.NewEncoder(w).Encode(newProblem(r, apiErr))
+}
+
+// wantsProblem reports whether the client prefers problem documents to the
+// usual JSON envelope. It has to list application/problem+json explicitly,
+// wildcards keep the envelope.
+func wantsProblem(r *http.Request) bool {
+	problemQ, jsonQ := -1.0, -1.0
+	for _, accepted := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err != nil {
+			continue
+		}
+
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+
+		switch mediaType {
+		case ProblemContentType:
+			problemQ = max(problemQ, q)
+		case "application/json", "application/*", "*/*":
+			jsonQ = max(jsonQ, q)
+		}
+	}
+	return problemQ > 0 && problemQ >= jsonQ
+}
@@ -8,42 +8,71 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
+	"github.com/orangeMangoDimz/go-social/cmd/migrate/migrations"
 	"github.com/orangeMangoDimz/go-social/docs"
 	"github.com/orangeMangoDimz/go-social/internal/auth"
 	"github.com/orangeMangoDimz/go-social/internal/config"
 	"github.com/orangeMangoDimz/go-social/internal/db"
 	"github.com/orangeMangoDimz/go-social/internal/env"
+	"github.com/orangeMangoDimz/go-social/internal/health"
+	"github.com/orangeMangoDimz/go-social/internal/logging"
 	"github.com/orangeMangoDimz/go-social/internal/mailer"
+	"github.com/orangeMangoDimz/go-social/internal/metrics"
 	"github.com/orangeMangoDimz/go-social/internal/ratelimiter"
 	authHandler "github.com/orangeMangoDimz/go-social/internal/server/http/handler/auth"
 	healthHandler "github.com/orangeMangoDimz/go-social/internal/server/http/handler/health"
 	postsHandler "github.com/orangeMangoDimz/go-social/internal/server/http/handler/posts"
+	searchHandler "github.com/orangeMangoDimz/go-social/internal/server/http/handler/search"
+	tagsHandler "github.com/orangeMangoDimz/go-social/internal/server/http/handler/tags"
 	usersHandler "github.com/orangeMangoDimz/go-social/internal/server/http/handler/users"
+	timelinesService "github.com/orangeMangoDimz/go-social/internal/service/domain/timelines"
 	"github.com/orangeMangoDimz/go-social/internal/storage/cache"
+	postsCache "github.com/orangeMangoDimz/go-social/internal/storage/cache/posts"
+	rolesCache "github.com/orangeMangoDimz/go-social/internal/storage/cache/roles"
+	sessionsCache "github.com/orangeMangoDimz/go-social/internal/storage/cache/sessions"
+	usersCache "github.com/orangeMangoDimz/go-social/internal/storage/cache/users"
 	"github.com/orangeMangoDimz/go-social/internal/storage/postgres"
+	"github.com/orangeMangoDimz/go-social/internal/tracing"
+	"github.com/orangeMangoDimz/go-social/internal/worker"
 	"github.com/redis/go-redis/v9"
 	httpSwagger "github.com/swaggo/http-swagger"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
+// envDevelopment is the ENV of a local server, the only one allowed to write
+// emails to the log instead of sending them
+const envDevelopment = "development"
+
 // Configuration loaders
 func loadConfig() config.Config {
+	environment := env.GetString("ENV", envDevelopment)
+
 	return config.Config{
 		Addr:        env.GetString("ADDR", ":8000"),
 		Db:          loadDbConfig(),
-		Env:         env.GetString("ENV", "development"),
+		Env:         environment,
 		ApiURL:      env.GetString("EXTERNAL_URL", "localhost:8000"),
 		FrontendURL: env.GetString("FRONTEND_URL", "http://localhost:3000"),
-		Mail:        loadMailConfig(),
+		Mail:        loadMailConfig(environment),
 		Auth:        loadAuthConfig(),
 		RedisCfg:    loadRedisConfig(),
+		Cache:       loadCacheConfig(),
 		RateLimiter: loadRateLimiterConfig(),
+		Comments:    loadCommentsConfig(),
+		Outbox:      loadOutboxConfig(),
+		Invitations: loadInvitationsConfig(),
+		Tracing:     loadTracingConfig(),
+		Health:      loadHealthConfig(),
+		Tags:        loadTagsConfig(),
+		Timeline:    loadTimelineConfig(),
 	}
 }
 
@@ -56,10 +85,26 @@ func loadDbConfig() config.DbConfig {
 	}
 }
 
-func loadMailConfig() config.MailConfig {
+func loadMailConfig(environment string) config.MailConfig {
+	// emails are only written to the log in development, anywhere else a
+	// provider has to be chosen or the server does not start
+	provider := ""
+	if environment == envDevelopment {
+		provider = mailer.ProviderLog
+	}
+
 	return config.MailConfig{
-		Exp:       time.Hour * 24 * 3, // 3 days
-		FromEmail: env.GetString("FROM_EMAIL", ""),
+		Exp:              time.Hour * 24 * 3, // 3 days
+		PasswordResetExp: time.Hour,          // 1 hour
+		FromEmail:        env.GetString("FROM_EMAIL", ""),
+		Provider:         env.GetString("MAILER_PROVIDER", provider),
+		SMTP: config.SMTPConfig{
+			Host:     env.GetString("SMTP_HOST", "localhost"),
+			Port:     env.GetInt("SMTP_PORT", 1025),
+			Username: env.GetString("SMTP_USERNAME", ""),
+			Password: env.GetString("SMTP_PASSWORD", ""),
+			TLSMode:  env.GetString("SMTP_TLS_MODE", mailer.TLSModeNone),
+		},
 		SendGrid: config.SendGridConfig{
 			ApiKey: env.GetString("SENDGRID_API_KEY", ""),
 		},
@@ -76,9 +121,10 @@ func loadAuthConfig() config.AuthConfig {
 			Pass: env.GetString("AUTH_BASIC_PASS", "admin"),
 		},
 		Token: config.TokenConfig{
-			Secret: env.GetString("AUTH_TOKEN_SECRET", "example"),
-			Exp:    time.Hour * 24 * 3, // 3 days
-			Iss:    "gophersocial",
+			Secret:     env.GetString("AUTH_TOKEN_SECRET", "example"),
+			Exp:        time.Minute * 15,   // 15 minutes
+			RefreshExp: time.Hour * 24 * 7, // 7 days
+			Iss:        "gophersocial",
 		},
 	}
 }
@@ -92,15 +138,111 @@ func loadRedisConfig() config.RedisConfig {
 	}
 }
 
+func loadCacheConfig() config.CacheConfig {
+	return config.CacheConfig{
+		UserTTL:    time.Second * time.Duration(env.GetInt("CACHE_USER_TTL_SECONDS", int(usersCache.UserExpTime.Seconds()))),
+		PostTTL:    time.Second * time.Duration(env.GetInt("CACHE_POST_TTL_SECONDS", int(postsCache.PostExpTime.Seconds()))),
+		RoleTTL:    time.Second * time.Duration(env.GetInt("CACHE_ROLE_TTL_SECONDS", int(rolesCache.RoleExpTime.Seconds()))),
+		SessionTTL: time.Second * time.Duration(env.GetInt("CACHE_SESSION_TTL_SECONDS", int(sessionsCache.SessionExpTime.Seconds()))),
+	}
+}
+
 func loadRateLimiterConfig() ratelimiter.Config {
 	return ratelimiter.Config{
 		RequestPerTimeFrame: env.GetInt("RATELIMITER_REQUESTS_COUNT", 20),
 		TimeFrame:           time.Second * 5,
-		Enabled:             env.GetBool("RATELIMITER_ENABLED", true),
+		Algorithm:           env.GetString("RATELIMITER_ALGORITHM", ratelimiter.AlgorithmFixedWindow),
+		Backend:             env.GetString("RATELIMITER_BACKEND", ratelimiter.BackendMemory),
+		Policies: map[string]ratelimiter.Policy{
+			ratelimiter.PolicyDefault: {
+				RequestPerTimeFrame:         env.GetInt("RATELIMITER_REQUESTS_COUNT", 20),
+				TimeFrame:                   time.Second * 5,
+				ElevatedRequestPerTimeFrame: env.GetInt("RATELIMITER_ADMIN_REQUESTS_COUNT", 100),
+			},
+			ratelimiter.PolicyAuth: {
+				RequestPerTimeFrame: env.GetInt("RATELIMITER_AUTH_REQUESTS_COUNT", 10),
+				TimeFrame:           time.Minute,
+			},
+			ratelimiter.PolicyWrite: {
+				RequestPerTimeFrame:         env.GetInt("RATELIMITER_WRITE_REQUESTS_COUNT", 20),
+				TimeFrame:                   time.Minute,
+				ElevatedRequestPerTimeFrame: env.GetInt("RATELIMITER_WRITE_ADMIN_REQUESTS_COUNT", 100),
+			},
+		},
+		Enabled: env.GetBool("RATELIMITER_ENABLED", true),
+	}
+}
+
+func loadCommentsConfig() config.CommentsConfig {
+	return config.CommentsConfig{
+		MaxDepth: env.GetInt("COMMENTS_MAX_DEPTH", 3),
+	}
+}
+
+func loadOutboxConfig() config.OutboxConfig {
+	return config.OutboxConfig{
+		Enabled:      env.GetBool("OUTBOX_WORKER_ENABLED", true),
+		PollInterval: time.Second * 2,
+		BatchSize:    env.GetInt("OUTBOX_BATCH_SIZE", 10),
+		MaxAttempts:  env.GetInt("OUTBOX_MAX_ATTEMPTS", 8),
+		BaseBackoff:  time.Second * 30,
+		MaxBackoff:   time.Hour,
+		Lease:        time.Minute,
+	}
+}
+
+func loadInvitationsConfig() config.InvitationsConfig {
+	return config.InvitationsConfig{
+		ResendCooldown:    time.Second * time.Duration(env.GetInt("INVITATIONS_RESEND_COOLDOWN_SECONDS", 120)),
+		PurgeEnabled:      env.GetBool("INVITATIONS_PURGE_ENABLED", true),
+		PurgeInterval:     time.Hour,
+		MaxUnactivatedAge: time.Hour * 24 * time.Duration(env.GetInt("INVITATIONS_MAX_UNACTIVATED_DAYS", 7)),
+	}
+}
+
+func loadTagsConfig() config.TagsConfig {
+	return config.TagsConfig{
+		TrendingWindow: time.Hour * time.Duration(env.GetInt("TAGS_TRENDING_WINDOW_HOURS", 24)),
+	}
+}
+
+func loadTimelineConfig() config.TimelineConfig {
+	return config.TimelineConfig{
+		Enabled:            env.GetBool("TIMELINE_ENABLED", false),
+		MaxEntries:         env.GetInt("TIMELINE_MAX_ENTRIES", timelinesService.DefaultMaxEntries),
+		FanoutMaxFollowers: env.GetInt("TIMELINE_FANOUT_MAX_FOLLOWERS", timelinesService.DefaultFanoutMaxFollowers),
+	}
+}
+
+func loadTracingConfig() config.TracingConfig {
+	return config.TracingConfig{
+		Exporter:    env.GetString("TRACING_EXPORTER", tracing.ExporterNone),
+		Endpoint:    env.GetString("TRACING_OTLP_ENDPOINT", ""),
+		ServiceName: env.GetString("TRACING_SERVICE_NAME", "go-social"),
+		SampleRatio: env.GetFloat("TRACING_SAMPLE_RATIO", 1),
+	}
+}
+
+func loadHealthConfig() config.HealthConfig {
+	return config.HealthConfig{
+		CheckTimeout:  time.Millisecond * time.Duration(env.GetInt("HEALTH_CHECK_TIMEOUT_MS", 2000)),
+		ShutdownDrain: time.Second * time.Duration(env.GetInt("SHUTDOWN_DRAIN_SECONDS", 5)),
 	}
 }
 
 // Component initializers
+func initLogger(level string) *zap.SugaredLogger {
+	cfg := zap.NewProductionConfig()
+	if l, err := zapcore.ParseLevel(level); err == nil {
+		cfg.Level = zap.NewAtomicLevelAt(l)
+	}
+	logger := zap.Must(cfg.Build())
+	// the global logger is the fallback of requests without a logger of
+	// their own, see logging.FromContext
+	zap.ReplaceGlobals(logger)
+	return logger.Sugar()
+}
+
 func initDatabase(cfg config.DbConfig, logger *zap.SugaredLogger) *sql.DB {
 	db, err := db.New(cfg.Addr, cfg.MaxOpenConns, cfg.MaxIdleConns, cfg.MaxIdleTime)
 	if err != nil {
@@ -115,22 +257,63 @@ func initCache(cfg config.RedisConfig, logger *zap.SugaredLogger) *redis.Client
 		return nil
 	}
 	rdb := cache.NewRedisClient(cfg.Addr, cfg.Password, cfg.Db)
+	rdb.AddHook(tracing.RedisHook{})
 	logger.Info("Redis connection established")
 	return rdb
 }
 
-func initMailer(cfg config.MailConfig, logger *zap.SugaredLogger) mailer.Client {
-	client, err := mailer.NewMailTrapClient(cfg.MailTrap.ApiKey, cfg.FromEmail)
+func initMailer(cfg config.MailConfig, environment string, logger *zap.SugaredLogger) mailer.Client {
+	if cfg.Provider == mailer.ProviderLog && environment != envDevelopment {
+		logger.Fatalw("Failed to initialize mailer: the log provider only runs in development", "env", environment)
+	}
+
+	client, err := mailer.NewClient(cfg, logger)
 	if err != nil {
 		logger.Fatal("Failed to initialize mailer:", err)
 	}
+	logger.Infow("Mailer initialized", "provider", cfg.Provider)
 	return client
 }
 
+func initHealthChecks(cfg config.Config, database *sql.DB, rdb *redis.Client, logger *zap.SugaredLogger) []health.Check {
+	version, err := migrations.LatestVersion()
+	if err != nil {
+		logger.Fatal("Failed to read the migrations:", err)
+	}
+
+	checks := []health.Check{
+		health.Postgres(database, cfg.Health.CheckTimeout),
+		health.Migrations(database, version, cfg.Health.CheckTimeout),
+	}
+	if rdb != nil {
+		checks = append(checks, health.Redis(rdb, cfg.Health.CheckTimeout))
+	}
+	return checks
+}
+
+func initRateLimiters(cfg ratelimiter.Config, rdb *redis.Client, logger *zap.SugaredLogger) *ratelimiter.Registry {
+	var factory ratelimiter.LimiterFactory = ratelimiter.NewInMemoryLimiter
+
+	if cfg.Backend == ratelimiter.BackendRedis {
+		if rdb == nil {
+			logger.Warn("Redis rate limiter requested but Redis is disabled, using in-memory limiter")
+		} else {
+			factory = ratelimiter.NewRedisFactory(rdb)
+			logger.Info("Redis rate limiter enabled")
+		}
+	}
+
+	registry, err := ratelimiter.NewRegistry(cfg, factory)
+	if err != nil {
+		logger.Fatal("Failed to initialize rate limiter:", err)
+	}
+	return registry
+}
+
 // NewApp creates and configures a new Application instance
 func NewApp() (*sql.DB, *Application) {
 	// Initialize logger first
-	logger := zap.Must(zap.NewProduction()).Sugar()
+	logger := initLogger(env.GetString("LOG_LEVEL", "info"))
 
 	// Load configuration
 	config := loadConfig()
@@ -138,7 +321,8 @@ func NewApp() (*sql.DB, *Application) {
 	// Initialize components
 	database := initDatabase(config.Db, logger)
 	cacheClient := initCache(config.RedisCfg, logger)
-	mailClient := initMailer(config.Mail, logger)
+	appMetrics := metrics.New(database)
+	mailClient := mailer.NewInstrumentedClient(initMailer(config.Mail, config.Env, logger), appMetrics.MailSends)
 
 	// Initialize auth and rate limiter
 	jwtAuth := auth.NewJWTAuthenticator(
@@ -147,20 +331,24 @@ func NewApp() (*sql.DB, *Application) {
 		config.Auth.Token.Iss,
 	)
 
-	rateLimiter := ratelimiter.NewFixedWindowLimiter(
-		config.RateLimiter.RequestPerTimeFrame,
-		config.RateLimiter.TimeFrame,
-	)
+	rateLimiters := initRateLimiters(config.RateLimiter, cacheClient, logger)
+
+	if config.Timeline.Enabled && cacheClient == nil {
+		logger.Warn("Timelines requested but Redis is disabled, computing feeds on read")
+		config.Timeline.Enabled = false
+	}
 
 	// Build application
 	app := Application{
 		Config:        config,
 		Store:         postgres.NewStore(database),
-		CacheStorage:  cache.NewRedisStorage(cacheClient),
+		CacheStorage:  cache.NewInstrumentedStorage(cache.NewRedisStorage(cacheClient, config.Cache), appMetrics.CacheRequests),
 		Logger:        logger,
 		Mail:          mailClient,
 		Authenticator: jwtAuth,
-		RateLimiter:   rateLimiter,
+		RateLimiters:  rateLimiters,
+		Metrics:       appMetrics,
+		HealthChecks:  initHealthChecks(config, database, cacheClient, logger),
 	}
 
 	return database, &app
@@ -172,35 +360,38 @@ func (app *Application) Mount(version string) http.Handler {
 	// A good base middleware stack
 	r.Use(middleware.RequestID)
 	r.Use(middleware.RealIP)
-	r.Use(middleware.Logger)
-	r.Use(middleware.Recoverer)
+	r.Use(tracing.Middleware)
+	r.Use(app.MetricsMiddleware)
+	r.Use(logging.Middleware(app.Logger))
+	r.Use(app.RecovererMiddleware)
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{env.GetString("CORS_ALLOWED_ORIGIN", "")},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
 		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
-		ExposedHeaders:   []string{"Link"},
+		ExposedHeaders:   []string{"Link", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "Retry-After"},
 		AllowCredentials: false,
 		MaxAge:           300, // Maximum value not ignored by any of major browsers
 	}))
 
-	if app.Config.RateLimiter.Enabled {
-		r.Use(app.RateLimiterMiddleware)
-	}
-
 	// Set a timeout value on the request context (ctx), that will signal
 	// through ctx.Done() that the request has timed out and further
 	// processing should be stopped.
 	r.Use(middleware.Timeout(60 * time.Second))
 
+	// Scraped by Prometheus
+	r.With(app.BasicAuthMiddleware()).Handle("/metrics", app.Metrics.Handler())
+
 	r.Route("/v1", func(r chi.Router) {
-		r.Group(healthHandler.RegisterRoute(app, app.Config, version))
+		r.Group(healthHandler.RegisterRoute(app, app.Config, version, *app.Logger))
 
 		docsURL := fmt.Sprintf("%s/swagger/doc.json", app.Config.Addr)
 		r.Get("/swagger/*", httpSwagger.Handler(httpSwagger.URL(docsURL)))
-		r.Route("/posts", postsHandler.RegisterRoute(app, app.Services.PostService, app.Services.CommentService, *app.Logger))
-		r.Route("/users", usersHandler.RegisterRoute(app, app.Services.UsersService, app.Services.FollowerService, *app.Logger))
+		r.Route("/posts", postsHandler.RegisterRoute(app, app.Services.PostService, app.Services.CommentService, app.Services.ReactionService, *app.Logger))
+		r.Route("/users", usersHandler.RegisterRoute(app, app.Services.UsersService, app.Services.FollowerService, app.Services.PostService, app.Services.ReactionService, *app.Logger))
+		r.Route("/search", searchHandler.RegisterRoute(app, app.Services.SearchService, *app.Logger))
+		r.Route("/tags", tagsHandler.RegisterRoute(app, app.Services.TagService, app.Services.ReactionService, *app.Logger))
 		// Authentication routes
-		r.Route("/authentication", authHandler.RegisterRoute(app.Services.UsersService, *app.Logger, app.Mail, app.Config, app.Authenticator))
+		r.Route("/authentication", authHandler.RegisterRoute(app, app.Services.UsersService, app.Services.SessionService, *app.Logger, app.Config, app.Authenticator))
 	})
 
 	return r
@@ -212,6 +403,12 @@ func (app *Application) Run(handler http.Handler, version string) error {
 	docs.SwaggerInfo.Host = app.Config.ApiURL
 	docs.SwaggerInfo.BasePath = "/v1"
 
+	shutdownTracing, err := tracing.Setup(context.Background(), app.Config.Tracing, version)
+	if err != nil {
+		return err
+	}
+	app.Logger.Infow("Tracing initialized", "exporter", app.Config.Tracing.Exporter)
+
 	server := http.Server{
 		Addr:         app.Config.Addr,
 		Handler:      handler,
@@ -220,6 +417,11 @@ func (app *Application) Run(handler http.Handler, version string) error {
 		IdleTimeout:  time.Minute,
 	}
 
+	// Background workers stop once the server has shut down
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	workersDone := app.startWorkers(workerCtx)
+
 	// Channel to handle graceful shutdown errors
 	shutdown := make(chan error)
 
@@ -232,12 +434,17 @@ func (app *Application) Run(handler http.Handler, version string) error {
 		signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 		s := <-quit // Block until a signal is received
 
+		app.Logger.Infow("signal caught", "signal", s.String())
+
+		// Fail readiness and keep serving until load balancers stop sending traffic
+		app.shuttingDown.Store(true)
+		app.Logger.Infow("draining before shutdown", "delay", app.Config.Health.ShutdownDrain.String())
+		time.Sleep(app.Config.Health.ShutdownDrain)
+
 		// Create context with 5-second timeout for graceful shutdown
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 
-		app.Logger.Infow("signal caught", "signal", s.String())
-
 		// Send shutdown result to main goroutine
 		shutdown <- server.Shutdown(ctx)
 	}()
@@ -245,7 +452,7 @@ func (app *Application) Run(handler http.Handler, version string) error {
 	app.Logger.Infow("server has started", "addr", app.Config.Addr, "env", app.Config.Env)
 
 	// Start the HTTP server (blocking call)
-	err := server.ListenAndServe()
+	err = server.ListenAndServe()
 	if !errors.Is(err, http.ErrServerClosed) {
 		return err
 	}
@@ -256,6 +463,48 @@ func (app *Application) Run(handler http.Handler, version string) error {
 		return err
 	}
 
+	stopWorkers()
+	<-workersDone
+
+	// Flush the spans of the last requests
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := shutdownTracing(ctx); err != nil {
+		app.Logger.Errorw("Failed to flush traces", "error", err)
+	}
+
 	app.Logger.Info("Server stopped gracefully")
 	return nil
 }
+
+// startWorkers runs the background workers until ctx is cancelled. The
+// returned channel is closed once they have all returned.
+func (app *Application) startWorkers(ctx context.Context) <-chan struct{} {
+	var wg sync.WaitGroup
+
+	if app.Config.Outbox.Enabled {
+		outboxWorker := worker.NewOutboxWorker(app.Store.Outbox, app.Mail, app.Config, app.Logger)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			outboxWorker.Run(ctx)
+		}()
+	}
+
+	if app.Config.Invitations.PurgeEnabled {
+		purger := worker.NewInvitationPurger(app.Store.Users, app.Config, app.Logger)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			purger.Run(ctx)
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		wg.Wait()
+	}()
+
+	return done
+}
@@ -1,8 +1,12 @@
 package httpserver
 
 import (
+	"sync/atomic"
+
 	"github.com/orangeMangoDimz/go-social/internal/config"
+	"github.com/orangeMangoDimz/go-social/internal/health"
 	"github.com/orangeMangoDimz/go-social/internal/mailer"
+	"github.com/orangeMangoDimz/go-social/internal/metrics"
 	"github.com/orangeMangoDimz/go-social/internal/ratelimiter"
 	authHandler "github.com/orangeMangoDimz/go-social/internal/server/http/handler/auth"
 	"github.com/orangeMangoDimz/go-social/internal/service"
@@ -18,7 +22,12 @@ type Application struct {
 	Logger        *zap.SugaredLogger
 	Mail          mailer.Client
 	Authenticator authHandler.Authenticator
-	RateLimiter   ratelimiter.Limiter
+	RateLimiters  *ratelimiter.Registry
+	Metrics       *metrics.Metrics
+	HealthChecks  []health.Check
 	Us            service.UsersService
 	Services      service.Service
+
+	// shuttingDown fails readiness once a shutdown signal is caught
+	shuttingDown atomic.Bool
 }
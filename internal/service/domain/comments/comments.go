@@ -2,29 +2,77 @@ package commentsService
 
 import (
 	"context"
+	"errors"
 
 	commentsEntity "github.com/orangeMangoDimz/go-social/internal/entities/comments"
 	"github.com/orangeMangoDimz/go-social/internal/storage"
+	"github.com/orangeMangoDimz/go-social/internal/storage/postgres/pagination"
 )
 
 type CommentService struct {
 	commentRepository storage.CommentsRepository
-	// validation
+	// maxDepth is the deepest level a reply can be nested at, 0 disables replies
+	maxDepth int
 }
 
-func NewPostService(commentRepository storage.CommentsRepository) *CommentService {
+func NewPostService(commentRepository storage.CommentsRepository, maxDepth int) *CommentService {
 	return &CommentService{
 		commentRepository: commentRepository,
-		// validation
+		maxDepth:          maxDepth,
 	}
 }
 
+// Create stores the comment. A reply has to target a comment of the same
+// post and is placed one level below it, as long as that stays within the
+// configured depth.
 func (s *CommentService) Create(ctx context.Context, comment *commentsEntity.Comment) error {
+	if comment.ParentID != nil {
+		parent, err := s.commentRepository.GetByID(ctx, *comment.ParentID)
+		if err != nil {
+			switch {
+			case errors.Is(err, storage.ErrNotFound):
+				return commentsEntity.ErrInvalidParent
+			default:
+				return err
+			}
+		}
+
+		if parent.PostID != comment.PostID {
+			return commentsEntity.ErrInvalidParent
+		}
+
+		if parent.Depth+1 > s.maxDepth {
+			return commentsEntity.ErrMaxDepthExceeded
+		}
+
+		comment.Depth = parent.Depth + 1
+	}
+
 	err := s.commentRepository.Create(ctx, comment)
 	return err
 }
 
-func (s *CommentService) GetByPostID(ctx context.Context, postID int64) ([]commentsEntity.Comment, error) {
-	comment, err := s.commentRepository.GetByPostID(ctx, postID)
+func (s *CommentService) GetByPostID(ctx context.Context, postID int64, fq pagination.PaginatedQuery) ([]commentsEntity.Comment, error) {
+	comments, err := s.commentRepository.GetByPostID(ctx, postID, fq)
+	return comments, err
+}
+
+func (s *CommentService) GetReplies(ctx context.Context, commentID int64, fq pagination.PaginatedQuery) ([]commentsEntity.Comment, error) {
+	replies, err := s.commentRepository.GetReplies(ctx, commentID, fq)
+	return replies, err
+}
+
+func (s *CommentService) GetByID(ctx context.Context, commentID int64) (*commentsEntity.Comment, error) {
+	comment, err := s.commentRepository.GetByID(ctx, commentID)
 	return comment, err
 }
+
+func (s *CommentService) Update(ctx context.Context, comment *commentsEntity.Comment) error {
+	err := s.commentRepository.Update(ctx, comment)
+	return err
+}
+
+func (s *CommentService) Delete(ctx context.Context, commentID int64) error {
+	err := s.commentRepository.Delete(ctx, commentID)
+	return err
+}
@@ -3,27 +3,69 @@ package followersService
 import (
 	"context"
 
+	usersEntity "github.com/orangeMangoDimz/go-social/internal/entities/users"
+	"github.com/orangeMangoDimz/go-social/internal/logging"
+	"github.com/orangeMangoDimz/go-social/internal/service"
 	"github.com/orangeMangoDimz/go-social/internal/storage"
+	"github.com/orangeMangoDimz/go-social/internal/storage/postgres/pagination"
+	"go.uber.org/zap"
 )
 
 type FollowerService struct {
 	followerRepository storage.FollowersRepository
+	timelineService    service.TimelineService
+	Logger             *zap.SugaredLogger
 	// validation
 }
 
-func NewFollowerService(followerRepository storage.FollowersRepository) *FollowerService {
+func NewFollowerService(followerRepository storage.FollowersRepository, timelineService service.TimelineService, logger *zap.SugaredLogger) *FollowerService {
 	return &FollowerService{
 		followerRepository: followerRepository,
+		timelineService:    timelineService,
+		Logger:             logger,
 		// validation
 	}
 }
 
+// Follow makes followedID, the caller, follow userID.
 func (s *FollowerService) Follow(ctx context.Context, followedID, userID int64) error {
-	err := s.followerRepository.Follow(ctx, followedID, userID)
-	return err
+	if err := s.followerRepository.Follow(ctx, followedID, userID); err != nil {
+		return err
+	}
+
+	s.rebuildTimeline(ctx, followedID)
+	return nil
 }
 
+// Unfollow makes followedID, the caller, stop following userID.
 func (s *FollowerService) Unfollow(ctx context.Context, followedID, userID int64) error {
-	err := s.followerRepository.Unfollow(ctx, followedID, userID)
-	return err
+	if err := s.followerRepository.Unfollow(ctx, followedID, userID); err != nil {
+		return err
+	}
+
+	s.rebuildTimeline(ctx, followedID)
+	return nil
+}
+
+// rebuildTimeline adds or drops the posts of a user the caller started or
+// stopped following.
+func (s *FollowerService) rebuildTimeline(ctx context.Context, userID int64) {
+	if err := s.timelineService.Rebuild(ctx, userID); err != nil {
+		logging.FromContext(ctx, s.Logger).Warnw("failed to rebuild the timeline", "user_id", userID, "error", err)
+	}
+}
+
+func (s *FollowerService) GetFollowers(ctx context.Context, userID int64, fq pagination.PaginatedQuery) ([]usersEntity.Connection, error) {
+	followers, err := s.followerRepository.GetFollowers(ctx, userID, fq)
+	return followers, err
+}
+
+func (s *FollowerService) GetFollowing(ctx context.Context, userID int64, fq pagination.PaginatedQuery) ([]usersEntity.Connection, error) {
+	following, err := s.followerRepository.GetFollowing(ctx, userID, fq)
+	return following, err
+}
+
+func (s *FollowerService) GetStats(ctx context.Context, userID, viewerID int64) (*usersEntity.ProfileStats, error) {
+	stats, err := s.followerRepository.GetStats(ctx, userID, viewerID)
+	return stats, err
 }
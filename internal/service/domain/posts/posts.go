@@ -2,45 +2,94 @@ package postsService
 
 import (
 	"context"
+	"fmt"
 
 	postsEntity "github.com/orangeMangoDimz/go-social/internal/entities/posts"
+	"github.com/orangeMangoDimz/go-social/internal/logging"
+	"github.com/orangeMangoDimz/go-social/internal/service"
 	"github.com/orangeMangoDimz/go-social/internal/storage"
+	"github.com/orangeMangoDimz/go-social/internal/storage/cache"
 	"github.com/orangeMangoDimz/go-social/internal/storage/postgres/pagination"
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
 )
 
 type PostService struct {
-	postRepository storage.PostsRepository
+	postRepository  storage.PostsRepository
+	postCache       cache.PostsCache
+	loads           singleflight.Group
+	timelineService service.TimelineService
+	Logger          *zap.SugaredLogger
 	// validation
 }
 
-func NewPostService(postRepository storage.PostsRepository) *PostService {
+func NewPostService(postRepository storage.PostsRepository, postCache cache.PostsCache, timelineService service.TimelineService, logger *zap.SugaredLogger) *PostService {
 	return &PostService{
-		postRepository: postRepository,
+		postRepository:  postRepository,
+		postCache:       postCache,
+		timelineService: timelineService,
+		Logger:          logger,
 		// validation
 	}
 }
 
 func (s *PostService) GetUserFeed(ctx context.Context, userID int64, fq pagination.PaginatedQuery) ([]postsEntity.Feed, error) {
+	if s.timelineService.Enabled() {
+		return s.timelineService.GetFeed(ctx, userID, fq)
+	}
+
 	feeds, err := s.postRepository.GetUserFeed(ctx, userID, fq)
 	return feeds, err
 }
 
+func (s *PostService) GetUserPosts(ctx context.Context, userID int64, fq pagination.PaginatedQuery) ([]postsEntity.Feed, error) {
+	posts, err := s.postRepository.GetUserPosts(ctx, userID, fq)
+	return posts, err
+}
+
 func (s *PostService) Create(ctx context.Context, post *postsEntity.Post) error {
-	err := s.postRepository.Create(ctx, post)
-	return err
+	if err := s.postRepository.Create(ctx, post); err != nil {
+		return err
+	}
+
+	// the post is saved, the followers see it once their timeline is rebuilt
+	if err := s.timelineService.FanOut(ctx, post); err != nil {
+		logging.FromContext(ctx, s.Logger).Warnw("failed to fan out the post", "post_id", post.ID, "error", err)
+	}
+	return nil
 }
 
 func (s *PostService) GetById(ctx context.Context, postId int64) (*postsEntity.Post, error) {
-	post, err := s.postRepository.GetById(ctx, postId)
-	return post, err
+	return cache.GetOrLoad(ctx, &s.loads, fmt.Sprint(postId), s.Logger,
+		func(ctx context.Context) (*postsEntity.Post, error) { return s.postCache.Get(ctx, postId) },
+		func(ctx context.Context) (*postsEntity.Post, error) { return s.postRepository.GetById(ctx, postId) },
+		s.postCache.Set,
+	)
 }
 
 func (s *PostService) Update(ctx context.Context, post *postsEntity.Post) error {
-	err := s.postRepository.Update(ctx, post)
-	return err
+	if err := s.postRepository.Update(ctx, post); err != nil {
+		return err
+	}
+
+	s.evict(ctx, post.ID)
+	return nil
 }
 
 func (s *PostService) Delete(ctx context.Context, postID int64) error {
-	err := s.postRepository.Delete(ctx, postID)
-	return err
+	if err := s.postRepository.Delete(ctx, postID); err != nil {
+		return err
+	}
+
+	s.evict(ctx, postID)
+	return nil
+}
+
+// evict drops the cached copy of a post that was written and keeps the loads
+// that read it before the write from caching it again, a failure leaves it
+// stale until it expires.
+func (s *PostService) evict(ctx context.Context, postID int64) {
+	if err := s.postCache.Delete(ctx, postID); err != nil {
+		logging.FromContext(ctx, s.Logger).Warnw("failed to evict the post from the cache", "post_id", postID, "error", err)
+	}
 }
@@ -0,0 +1,48 @@
+package reactionsService
+
+import (
+	"context"
+
+	reactionsEntity "github.com/orangeMangoDimz/go-social/internal/entities/reactions"
+	"github.com/orangeMangoDimz/go-social/internal/storage"
+	"github.com/orangeMangoDimz/go-social/internal/storage/postgres/pagination"
+)
+
+type ReactionService struct {
+	reactionRepository storage.ReactionsRepository
+}
+
+func NewReactionService(reactionRepository storage.ReactionsRepository) *ReactionService {
+	return &ReactionService{
+		reactionRepository: reactionRepository,
+	}
+}
+
+func (s *ReactionService) React(ctx context.Context, reaction *reactionsEntity.Reaction) error {
+	err := s.reactionRepository.Add(ctx, reaction)
+	return err
+}
+
+func (s *ReactionService) Unreact(ctx context.Context, postID, userID int64, kind string) error {
+	err := s.reactionRepository.Remove(ctx, postID, userID, kind)
+	return err
+}
+
+func (s *ReactionService) GetByPostID(ctx context.Context, postID int64, kind string, fq pagination.PaginatedQuery) ([]reactionsEntity.Reaction, error) {
+	reactions, err := s.reactionRepository.GetByPostID(ctx, postID, kind, fq)
+	return reactions, err
+}
+
+// GetSummary returns the reaction summary of a single post as seen by viewerID
+func (s *ReactionService) GetSummary(ctx context.Context, postID, viewerID int64) (*reactionsEntity.Summary, error) {
+	summaries, err := s.reactionRepository.GetSummaries(ctx, []int64{postID}, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	return summaries[postID], nil
+}
+
+func (s *ReactionService) GetSummaries(ctx context.Context, postIDs []int64, viewerID int64) (map[int64]*reactionsEntity.Summary, error) {
+	summaries, err := s.reactionRepository.GetSummaries(ctx, postIDs, viewerID)
+	return summaries, err
+}
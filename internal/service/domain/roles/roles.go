@@ -5,21 +5,34 @@ import (
 
 	usersEntity "github.com/orangeMangoDimz/go-social/internal/entities/users"
 	"github.com/orangeMangoDimz/go-social/internal/storage"
+	"github.com/orangeMangoDimz/go-social/internal/storage/cache"
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
 )
 
 type RoleService struct {
 	roleRepository storage.RolesRepository
+	roleCache      cache.RolesCache
+	loads          singleflight.Group
+	Logger         *zap.SugaredLogger
 	// validation
 }
 
-func NewRoleService(roleRepository storage.RolesRepository) *RoleService {
+func NewRoleService(roleRepository storage.RolesRepository, roleCache cache.RolesCache, logger *zap.SugaredLogger) *RoleService {
 	return &RoleService{
 		roleRepository: roleRepository,
+		roleCache:      roleCache,
+		Logger:         logger,
 		// validation
 	}
 }
 
+// GetByName reads the role from the cache, the ownership checks load it on
+// every write.
 func (s *RoleService) GetByName(ctx context.Context, roleName string) (*usersEntity.Role, error) {
-	role, err := s.roleRepository.GetByName(ctx, roleName)
-	return role, err
+	return cache.GetOrLoad(ctx, &s.loads, roleName, s.Logger,
+		func(ctx context.Context) (*usersEntity.Role, error) { return s.roleCache.Get(ctx, roleName) },
+		func(ctx context.Context) (*usersEntity.Role, error) { return s.roleRepository.GetByName(ctx, roleName) },
+		s.roleCache.Set,
+	)
 }
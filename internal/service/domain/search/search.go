@@ -0,0 +1,33 @@
+package searchService
+
+import (
+	"context"
+	"fmt"
+
+	searchEntity "github.com/orangeMangoDimz/go-social/internal/entities/search"
+	"github.com/orangeMangoDimz/go-social/internal/storage"
+)
+
+type SearchService struct {
+	searchRepository storage.SearchRepository
+}
+
+func NewSearchService(searchRepository storage.SearchRepository) *SearchService {
+	return &SearchService{
+		searchRepository: searchRepository,
+	}
+}
+
+// Search runs the query against the kind of document it targets
+func (s *SearchService) Search(ctx context.Context, q searchEntity.Query) ([]searchEntity.Result, error) {
+	switch q.Type {
+	case searchEntity.TypePosts:
+		return s.searchRepository.Posts(ctx, q)
+	case searchEntity.TypeComments:
+		return s.searchRepository.Comments(ctx, q)
+	case searchEntity.TypeUsers:
+		return s.searchRepository.Users(ctx, q)
+	default:
+		return nil, fmt.Errorf("unknown search type %q", q.Type)
+	}
+}
@@ -6,18 +6,31 @@ import (
 	commentsService "github.com/orangeMangoDimz/go-social/internal/service/domain/comments"
 	followersService "github.com/orangeMangoDimz/go-social/internal/service/domain/followers"
 	postsService "github.com/orangeMangoDimz/go-social/internal/service/domain/posts"
+	reactionsService "github.com/orangeMangoDimz/go-social/internal/service/domain/reactions"
 	rolesService "github.com/orangeMangoDimz/go-social/internal/service/domain/roles"
+	searchService "github.com/orangeMangoDimz/go-social/internal/service/domain/search"
+	sessionsService "github.com/orangeMangoDimz/go-social/internal/service/domain/sessions"
+	tagsService "github.com/orangeMangoDimz/go-social/internal/service/domain/tags"
+	timelinesService "github.com/orangeMangoDimz/go-social/internal/service/domain/timelines"
 	usersService "github.com/orangeMangoDimz/go-social/internal/service/domain/users"
 	"github.com/orangeMangoDimz/go-social/internal/storage"
+	"github.com/orangeMangoDimz/go-social/internal/storage/cache"
 	"go.uber.org/zap"
 )
 
-func NewService(repository storage.Storage, logger *zap.SugaredLogger, config config.Config) *service.Service {
+func NewService(repository storage.Storage, cacheStorage cache.Storage, logger *zap.SugaredLogger, config config.Config) *service.Service {
+	timelineService := timelinesService.NewTimelineService(repository.Timelines, cacheStorage.Timelines, config.Timeline, logger)
+
 	return &service.Service{
-		UsersService:    usersService.NewUserService(repository.Users, logger, config),
-		FollowerService: followersService.NewFollowerService(repository.Followers),
-		PostService:     postsService.NewPostService(repository.Posts),
-		RoleService:     rolesService.NewRoleService(repository.Roles),
-		CommentService:  commentsService.NewPostService(repository.Comments),
+		UsersService:    usersService.NewUserService(repository.Users, cacheStorage.Users, cacheStorage.Sessions, logger, config),
+		FollowerService: followersService.NewFollowerService(repository.Followers, timelineService, logger),
+		PostService:     postsService.NewPostService(repository.Posts, cacheStorage.Posts, timelineService, logger),
+		RoleService:     rolesService.NewRoleService(repository.Roles, cacheStorage.Roles, logger),
+		CommentService:  commentsService.NewPostService(repository.Comments, config.Comments.MaxDepth),
+		SessionService:  sessionsService.NewSessionService(repository.Sessions, cacheStorage.Sessions, config.Auth.Token.RefreshExp, logger),
+		ReactionService: reactionsService.NewReactionService(repository.Reactions),
+		SearchService:   searchService.NewSearchService(repository.Search),
+		TagService:      tagsService.NewTagService(repository.Tags, cacheStorage.Tags, config.Tags.TrendingWindow, logger),
+		TimelineService: timelineService,
 	}
 }
@@ -0,0 +1,104 @@
+package sessionsService
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	sessionsEntity "github.com/orangeMangoDimz/go-social/internal/entities/sessions"
+	"github.com/orangeMangoDimz/go-social/internal/logging"
+	"github.com/orangeMangoDimz/go-social/internal/storage"
+	"github.com/orangeMangoDimz/go-social/internal/storage/cache"
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+)
+
+type SessionService struct {
+	sessionRepository storage.SessionsRepository
+	sessionCache      cache.SessionsCache
+	loads             singleflight.Group
+	refreshTokenExp   time.Duration
+	Logger            *zap.SugaredLogger
+}
+
+func NewSessionService(sessionRepository storage.SessionsRepository, sessionCache cache.SessionsCache, refreshTokenExp time.Duration, logger *zap.SugaredLogger) *SessionService {
+	return &SessionService{
+		sessionRepository: sessionRepository,
+		sessionCache:      sessionCache,
+		refreshTokenExp:   refreshTokenExp,
+		Logger:            logger,
+	}
+}
+
+// Create opens a new session for the user and returns it together with the
+// plain refresh token. Only the hash of the token is stored.
+func (s *SessionService) Create(ctx context.Context, userID int64) (*sessionsEntity.Session, string, error) {
+	session := &sessionsEntity.Session{
+		ID:     uuid.New().String(),
+		UserID: userID,
+	}
+
+	plainToken := uuid.New().String()
+	if err := s.sessionRepository.Create(ctx, session, hashToken(plainToken), s.refreshTokenExp); err != nil {
+		return nil, "", err
+	}
+
+	return session, plainToken, nil
+}
+
+// Refresh rotates the given refresh token and returns its session with the
+// plain replacement token.
+func (s *SessionService) Refresh(ctx context.Context, refreshToken string) (*sessionsEntity.Session, string, error) {
+	plainToken := uuid.New().String()
+	session, err := s.sessionRepository.Rotate(ctx, hashToken(refreshToken), hashToken(plainToken), s.refreshTokenExp)
+	if errors.Is(err, storage.ErrTokenReused) {
+		s.revokeCached(ctx, session.ID)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	return session, plainToken, nil
+}
+
+// IsActive reads the state of the session from the cache, every
+// authenticated request checks its session.
+func (s *SessionService) IsActive(ctx context.Context, sessionID string) (bool, error) {
+	active, err := cache.GetOrLoad(ctx, &s.loads, sessionID, s.Logger,
+		func(ctx context.Context) (*bool, error) { return s.sessionCache.Get(ctx, sessionID) },
+		func(ctx context.Context) (*bool, error) {
+			active, err := s.sessionRepository.IsActive(ctx, sessionID)
+			return &active, err
+		},
+		func(ctx context.Context, active *bool) error { return s.sessionCache.Set(ctx, sessionID, *active) },
+	)
+	if err != nil {
+		return false, err
+	}
+	return *active, nil
+}
+
+func (s *SessionService) Revoke(ctx context.Context, sessionID string) error {
+	if err := s.sessionRepository.Revoke(ctx, sessionID); err != nil {
+		return err
+	}
+
+	s.revokeCached(ctx, sessionID)
+	return nil
+}
+
+// revokeCached caches a session revoked in Postgres as revoked, a failure
+// leaves it usable until it expires from the cache.
+func (s *SessionService) revokeCached(ctx context.Context, sessionID string) {
+	if err := s.sessionCache.Revoke(ctx, sessionID); err != nil {
+		logging.FromContext(ctx, s.Logger).Warnw("failed to revoke the session in the cache", "session_id", sessionID, "error", err)
+	}
+}
+
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
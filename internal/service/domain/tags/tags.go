@@ -0,0 +1,62 @@
+package tagsService
+
+import (
+	"context"
+	"time"
+
+	postsEntity "github.com/orangeMangoDimz/go-social/internal/entities/posts"
+	tagsEntity "github.com/orangeMangoDimz/go-social/internal/entities/tags"
+	"github.com/orangeMangoDimz/go-social/internal/logging"
+	"github.com/orangeMangoDimz/go-social/internal/storage"
+	"github.com/orangeMangoDimz/go-social/internal/storage/cache"
+	"github.com/orangeMangoDimz/go-social/internal/storage/postgres/pagination"
+	"go.uber.org/zap"
+)
+
+// MaxTrending is how many trending tags are computed and cached
+const MaxTrending = 20
+
+type TagService struct {
+	tagRepository  storage.TagsRepository
+	tagCache       cache.TagsCache
+	trendingWindow time.Duration
+	Logger         *zap.SugaredLogger
+}
+
+func NewTagService(tagRepository storage.TagsRepository, tagCache cache.TagsCache, trendingWindow time.Duration, logger *zap.SugaredLogger) *TagService {
+	return &TagService{
+		tagRepository:  tagRepository,
+		tagCache:       tagCache,
+		trendingWindow: trendingWindow,
+		Logger:         logger,
+	}
+}
+
+func (s *TagService) GetPosts(ctx context.Context, tag string, fq pagination.PaginatedQuery) ([]postsEntity.Feed, error) {
+	feed, err := s.tagRepository.GetPosts(ctx, tag, fq)
+	return feed, err
+}
+
+// GetTrending returns the top limit trending tags. They are computed at most
+// once per cache period, a cache failure only costs the query.
+func (s *TagService) GetTrending(ctx context.Context, limit int) ([]tagsEntity.TrendingTag, error) {
+	logger := logging.FromContext(ctx, s.Logger)
+
+	trending, err := s.tagCache.GetTrending(ctx)
+	if err != nil {
+		logger.Warnw("failed to read the trending tags from the cache", "error", err)
+	}
+
+	if trending == nil {
+		trending, err = s.tagRepository.GetTrending(ctx, s.trendingWindow, MaxTrending)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := s.tagCache.SetTrending(ctx, trending); err != nil {
+			logger.Warnw("failed to cache the trending tags", "error", err)
+		}
+	}
+
+	return trending[:min(limit, len(trending))], nil
+}
@@ -0,0 +1,133 @@
+package timelinesService
+
+import (
+	"context"
+
+	"github.com/orangeMangoDimz/go-social/internal/config"
+	postsEntity "github.com/orangeMangoDimz/go-social/internal/entities/posts"
+	"github.com/orangeMangoDimz/go-social/internal/logging"
+	"github.com/orangeMangoDimz/go-social/internal/storage"
+	"github.com/orangeMangoDimz/go-social/internal/storage/cache"
+	"github.com/orangeMangoDimz/go-social/internal/storage/postgres/pagination"
+	"go.uber.org/zap"
+)
+
+const (
+	DefaultMaxEntries         = 800
+	DefaultFanoutMaxFollowers = 10000
+
+	// rebuildBatchSize is how many users RebuildAll reads at once
+	rebuildBatchSize = 100
+)
+
+// TimelineService precomputes the home timelines: a new post is pushed to the
+// timeline of every follower of its author (fan-out on write), unless the
+// author has more than FanoutMaxFollowers followers, whose posts are merged in
+// when the feed is read (fan-out on read).
+type TimelineService struct {
+	timelineRepository storage.TimelinesRepository
+	timelineCache      cache.TimelinesCache
+	config             config.TimelineConfig
+	Logger             *zap.SugaredLogger
+}
+
+func NewTimelineService(timelineRepository storage.TimelinesRepository, timelineCache cache.TimelinesCache, config config.TimelineConfig, logger *zap.SugaredLogger) *TimelineService {
+	return &TimelineService{
+		timelineRepository: timelineRepository,
+		timelineCache:      timelineCache,
+		config:             config,
+		Logger:             logger,
+	}
+}
+
+// Enabled reports whether feeds are read from the timelines.
+func (s *TimelineService) Enabled() bool {
+	return s.config.Enabled
+}
+
+// FanOut pushes post to the timelines of the followers of its author.
+func (s *TimelineService) FanOut(ctx context.Context, post *postsEntity.Post) error {
+	if !s.config.Enabled {
+		return nil
+	}
+
+	followers, err := s.timelineRepository.GetFollowerIDs(ctx, post.UserId, s.config.FanoutMaxFollowers+1)
+	if err != nil {
+		return err
+	}
+
+	// too many followers, the post is read from Postgres instead
+	if len(followers) > s.config.FanoutMaxFollowers {
+		return nil
+	}
+
+	return s.timelineCache.Push(ctx, post.ID, followers, s.config.MaxEntries)
+}
+
+// GetFeed reads the feed of userID from their timeline, which is rebuilt
+// first when it is not cached.
+func (s *TimelineService) GetFeed(ctx context.Context, userID int64, fq pagination.PaginatedQuery) ([]postsEntity.Feed, error) {
+	logger := logging.FromContext(ctx, s.Logger)
+
+	postIDs, err := s.timelineCache.Get(ctx, userID)
+	if err != nil {
+		logger.Warnw("failed to read the timeline from the cache", "user_id", userID, "error", err)
+	}
+
+	// an empty timeline is cached too, only nil is a miss
+	if postIDs == nil {
+		postIDs, err = s.timelineRepository.GetPostIDs(ctx, userID, s.config.FanoutMaxFollowers, s.config.MaxEntries)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := s.timelineCache.Set(ctx, userID, postIDs); err != nil {
+			logger.Warnw("failed to cache the timeline", "user_id", userID, "error", err)
+		}
+	}
+
+	return s.timelineRepository.GetFeed(ctx, userID, postIDs, s.config.FanoutMaxFollowers, fq)
+}
+
+// Rebuild repopulates the timeline of userID from Postgres, for instance once
+// they followed or unfollowed someone.
+func (s *TimelineService) Rebuild(ctx context.Context, userID int64) error {
+	if !s.config.Enabled {
+		return nil
+	}
+
+	postIDs, err := s.timelineRepository.GetPostIDs(ctx, userID, s.config.FanoutMaxFollowers, s.config.MaxEntries)
+	if err != nil {
+		return err
+	}
+
+	return s.timelineCache.Set(ctx, userID, postIDs)
+}
+
+// RebuildAll rebuilds the timeline of every user and returns how many were
+// rebuilt.
+func (s *TimelineService) RebuildAll(ctx context.Context) (int, error) {
+	var (
+		rebuilt int
+		afterID int64
+	)
+
+	for {
+		userIDs, err := s.timelineRepository.GetUserIDs(ctx, afterID, rebuildBatchSize)
+		if err != nil {
+			return rebuilt, err
+		}
+
+		for _, userID := range userIDs {
+			if err := s.Rebuild(ctx, userID); err != nil {
+				return rebuilt, err
+			}
+			rebuilt++
+		}
+
+		if len(userIDs) < rebuildBatchSize {
+			return rebuilt, nil
+		}
+		afterID = userIDs[len(userIDs)-1]
+	}
+}
@@ -2,31 +2,46 @@ package usersService
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/orangeMangoDimz/go-social/internal/config"
+	outboxEntity "github.com/orangeMangoDimz/go-social/internal/entities/outbox"
 	usersEntity "github.com/orangeMangoDimz/go-social/internal/entities/users"
+	"github.com/orangeMangoDimz/go-social/internal/logging"
 	"github.com/orangeMangoDimz/go-social/internal/storage"
+	"github.com/orangeMangoDimz/go-social/internal/storage/cache"
 	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
 )
 
 type UserService struct {
 	userRepository storage.UsersRepository
+	userCache      cache.UsersCache
+	sessionCache   cache.SessionsCache
+	loads          singleflight.Group
 	Logger         *zap.SugaredLogger
 	Config         config.Config
 }
 
-func NewUserService(userRepository storage.UsersRepository, logger *zap.SugaredLogger, config config.Config) *UserService {
+func NewUserService(userRepository storage.UsersRepository, userCache cache.UsersCache, sessionCache cache.SessionsCache, logger *zap.SugaredLogger, config config.Config) *UserService {
 	return &UserService{
 		userRepository: userRepository,
+		userCache:      userCache,
+		sessionCache:   sessionCache,
 		Logger:         logger,
 		Config:         config,
 	}
 }
 
+// GetById reads the user from the cache, every authenticated request loads
+// its user.
 func (s *UserService) GetById(ctx context.Context, userID int64) (*usersEntity.User, error) {
-	user, err := s.userRepository.GetById(ctx, userID)
-	return user, err
+	return cache.GetOrLoad(ctx, &s.loads, fmt.Sprint(userID), s.Logger,
+		func(ctx context.Context) (*usersEntity.User, error) { return s.userCache.Get(ctx, userID) },
+		func(ctx context.Context) (*usersEntity.User, error) { return s.userRepository.GetById(ctx, userID) },
+		s.userCache.Set,
+	)
 }
 
 func (s *UserService) GetByEmail(ctx context.Context, userEmail string) (*usersEntity.User, error) {
@@ -38,17 +53,72 @@ func (s *UserService) FollowUser(ctx context.Context, followedUserID int64, foll
 	return nil
 }
 
-func (s *UserService) CreateAndInvite(ctx context.Context, user *usersEntity.User, token string, invitationExp time.Duration) error {
-	err := s.userRepository.CreateAndInvite(ctx, user, token, invitationExp)
+func (s *UserService) CreateAndInvite(ctx context.Context, user *usersEntity.User, token string, invitationExp time.Duration, email *outboxEntity.Email) error {
+	err := s.userRepository.CreateAndInvite(ctx, user, token, invitationExp, email)
 	return err
 }
 
-func (s *UserService) Activate(ctx context.Context, token string) error {
-	err := s.userRepository.Activate(ctx, token)
+// GetPendingByEmail returns the account registered with the email that has
+// not been activated yet.
+func (s *UserService) GetPendingByEmail(ctx context.Context, userEmail string) (*usersEntity.User, error) {
+	user, err := s.userRepository.GetPendingByEmail(ctx, userEmail)
+	return user, err
+}
+
+// ReissueInvitation replaces the invitations of the user with a new one and
+// queues its email, unless the last one was sent less than cooldown ago.
+func (s *UserService) ReissueInvitation(ctx context.Context, userID int64, token string, invitationExp, cooldown time.Duration, email *outboxEntity.Email) error {
+	err := s.userRepository.ReissueInvitation(ctx, userID, token, invitationExp, cooldown, email)
 	return err
 }
 
+func (s *UserService) Activate(ctx context.Context, token string) error {
+	userID, err := s.userRepository.Activate(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx, s.Logger).Infow("user account activated", "user_id", userID)
+	s.evict(ctx, userID)
+	return nil
+}
+
 func (s *UserService) Delete(ctx context.Context, userID int64) error {
-	err := s.userRepository.Delete(ctx, userID)
+	if err := s.userRepository.Delete(ctx, userID); err != nil {
+		return err
+	}
+
+	s.evict(ctx, userID)
+	return nil
+}
+
+// CreatePasswordReset replaces the reset tokens of the user with a new one and
+// queues its email.
+func (s *UserService) CreatePasswordReset(ctx context.Context, userID int64, token string, resetExp time.Duration, email *outboxEntity.Email) error {
+	err := s.userRepository.CreatePasswordReset(ctx, userID, token, resetExp, email)
 	return err
 }
+
+// ResetPassword sets the new password and signs the user out everywhere.
+func (s *UserService) ResetPassword(ctx context.Context, token string, password *usersEntity.Password) error {
+	sessionIDs, err := s.userRepository.ResetPassword(ctx, token, password)
+	if err != nil {
+		return err
+	}
+
+	// the sessions are revoked, a failure leaves them usable until they expire
+	// from the cache
+	if err := s.sessionCache.Revoke(ctx, sessionIDs...); err != nil {
+		logging.FromContext(ctx, s.Logger).Warnw("failed to revoke the sessions in the cache", "session_ids", sessionIDs, "error", err)
+	}
+	return nil
+}
+
+// evict drops the cached copy of a user that was written and keeps the loads
+// that read it before the write from caching it again, a failure leaves it
+// stale until it expires.
+func (s *UserService) evict(ctx context.Context, userID int64) {
+	if err := s.userCache.Delete(ctx, userID); err != nil {
+		logging.FromContext(ctx, s.Logger).Warnw("failed to evict the user from the cache", "user_id", userID, "error", err)
+	}
+}
@@ -5,7 +5,12 @@ import (
 	"time"
 
 	commentsEntity "github.com/orangeMangoDimz/go-social/internal/entities/comments"
+	outboxEntity "github.com/orangeMangoDimz/go-social/internal/entities/outbox"
 	postsEntity "github.com/orangeMangoDimz/go-social/internal/entities/posts"
+	reactionsEntity "github.com/orangeMangoDimz/go-social/internal/entities/reactions"
+	searchEntity "github.com/orangeMangoDimz/go-social/internal/entities/search"
+	sessionsEntity "github.com/orangeMangoDimz/go-social/internal/entities/sessions"
+	tagsEntity "github.com/orangeMangoDimz/go-social/internal/entities/tags"
 	usersEntity "github.com/orangeMangoDimz/go-social/internal/entities/users"
 	"github.com/orangeMangoDimz/go-social/internal/storage/postgres/pagination"
 )
@@ -14,31 +19,75 @@ type UsersService interface {
 	GetById(context.Context, int64) (*usersEntity.User, error)
 	GetByEmail(context.Context, string) (*usersEntity.User, error)
 	FollowUser(context.Context, int64, int64) error
-	CreateAndInvite(context.Context, *usersEntity.User, string, time.Duration) error
+	CreateAndInvite(context.Context, *usersEntity.User, string, time.Duration, *outboxEntity.Email) error
+	GetPendingByEmail(context.Context, string) (*usersEntity.User, error)
+	ReissueInvitation(context.Context, int64, string, time.Duration, time.Duration, *outboxEntity.Email) error
 	Activate(context.Context, string) error
 	Delete(context.Context, int64) error
+	CreatePasswordReset(context.Context, int64, string, time.Duration, *outboxEntity.Email) error
+	ResetPassword(context.Context, string, *usersEntity.Password) error
 }
 
 type FollowerService interface {
 	Follow(context.Context, int64, int64) error
 	Unfollow(context.Context, int64, int64) error
+	GetFollowers(context.Context, int64, pagination.PaginatedQuery) ([]usersEntity.Connection, error)
+	GetFollowing(context.Context, int64, pagination.PaginatedQuery) ([]usersEntity.Connection, error)
+	GetStats(context.Context, int64, int64) (*usersEntity.ProfileStats, error)
 }
 
 type PostsService interface {
 	GetUserFeed(context.Context, int64, pagination.PaginatedQuery) ([]postsEntity.Feed, error)
+	GetUserPosts(context.Context, int64, pagination.PaginatedQuery) ([]postsEntity.Feed, error)
 	Create(context.Context, *postsEntity.Post) error
 	GetById(context.Context, int64) (*postsEntity.Post, error)
 	Update(context.Context, *postsEntity.Post) error
 	Delete(context.Context, int64) error
 }
 
+type TimelineService interface {
+	Enabled() bool
+	FanOut(context.Context, *postsEntity.Post) error
+	GetFeed(context.Context, int64, pagination.PaginatedQuery) ([]postsEntity.Feed, error)
+	Rebuild(context.Context, int64) error
+	RebuildAll(context.Context) (int, error)
+}
+
 type RoleService interface {
 	GetByName(context.Context, string) (*usersEntity.Role, error)
 }
 
 type CommentService interface {
 	Create(context.Context, *commentsEntity.Comment) error
-	GetByPostID(context.Context, int64) ([]commentsEntity.Comment, error)
+	GetByID(context.Context, int64) (*commentsEntity.Comment, error)
+	GetByPostID(context.Context, int64, pagination.PaginatedQuery) ([]commentsEntity.Comment, error)
+	GetReplies(context.Context, int64, pagination.PaginatedQuery) ([]commentsEntity.Comment, error)
+	Update(context.Context, *commentsEntity.Comment) error
+	Delete(context.Context, int64) error
+}
+
+type ReactionService interface {
+	React(context.Context, *reactionsEntity.Reaction) error
+	Unreact(context.Context, int64, int64, string) error
+	GetByPostID(context.Context, int64, string, pagination.PaginatedQuery) ([]reactionsEntity.Reaction, error)
+	GetSummary(context.Context, int64, int64) (*reactionsEntity.Summary, error)
+	GetSummaries(context.Context, []int64, int64) (map[int64]*reactionsEntity.Summary, error)
+}
+
+type TagService interface {
+	GetPosts(context.Context, string, pagination.PaginatedQuery) ([]postsEntity.Feed, error)
+	GetTrending(context.Context, int) ([]tagsEntity.TrendingTag, error)
+}
+
+type SearchService interface {
+	Search(context.Context, searchEntity.Query) ([]searchEntity.Result, error)
+}
+
+type SessionService interface {
+	Create(context.Context, int64) (*sessionsEntity.Session, string, error)
+	Refresh(context.Context, string) (*sessionsEntity.Session, string, error)
+	IsActive(context.Context, string) (bool, error)
+	Revoke(context.Context, string) error
 }
 
 type Service struct {
@@ -47,4 +96,9 @@ type Service struct {
 	PostService     PostsService
 	RoleService     RoleService
 	CommentService  CommentService
+	SessionService  SessionService
+	ReactionService ReactionService
+	SearchService   SearchService
+	TagService      TagService
+	TimelineService TimelineService
 }
@@ -0,0 +1,71 @@
+package cache
+
+import (
+	"context"
+
+	postsEntity "github.com/orangeMangoDimz/go-social/internal/entities/posts"
+	usersEntity "github.com/orangeMangoDimz/go-social/internal/entities/users"
+	"github.com/orangeMangoDimz/go-social/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// NewInstrumentedStorage wraps the users, posts, roles and sessions caches of storage so
+// their lookups are counted in requests, labelled by cache and result.
+func NewInstrumentedStorage(storage Storage, requests *prometheus.CounterVec) Storage {
+	storage.Users = &instrumentedUsers{UsersCache: storage.Users, requests: requests}
+	storage.Posts = &instrumentedPosts{PostsCache: storage.Posts, requests: requests}
+	storage.Roles = &instrumentedRoles{RolesCache: storage.Roles, requests: requests}
+	storage.Sessions = &instrumentedSessions{SessionsCache: storage.Sessions, requests: requests}
+	return storage
+}
+
+type instrumentedUsers struct {
+	UsersCache
+	requests *prometheus.CounterVec
+}
+
+func (c *instrumentedUsers) Get(ctx context.Context, userID int64) (*usersEntity.User, error) {
+	user, err := c.UsersCache.Get(ctx, userID)
+	c.requests.WithLabelValues("users", lookupResult(user != nil)).Inc()
+	return user, err
+}
+
+type instrumentedPosts struct {
+	PostsCache
+	requests *prometheus.CounterVec
+}
+
+func (c *instrumentedPosts) Get(ctx context.Context, postID int64) (*postsEntity.Post, error) {
+	post, err := c.PostsCache.Get(ctx, postID)
+	c.requests.WithLabelValues("posts", lookupResult(post != nil)).Inc()
+	return post, err
+}
+
+type instrumentedRoles struct {
+	RolesCache
+	requests *prometheus.CounterVec
+}
+
+func (c *instrumentedRoles) Get(ctx context.Context, name string) (*usersEntity.Role, error) {
+	role, err := c.RolesCache.Get(ctx, name)
+	c.requests.WithLabelValues("roles", lookupResult(role != nil)).Inc()
+	return role, err
+}
+
+type instrumentedSessions struct {
+	SessionsCache
+	requests *prometheus.CounterVec
+}
+
+func (c *instrumentedSessions) Get(ctx context.Context, sessionID string) (*bool, error) {
+	active, err := c.SessionsCache.Get(ctx, sessionID)
+	c.requests.WithLabelValues("sessions", lookupResult(active != nil)).Inc()
+	return active, err
+}
+
+func lookupResult(hit bool) string {
+	if hit {
+		return metrics.CacheHit
+	}
+	return metrics.CacheMiss
+}
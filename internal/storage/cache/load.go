@@ -0,0 +1,56 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/orangeMangoDimz/go-social/internal/logging"
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+)
+
+// GetOrLoad returns the value cached under key, or loads it and caches it.
+// Concurrent misses of the same key wait for a single load instead of each
+// hitting the database. A cache failure is logged and only costs the load.
+func GetOrLoad[T any](
+	ctx context.Context,
+	group *singleflight.Group,
+	key string,
+	logger *zap.SugaredLogger,
+	get func(context.Context) (*T, error),
+	load func(context.Context) (*T, error),
+	set func(context.Context, *T) error,
+) (*T, error) {
+	logger = logging.FromContext(ctx, logger)
+
+	value, err := get(ctx)
+	if err != nil {
+		logger.Warnw("failed to read from the cache", "key", key, "error", err)
+	}
+	if value != nil {
+		return value, nil
+	}
+
+	shared, err, _ := group.Do(key, func() (any, error) {
+		// the load is shared, it must not fail because the first caller
+		// went away
+		ctx := context.WithoutCancel(ctx)
+
+		value, err := load(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := set(ctx, value); err != nil {
+			logger.Warnw("failed to write to the cache", "key", key, "error", err)
+		}
+		return value, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// every caller gets its own copy, handlers modify what they load
+	value = new(T)
+	*value = *shared.(*T)
+	return value, nil
+}
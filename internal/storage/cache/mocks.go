@@ -3,21 +3,100 @@ package cache
 import (
 	"context"
 
+	postsEntity "github.com/orangeMangoDimz/go-social/internal/entities/posts"
+	tagsEntity "github.com/orangeMangoDimz/go-social/internal/entities/tags"
 	usersEntity "github.com/orangeMangoDimz/go-social/internal/entities/users"
 )
 
 func NewMockStore() Storage {
 	return Storage{
-		Users: &MockUserStore{},
+		Users:     &MockUserStore{},
+		Posts:     &MockPostStore{},
+		Roles:     &MockRoleStore{},
+		Sessions:  &MockSessionStore{},
+		Tags:      &MockTagStore{},
+		Timelines: &MockTimelineStore{},
 	}
 }
 
+// MockUserStore always misses
 type MockUserStore struct{}
 
 func (m *MockUserStore) Get(ctx context.Context, userID int64) (*usersEntity.User, error) {
-	return &usersEntity.User{}, nil
+	return nil, nil
 }
 
 func (m *MockUserStore) Set(ctx context.Context, user *usersEntity.User) error {
 	return nil
 }
+
+func (m *MockUserStore) Delete(ctx context.Context, userID int64) error {
+	return nil
+}
+
+// MockPostStore always misses
+type MockPostStore struct{}
+
+func (m *MockPostStore) Get(ctx context.Context, postID int64) (*postsEntity.Post, error) {
+	return nil, nil
+}
+
+func (m *MockPostStore) Set(ctx context.Context, post *postsEntity.Post) error {
+	return nil
+}
+
+func (m *MockPostStore) Delete(ctx context.Context, postID int64) error {
+	return nil
+}
+
+// MockRoleStore always misses
+type MockRoleStore struct{}
+
+func (m *MockRoleStore) Get(ctx context.Context, name string) (*usersEntity.Role, error) {
+	return nil, nil
+}
+
+func (m *MockRoleStore) Set(ctx context.Context, role *usersEntity.Role) error {
+	return nil
+}
+
+// MockSessionStore always misses
+type MockSessionStore struct{}
+
+func (m *MockSessionStore) Get(ctx context.Context, sessionID string) (*bool, error) {
+	return nil, nil
+}
+
+func (m *MockSessionStore) Set(ctx context.Context, sessionID string, active bool) error {
+	return nil
+}
+
+func (m *MockSessionStore) Revoke(ctx context.Context, sessionIDs ...string) error {
+	return nil
+}
+
+// MockTagStore always misses
+type MockTagStore struct{}
+
+func (m *MockTagStore) GetTrending(ctx context.Context) ([]tagsEntity.TrendingTag, error) {
+	return nil, nil
+}
+
+func (m *MockTagStore) SetTrending(ctx context.Context, trending []tagsEntity.TrendingTag) error {
+	return nil
+}
+
+// MockTimelineStore always misses
+type MockTimelineStore struct{}
+
+func (m *MockTimelineStore) Get(ctx context.Context, userID int64) ([]int64, error) {
+	return nil, nil
+}
+
+func (m *MockTimelineStore) Set(ctx context.Context, userID int64, postIDs []int64) error {
+	return nil
+}
+
+func (m *MockTimelineStore) Push(ctx context.Context, postID int64, userIDs []int64, maxEntries int) error {
+	return nil
+}
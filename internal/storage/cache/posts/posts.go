@@ -0,0 +1,71 @@
+package postsCache
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	postsEntity "github.com/orangeMangoDimz/go-social/internal/entities/posts"
+	"github.com/orangeMangoDimz/go-social/internal/storage/cache/tombstone"
+	"github.com/redis/go-redis/v9"
+)
+
+type PostStore struct {
+	Rdb *redis.Client
+	// Exp is how long a post stays cached, PostExpTime when zero
+	Exp time.Duration
+}
+
+const PostExpTime = time.Minute
+
+func postKey(postID int64) string {
+	return fmt.Sprintf("post-%v", postID)
+}
+
+func (s *PostStore) Get(ctx context.Context, postID int64) (*postsEntity.Post, error) {
+	// Return nil if Redis client is not available
+	if s.Rdb == nil {
+		return nil, nil
+	}
+
+	data, err := tombstone.Get(ctx, s.Rdb, postKey(postID))
+	if err == redis.Nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var post postsEntity.Post
+	if err := json.Unmarshal([]byte(data), &post); err != nil {
+		return nil, err
+	}
+
+	return &post, nil
+}
+
+func (s *PostStore) Set(ctx context.Context, post *postsEntity.Post) error {
+	// Return nil if Redis client is not available (no-op)
+	if s.Rdb == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(post)
+	if err != nil {
+		return err
+	}
+
+	return tombstone.Set(ctx, s.Rdb, postKey(post.ID), data, cmp.Or(s.Exp, PostExpTime))
+}
+
+// Delete evicts the post, the next read loads it again and a load that read
+// it before it was written does not cache it.
+func (s *PostStore) Delete(ctx context.Context, postID int64) error {
+	// Return nil if Redis client is not available (no-op)
+	if s.Rdb == nil {
+		return nil
+	}
+
+	return tombstone.Evict(ctx, s.Rdb, postKey(postID))
+}
@@ -0,0 +1,61 @@
+package rolesCache
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	usersEntity "github.com/orangeMangoDimz/go-social/internal/entities/users"
+	"github.com/redis/go-redis/v9"
+)
+
+// RoleStore caches the roles by name. They only change through migrations, so
+// they are never invalidated and simply expire.
+type RoleStore struct {
+	Rdb *redis.Client
+	// Exp is how long a role stays cached, RoleExpTime when zero
+	Exp time.Duration
+}
+
+const RoleExpTime = 10 * time.Minute
+
+func roleKey(name string) string {
+	return fmt.Sprintf("role-%v", name)
+}
+
+func (s *RoleStore) Get(ctx context.Context, name string) (*usersEntity.Role, error) {
+	// Return nil if Redis client is not available
+	if s.Rdb == nil {
+		return nil, nil
+	}
+
+	data, err := s.Rdb.Get(ctx, roleKey(name)).Result()
+	if err == redis.Nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var role usersEntity.Role
+	if err := json.Unmarshal([]byte(data), &role); err != nil {
+		return nil, err
+	}
+
+	return &role, nil
+}
+
+func (s *RoleStore) Set(ctx context.Context, role *usersEntity.Role) error {
+	// Return nil if Redis client is not available (no-op)
+	if s.Rdb == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(role)
+	if err != nil {
+		return err
+	}
+
+	return s.Rdb.SetEx(ctx, roleKey(role.Name), data, cmp.Or(s.Exp, RoleExpTime)).Err()
+}
@@ -0,0 +1,80 @@
+package sessionsCache
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// SessionStore caches whether the sessions are active. A revoked session is
+// never active again, so a revocation overwrites the cached state while a
+// state loaded from Postgres is only cached when there is none. A load racing
+// a revocation cannot cache the session as active once it is revoked.
+type SessionStore struct {
+	Rdb *redis.Client
+	// Exp is how long a session state stays cached, SessionExpTime when zero
+	Exp time.Duration
+}
+
+const SessionExpTime = time.Minute
+
+const (
+	active  = "1"
+	revoked = "0"
+)
+
+func sessionKey(sessionID string) string {
+	return fmt.Sprintf("session-%v", sessionID)
+}
+
+// Get reports whether the session is active, or returns nil when its state is
+// not cached.
+func (s *SessionStore) Get(ctx context.Context, sessionID string) (*bool, error) {
+	// Return nil if Redis client is not available
+	if s.Rdb == nil {
+		return nil, nil
+	}
+
+	data, err := s.Rdb.Get(ctx, sessionKey(sessionID)).Result()
+	if err == redis.Nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	isActive := data == active
+	return &isActive, nil
+}
+
+// Set caches the state of the session unless one is cached already.
+func (s *SessionStore) Set(ctx context.Context, sessionID string, isActive bool) error {
+	// Return nil if Redis client is not available (no-op)
+	if s.Rdb == nil {
+		return nil
+	}
+
+	state := revoked
+	if isActive {
+		state = active
+	}
+	return s.Rdb.SetNX(ctx, sessionKey(sessionID), state, cmp.Or(s.Exp, SessionExpTime)).Err()
+}
+
+// Revoke caches the sessions as revoked, replacing their cached state.
+func (s *SessionStore) Revoke(ctx context.Context, sessionIDs ...string) error {
+	// Return nil if Redis client is not available (no-op)
+	if s.Rdb == nil || len(sessionIDs) == 0 {
+		return nil
+	}
+
+	pipe := s.Rdb.Pipeline()
+	for _, sessionID := range sessionIDs {
+		pipe.SetEx(ctx, sessionKey(sessionID), revoked, cmp.Or(s.Exp, SessionExpTime))
+	}
+
+	_, err := pipe.Exec(ctx)
+	return err
+}
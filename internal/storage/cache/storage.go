@@ -3,20 +3,79 @@ package cache
 import (
 	"context"
 
+	"github.com/orangeMangoDimz/go-social/internal/config"
+	postsEntity "github.com/orangeMangoDimz/go-social/internal/entities/posts"
+	tagsEntity "github.com/orangeMangoDimz/go-social/internal/entities/tags"
 	usersEntity "github.com/orangeMangoDimz/go-social/internal/entities/users"
+	postsCache "github.com/orangeMangoDimz/go-social/internal/storage/cache/posts"
+	rolesCache "github.com/orangeMangoDimz/go-social/internal/storage/cache/roles"
+	sessionsCache "github.com/orangeMangoDimz/go-social/internal/storage/cache/sessions"
+	tagsCache "github.com/orangeMangoDimz/go-social/internal/storage/cache/tags"
+	timelinesCache "github.com/orangeMangoDimz/go-social/internal/storage/cache/timelines"
 	usersCache "github.com/orangeMangoDimz/go-social/internal/storage/cache/users"
 	"github.com/redis/go-redis/v9"
 )
 
 type Storage struct {
-	Users interface {
-		Get(context.Context, int64) (*usersEntity.User, error)
-		Set(context.Context, *usersEntity.User) error
-	}
+	Users     UsersCache
+	Posts     PostsCache
+	Roles     RolesCache
+	Sessions  SessionsCache
+	Tags      TagsCache
+	Timelines TimelinesCache
+}
+
+// UsersCache keeps the users by ID. A miss returns nil without error.
+type UsersCache interface {
+	Get(context.Context, int64) (*usersEntity.User, error)
+	Set(context.Context, *usersEntity.User) error
+	Delete(context.Context, int64) error
+}
+
+// PostsCache keeps the posts by ID. A miss returns nil without error.
+type PostsCache interface {
+	Get(context.Context, int64) (*postsEntity.Post, error)
+	Set(context.Context, *postsEntity.Post) error
+	Delete(context.Context, int64) error
+}
+
+// RolesCache keeps the roles by name. A miss returns nil without error.
+type RolesCache interface {
+	Get(context.Context, string) (*usersEntity.Role, error)
+	Set(context.Context, *usersEntity.Role) error
+}
+
+// SessionsCache keeps whether the sessions are active by ID. A miss returns
+// nil without error.
+type SessionsCache interface {
+	Get(context.Context, string) (*bool, error)
+	Set(ctx context.Context, sessionID string, active bool) error
+	Revoke(ctx context.Context, sessionIDs ...string) error
+}
+
+// TagsCache keeps the trending tags. A miss returns nil without error.
+type TagsCache interface {
+	GetTrending(context.Context) ([]tagsEntity.TrendingTag, error)
+	SetTrending(context.Context, []tagsEntity.TrendingTag) error
+}
+
+// TimelinesCache keeps the post IDs of the home timelines. A miss returns nil
+// without error.
+type TimelinesCache interface {
+	Get(context.Context, int64) ([]int64, error)
+	Set(context.Context, int64, []int64) error
+	Push(ctx context.Context, postID int64, userIDs []int64, maxEntries int) error
 }
 
-func NewRedisStorage(rdb *redis.Client) Storage {
+// NewRedisStorage caches in rdb with the TTLs of cfg, the defaults of each
+// store apply to the ones left at zero.
+func NewRedisStorage(rdb *redis.Client, cfg config.CacheConfig) Storage {
 	return Storage{
-		Users: &usersCache.UserStore{Rdb: rdb},
+		Users:     &usersCache.UserStore{Rdb: rdb, Exp: cfg.UserTTL},
+		Posts:     &postsCache.PostStore{Rdb: rdb, Exp: cfg.PostTTL},
+		Roles:     &rolesCache.RoleStore{Rdb: rdb, Exp: cfg.RoleTTL},
+		Sessions:  &sessionsCache.SessionStore{Rdb: rdb, Exp: cfg.SessionTTL},
+		Tags:      &tagsCache.TagStore{Rdb: rdb},
+		Timelines: &timelinesCache.TimelineStore{Rdb: rdb},
 	}
 }
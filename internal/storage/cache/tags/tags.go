@@ -0,0 +1,55 @@
+package tagsCache
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	tagsEntity "github.com/orangeMangoDimz/go-social/internal/entities/tags"
+	"github.com/redis/go-redis/v9"
+)
+
+type TagStore struct {
+	Rdb *redis.Client
+}
+
+// TrendingExpTime is how long the trending tags are served before being
+// computed again
+const TrendingExpTime = 5 * time.Minute
+
+const trendingKey = "tags-trending"
+
+func (s *TagStore) GetTrending(ctx context.Context) ([]tagsEntity.TrendingTag, error) {
+	// Return nil if Redis client is not available
+	if s.Rdb == nil {
+		return nil, nil
+	}
+
+	data, err := s.Rdb.Get(ctx, trendingKey).Result()
+	if err == redis.Nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	trending := []tagsEntity.TrendingTag{}
+	if err := json.Unmarshal([]byte(data), &trending); err != nil {
+		return nil, err
+	}
+
+	return trending, nil
+}
+
+func (s *TagStore) SetTrending(ctx context.Context, trending []tagsEntity.TrendingTag) error {
+	// Return nil if Redis client is not available (no-op)
+	if s.Rdb == nil {
+		return nil
+	}
+
+	json, err := json.Marshal(trending)
+	if err != nil {
+		return err
+	}
+
+	return s.Rdb.SetEx(ctx, trendingKey, json, TrendingExpTime).Err()
+}
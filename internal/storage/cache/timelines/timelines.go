@@ -0,0 +1,113 @@
+package timelinesCache
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// TimelineExpTime is how long the timeline of an idle user is kept, it is
+// rebuilt from Postgres on the next read
+const TimelineExpTime = 7 * 24 * time.Hour
+
+// pushScript adds a post to a timeline and trims it to its cap, but only when
+// the timeline exists. A partial timeline would hide the older posts of the
+// other followed users until it is rebuilt.
+var pushScript = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 0 then
+	return 0
+end
+redis.call("ZADD", KEYS[1], ARGV[1], ARGV[1])
+redis.call("ZREMRANGEBYRANK", KEYS[1], 0, -tonumber(ARGV[2]) - 1)
+return 1
+`)
+
+// emptyMember is kept in every timeline so an empty one still exists and is
+// told apart from a missing one. Post IDs start at 1, so it ranks last and is
+// the first member trimmed once the timeline is full.
+const emptyMember = "0"
+
+// TimelineStore keeps the IDs of the posts of each home timeline in a sorted
+// set scored by post ID, which grows with the creation time.
+type TimelineStore struct {
+	Rdb *redis.Client
+}
+
+func timelineKey(userID int64) string {
+	return fmt.Sprintf("timeline-%v", userID)
+}
+
+// Get returns the post IDs of the timeline of userID, newest first, or nil
+// when it is not cached. A timeline cached empty is an empty slice.
+func (s *TimelineStore) Get(ctx context.Context, userID int64) ([]int64, error) {
+	// Return nil if Redis client is not available
+	if s.Rdb == nil {
+		return nil, nil
+	}
+
+	members, err := s.Rdb.ZRevRange(ctx, timelineKey(userID), 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	// a sorted set without members does not exist, it is a miss
+	if len(members) == 0 {
+		return nil, nil
+	}
+
+	postIDs := make([]int64, 0, len(members))
+	for _, member := range members {
+		if member == emptyMember {
+			continue
+		}
+		postID, err := strconv.ParseInt(member, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		postIDs = append(postIDs, postID)
+	}
+	return postIDs, nil
+}
+
+// Set replaces the timeline of userID.
+func (s *TimelineStore) Set(ctx context.Context, userID int64, postIDs []int64) error {
+	// Return nil if Redis client is not available (no-op)
+	if s.Rdb == nil {
+		return nil
+	}
+
+	key := timelineKey(userID)
+	members := make([]redis.Z, 0, len(postIDs)+1)
+	members = append(members, redis.Z{Score: 0, Member: emptyMember})
+	for _, postID := range postIDs {
+		members = append(members, redis.Z{Score: float64(postID), Member: postID})
+	}
+
+	_, err := s.Rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		pipe.ZAdd(ctx, key, members...)
+		pipe.Expire(ctx, key, TimelineExpTime)
+		return nil
+	})
+	return err
+}
+
+// Push adds postID to the cached timelines of userIDs, keeping the newest
+// maxEntries posts of each.
+func (s *TimelineStore) Push(ctx context.Context, postID int64, userIDs []int64, maxEntries int) error {
+	// Return nil if Redis client is not available (no-op)
+	if s.Rdb == nil || len(userIDs) == 0 {
+		return nil
+	}
+
+	pipe := s.Rdb.Pipeline()
+	for _, userID := range userIDs {
+		pushScript.Eval(ctx, pipe, []string{timelineKey(userID)}, postID, maxEntries)
+	}
+
+	_, err := pipe.Exec(ctx)
+	return err
+}
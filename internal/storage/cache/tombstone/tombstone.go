@@ -0,0 +1,55 @@
+// Package tombstone evicts written entries from the cache without letting a
+// load that read them before the write cache them again.
+//
+// An evicted key holds a tombstone for longer than any load can take, so a
+// load racing the write either caches its copy before the tombstone replaces
+// it or finds the tombstone and caches nothing. Reads treat the tombstone as
+// a miss.
+package tombstone
+
+import (
+	"context"
+	"time"
+
+	"github.com/orangeMangoDimz/go-social/internal/storage"
+	"github.com/redis/go-redis/v9"
+)
+
+// TTL outlasts the loads started before an eviction, which are bounded by the
+// query timeout.
+var TTL = 2 * storage.QueryTimeoutDuration
+
+const marker = "\x00evicted"
+
+// setScript caches ARGV[1] under KEYS[1] for ARGV[3] milliseconds, unless
+// KEYS[1] holds the tombstone ARGV[2].
+var setScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[2] then
+	return 0
+end
+redis.call("SET", KEYS[1], ARGV[1], "PX", ARGV[3])
+return 1
+`)
+
+// Get returns the value cached under key, or redis.Nil when there is none or
+// it was evicted.
+func Get(ctx context.Context, rdb *redis.Client, key string) (string, error) {
+	data, err := rdb.Get(ctx, key).Result()
+	if err != nil {
+		return "", err
+	}
+	if data == marker {
+		return "", redis.Nil
+	}
+	return data, nil
+}
+
+// Set caches data under key for exp, unless key was evicted less than TTL ago.
+func Set(ctx context.Context, rdb *redis.Client, key string, data []byte, exp time.Duration) error {
+	return setScript.Run(ctx, rdb, []string{key}, data, marker, exp.Milliseconds()).Err()
+}
+
+// Evict replaces the value cached under key with a tombstone.
+func Evict(ctx context.Context, rdb *redis.Client, key string) error {
+	return rdb.Set(ctx, key, marker, TTL).Err()
+}
@@ -1,30 +1,36 @@
 package usersCache
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
 	"time"
 
 	usersEntity "github.com/orangeMangoDimz/go-social/internal/entities/users"
+	"github.com/orangeMangoDimz/go-social/internal/storage/cache/tombstone"
 	"github.com/redis/go-redis/v9"
 )
 
 type UserStore struct {
 	Rdb *redis.Client
+	// Exp is how long a user stays cached, UserExpTime when zero
+	Exp time.Duration
 }
 
 const UserExpTime = time.Minute
 
+func userKey(userID int64) string {
+	return fmt.Sprintf("user-%v", userID)
+}
+
 func (s *UserStore) Get(ctx context.Context, userID int64) (*usersEntity.User, error) {
 	// Return nil if Redis client is not available
 	if s.Rdb == nil {
 		return nil, nil
 	}
 
-	cacheKey := fmt.Sprintf("user-%v", userID)
-
-	data, err := s.Rdb.Get(ctx, cacheKey).Result()
+	data, err := tombstone.Get(ctx, s.Rdb, userKey(userID))
 	if err == redis.Nil {
 		return nil, nil
 	} else if err != nil {
@@ -48,12 +54,21 @@ func (s *UserStore) Set(ctx context.Context, user *usersEntity.User) error {
 		return nil
 	}
 
-	cacheKey := fmt.Sprintf("user-%v", user.ID)
-
 	json, err := json.Marshal(user)
 	if err != nil {
 		return err
 	}
 
-	return s.Rdb.SetEx(ctx, cacheKey, json, UserExpTime).Err()
+	return tombstone.Set(ctx, s.Rdb, userKey(user.ID), json, cmp.Or(s.Exp, UserExpTime))
+}
+
+// Delete evicts the user, the next read loads it again and a load that read
+// it before it was written does not cache it.
+func (s *UserStore) Delete(ctx context.Context, userID int64) error {
+	// Return nil if Redis client is not available (no-op)
+	if s.Rdb == nil {
+		return nil
+	}
+
+	return tombstone.Evict(ctx, s.Rdb, userKey(userID))
 }
@@ -3,10 +3,13 @@ package comments
 import (
 	"context"
 	"database/sql"
+	"errors"
 
+	"github.com/lib/pq"
 	commentsEntity "github.com/orangeMangoDimz/go-social/internal/entities/comments"
 	usersEntity "github.com/orangeMangoDimz/go-social/internal/entities/users"
 	"github.com/orangeMangoDimz/go-social/internal/storage"
+	"github.com/orangeMangoDimz/go-social/internal/storage/postgres/pagination"
 )
 
 type CommentStore struct {
@@ -15,8 +18,8 @@ type CommentStore struct {
 
 func (s *CommentStore) Create(ctx context.Context, comment *commentsEntity.Comment) error {
 	query := `
-		INSERT INTO comments (post_id, user_id, content) 
-		VALUES ($1, $2, $3) RETURNING id, created_at
+		INSERT INTO comments (post_id, parent_id, depth, user_id, content) 
+		VALUES ($1, $2, $3, $4, $5) RETURNING id, created_at, updated_at
 	`
 
 	ctx, cancel := context.WithTimeout(ctx, storage.QueryTimeoutDuration)
@@ -26,26 +29,87 @@ func (s *CommentStore) Create(ctx context.Context, comment *commentsEntity.Comme
 		ctx,
 		query,
 		comment.PostID,
+		comment.ParentID,
+		comment.Depth,
 		comment.UserID,
 		comment.Content,
 	).Scan(
 		&comment.ID,
 		&comment.CreatedAt,
+		&comment.UpdatedAt,
 	)
 
 	if err != nil {
-		return err
+		var pqErr *pq.Error
+		switch {
+		// the post or the parent comment was deleted in the meantime
+		case errors.As(err, &pqErr) && pqErr.Code == "23503":
+			return storage.ErrNotFound
+		default:
+			return err
+		}
 	}
 	return nil
 }
 
-func (s *CommentStore) GetByPostID(ctx context.Context, postID int64) ([]commentsEntity.Comment, error) {
+func (s *CommentStore) GetByID(ctx context.Context, commentID int64) (*commentsEntity.Comment, error) {
+	query := `
+		SELECT
+			c.id, c.post_id, c.parent_id, c.depth, c.user_id, c.content, c.created_at, c.updated_at,
+			(SELECT COUNT(*) FROM comments r WHERE r.parent_id = c.id),
+			u.username, u.id
+		FROM comments c
+		JOIN users u ON u.id = c.user_id
+		WHERE c.id = $1
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, storage.QueryTimeoutDuration)
+	defer cancel()
+
+	var c commentsEntity.Comment
+	err := scanComment(s.Db.QueryRowContext(ctx, query, commentID), &c)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, storage.ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+	return &c, nil
+}
+
+// GetByPostID lists the top-level comments of postID. Replies are fetched
+// per comment with GetReplies.
+func (s *CommentStore) GetByPostID(ctx context.Context, postID int64, fq pagination.PaginatedQuery) ([]commentsEntity.Comment, error) {
+	return s.list(ctx, "c.post_id = $1 AND c.parent_id IS NULL", postID, fq)
+}
+
+// GetReplies lists the direct replies to commentID.
+func (s *CommentStore) GetReplies(ctx context.Context, commentID int64, fq pagination.PaginatedQuery) ([]commentsEntity.Comment, error) {
+	return s.list(ctx, "c.parent_id = $1", commentID, fq)
+}
+
+func (s *CommentStore) list(ctx context.Context, filter string, id int64, fq pagination.PaginatedQuery) ([]commentsEntity.Comment, error) {
+	keysetOp, cursorCreatedAt, cursorID, err := fq.Keyset()
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
-		SELECT c.id, c.post_id, c.user_id, c.content, c.created_at, u.username, u.id
+		SELECT
+			c.id, c.post_id, c.parent_id, c.depth, c.user_id, c.content, c.created_at, c.updated_at,
+			(SELECT COUNT(*) FROM comments r WHERE r.parent_id = c.id),
+			u.username, u.id
 		FROM comments c
 		JOIN users u ON u.id = c.user_id
-		WHERE c.post_id = $1
-		ORDER BY c.created_at DESC
+		WHERE
+			` + filter + ` AND
+			($4::timestamptz IS NULL OR (c.created_at, c.id) ` + keysetOp + ` ($4::timestamptz, $5))
+		ORDER BY c.created_at ` + fq.Sort + `, c.id ` + fq.Sort + `
+		LIMIT $2
+		OFFSET $3
 	`
 
 	ctx, cancel := context.WithTimeout(ctx, storage.QueryTimeoutDuration)
@@ -54,7 +118,11 @@ func (s *CommentStore) GetByPostID(ctx context.Context, postID int64) ([]comment
 	rows, err := s.Db.QueryContext(
 		ctx,
 		query,
-		postID,
+		id,
+		fq.Limit,
+		fq.Offset,
+		cursorCreatedAt,
+		cursorID,
 	)
 
 	if err != nil {
@@ -67,12 +135,87 @@ func (s *CommentStore) GetByPostID(ctx context.Context, postID int64) ([]comment
 
 	for rows.Next() {
 		var c commentsEntity.Comment
-		c.User = usersEntity.User{}
-		err := rows.Scan(&c.ID, &c.PostID, &c.UserID, &c.Content, &c.CreatedAt, &c.User.Username, &c.User.ID)
-		if err != nil {
+		if err := scanComment(rows, &c); err != nil {
 			return nil, err
 		}
 		comments = append(comments, c)
 	}
-	return comments, nil
+	return comments, rows.Err()
+}
+
+func scanComment(row interface{ Scan(...any) error }, c *commentsEntity.Comment) error {
+	c.User = usersEntity.User{}
+	return row.Scan(
+		&c.ID,
+		&c.PostID,
+		&c.ParentID,
+		&c.Depth,
+		&c.UserID,
+		&c.Content,
+		&c.CreatedAt,
+		&c.UpdatedAt,
+		&c.ReplyCount,
+		&c.User.Username,
+		&c.User.ID,
+	)
+}
+
+func (s *CommentStore) Update(ctx context.Context, comment *commentsEntity.Comment) error {
+	query := `
+		UPDATE comments
+		SET content = $1, updated_at = NOW()
+		WHERE id = $2
+		RETURNING updated_at
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, storage.QueryTimeoutDuration)
+	defer cancel()
+
+	err := s.Db.QueryRowContext(
+		ctx,
+		query,
+		comment.Content,
+		comment.ID,
+	).Scan(&comment.UpdatedAt)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return storage.ErrNotFound
+		default:
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *CommentStore) Delete(ctx context.Context, commentID int64) error {
+	query := `
+		DELETE FROM comments
+		WHERE comments.id = $1
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, storage.QueryTimeoutDuration)
+	defer cancel()
+
+	res, err := s.Db.ExecContext(
+		ctx,
+		query,
+		commentID,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
 }
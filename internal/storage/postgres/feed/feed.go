@@ -0,0 +1,103 @@
+// Package feed lists posts the way every feed shows them: with their author
+// and comment count, filtered and paginated by the feed query.
+package feed
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+	postsEntity "github.com/orangeMangoDimz/go-social/internal/entities/posts"
+	"github.com/orangeMangoDimz/go-social/internal/storage"
+	"github.com/orangeMangoDimz/go-social/internal/storage/postgres/pagination"
+)
+
+// Query lists the posts p matching cond with the search, tags, time range and
+// cursor of fq applied, in the order of fq.Sort. cond uses the placeholders
+// $1 to $len(args), bound to args.
+func Query(ctx context.Context, db *sql.DB, cond string, args []any, fq pagination.PaginatedQuery) ([]postsEntity.Feed, error) {
+	// keyset condition, rows strictly after the cursor in the requested order
+	keysetOp, cursorCreatedAt, cursorID, err := fq.Keyset()
+	if err != nil {
+		return nil, err
+	}
+
+	// no tags filter is an empty array, which a NULL would not equal
+	tags := fq.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	n := len(args)
+	query := fmt.Sprintf(`
+		SELECT
+			p.id, p.user_id, p.title, p.content, p.created_at, p.tags,
+			u.username,
+			COUNT(c.id) AS comments_count
+		FROM posts p
+		JOIN users u ON u.id = p.user_id
+		LEFT JOIN comments c ON c.post_id = p.id
+		WHERE
+			(%s) AND
+			(p.title ILIKE '%%' || $%d || '%%' OR p.content ILIKE '%%' || $%d || '%%') AND
+			(p.tags @> $%d OR $%d = '{}') AND
+			(p.created_at >= $%d AND p.created_at <= $%d) AND
+			($%d::timestamptz IS NULL OR (p.created_at, p.id) %s ($%d::timestamptz, $%d))
+		GROUP BY p.id, u.username
+		ORDER BY p.created_at %s, p.id %s
+		LIMIT $%d
+		OFFSET $%d
+	`,
+		cond,
+		n+1, n+1,
+		n+2, n+2,
+		n+3, n+4,
+		n+5, keysetOp, n+5, n+6,
+		fq.Sort, fq.Sort,
+		n+7,
+		n+8,
+	)
+
+	args = append(args[:n:n],
+		fq.Search,
+		pq.Array(tags),
+		fq.Since,
+		fq.Until,
+		cursorCreatedAt,
+		cursorID,
+		fq.Limit,
+		fq.Offset,
+	)
+
+	ctx, cancel := context.WithTimeout(ctx, storage.QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	feeds := []postsEntity.Feed{}
+
+	for rows.Next() {
+		var f postsEntity.Feed
+		err := rows.Scan(
+			&f.ID,
+			&f.UserId,
+			&f.Title,
+			&f.Content,
+			&f.CreatedAt,
+			pq.Array(&f.Tags),
+			&f.User.Username,
+			&f.TotalComments,
+		)
+		if err != nil {
+			return nil, err
+		}
+		feeds = append(feeds, f)
+	}
+	return feeds, rows.Err()
+}
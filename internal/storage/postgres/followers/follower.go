@@ -6,7 +6,9 @@ import (
 	"errors"
 
 	"github.com/lib/pq"
+	usersEntity "github.com/orangeMangoDimz/go-social/internal/entities/users"
 	"github.com/orangeMangoDimz/go-social/internal/storage"
+	"github.com/orangeMangoDimz/go-social/internal/storage/postgres/pagination"
 )
 
 type FollowerStore struct {
@@ -60,3 +62,92 @@ func (s *FollowerStore) Unfollow(ctx context.Context, followedID, userID int64)
 
 	return err
 }
+
+// GetFollowers lists the users following userID, most recent follow first.
+func (s *FollowerStore) GetFollowers(ctx context.Context, userID int64, fq pagination.PaginatedQuery) ([]usersEntity.Connection, error) {
+	return s.getConnections(ctx, "f.follower_id", "f.user_id", userID, fq)
+}
+
+// GetFollowing lists the users followed by userID, most recent follow first.
+func (s *FollowerStore) GetFollowing(ctx context.Context, userID int64, fq pagination.PaginatedQuery) ([]usersEntity.Connection, error) {
+	return s.getConnections(ctx, "f.user_id", "f.follower_id", userID, fq)
+}
+
+// getConnections lists the users found in listColumn of the follow rows
+// whose ownerColumn is userID.
+func (s *FollowerStore) getConnections(ctx context.Context, listColumn, ownerColumn string, userID int64, fq pagination.PaginatedQuery) ([]usersEntity.Connection, error) {
+	keysetOp, cursorCreatedAt, cursorID, err := fq.Keyset()
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT u.id, u.username, f.created_at
+		FROM followers f
+		JOIN users u ON u.id = ` + listColumn + `
+		WHERE
+			` + ownerColumn + ` = $1 AND
+			($4::timestamptz IS NULL OR (f.created_at, u.id) ` + keysetOp + ` ($4::timestamptz, $5))
+		ORDER BY f.created_at ` + fq.Sort + `, u.id ` + fq.Sort + `
+		LIMIT $2
+		OFFSET $3
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, storage.QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := s.Db.QueryContext(
+		ctx,
+		query,
+		userID,
+		fq.Limit,
+		fq.Offset,
+		cursorCreatedAt,
+		cursorID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	connections := []usersEntity.Connection{}
+
+	for rows.Next() {
+		var c usersEntity.Connection
+		if err := rows.Scan(&c.ID, &c.Username, &c.FollowedAt); err != nil {
+			return nil, err
+		}
+		connections = append(connections, c)
+	}
+	return connections, nil
+}
+
+// GetStats returns the counters of userID and its relationship with viewerID.
+func (s *FollowerStore) GetStats(ctx context.Context, userID, viewerID int64) (*usersEntity.ProfileStats, error) {
+	query := `
+		SELECT
+			COALESCE((SELECT follower_count FROM users WHERE id = $1), 0) AS follower_count,
+			(SELECT COUNT(*) FROM followers WHERE follower_id = $1) AS following_count,
+			(SELECT COUNT(*) FROM posts WHERE user_id = $1) AS post_count,
+			EXISTS (SELECT 1 FROM followers WHERE user_id = $1 AND follower_id = $2) AS is_following,
+			EXISTS (SELECT 1 FROM followers WHERE user_id = $2 AND follower_id = $1) AS follows_you
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, storage.QueryTimeoutDuration)
+	defer cancel()
+
+	var stats usersEntity.ProfileStats
+	err := s.Db.QueryRowContext(ctx, query, userID, viewerID).Scan(
+		&stats.FollowerCount,
+		&stats.FollowingCount,
+		&stats.PostCount,
+		&stats.IsFollowing,
+		&stats.FollowsYou,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
@@ -3,15 +3,34 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"sync"
 	"time"
 
+	commentsEntity "github.com/orangeMangoDimz/go-social/internal/entities/comments"
+	outboxEntity "github.com/orangeMangoDimz/go-social/internal/entities/outbox"
+	postsEntity "github.com/orangeMangoDimz/go-social/internal/entities/posts"
+	reactionsEntity "github.com/orangeMangoDimz/go-social/internal/entities/reactions"
+	searchEntity "github.com/orangeMangoDimz/go-social/internal/entities/search"
+	sessionsEntity "github.com/orangeMangoDimz/go-social/internal/entities/sessions"
+	tagsEntity "github.com/orangeMangoDimz/go-social/internal/entities/tags"
 	usersEntity "github.com/orangeMangoDimz/go-social/internal/entities/users"
 	"github.com/orangeMangoDimz/go-social/internal/storage"
+	"github.com/orangeMangoDimz/go-social/internal/storage/postgres/pagination"
 )
 
 func NewMockStore() storage.Storage {
 	return storage.Storage{
-		Users: &MockUserStore{},
+		Users:     &MockUserStore{},
+		Posts:     &MockPostStore{},
+		Comments:  &MockCommentStore{},
+		Sessions:  &MockSessionStore{},
+		Roles:     &MockRoleStore{},
+		Followers: &MockFollowerStore{},
+		Reactions: &MockReactionStore{},
+		Outbox:    &MockOutboxStore{},
+		Search:    &MockSearchStore{},
+		Tags:      &MockTagStore{},
+		Timelines: &MockTimelineStore{},
 	}
 }
 
@@ -30,14 +49,295 @@ func (m *MockUserStore) GetByEmail(ctx context.Context, userEmail string) (*user
 	return &usersEntity.User{}, nil
 }
 
-func (m *MockUserStore) CreateAndInvite(ctx context.Context, user *usersEntity.User, token string, invitationExp time.Duration) error {
+func (m *MockUserStore) CreateAndInvite(ctx context.Context, user *usersEntity.User, token string, invitationExp time.Duration, email *outboxEntity.Email) error {
 	return nil
 }
 
-func (m *MockUserStore) Activate(ctx context.Context, token string) error {
+func (m *MockUserStore) GetPendingByEmail(ctx context.Context, userEmail string) (*usersEntity.User, error) {
+	return &usersEntity.User{ID: 1, Email: userEmail}, nil
+}
+
+func (m *MockUserStore) ReissueInvitation(ctx context.Context, userID int64, token string, invitationExp, cooldown time.Duration, email *outboxEntity.Email) error {
 	return nil
 }
 
+func (m *MockUserStore) DeleteUnactivated(ctx context.Context, createdBefore time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (m *MockUserStore) PurgeExpiredInvitations(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
+func (m *MockUserStore) Activate(ctx context.Context, token string) (int64, error) {
+	return 1, nil
+}
+
 func (m *MockUserStore) Delete(ctx context.Context, userID int64) error {
 	return nil
 }
+
+func (m *MockUserStore) CreatePasswordReset(ctx context.Context, userID int64, token string, resetExp time.Duration, email *outboxEntity.Email) error {
+	return nil
+}
+
+func (m *MockUserStore) ResetPassword(ctx context.Context, token string, password *usersEntity.Password) ([]string, error) {
+	return []string{}, nil
+}
+
+type MockPostStore struct {
+}
+
+func (m *MockPostStore) GetById(ctx context.Context, postID int64) (*postsEntity.Post, error) {
+	return &postsEntity.Post{ID: postID}, nil
+}
+
+func (m *MockPostStore) Delete(ctx context.Context, postID int64) error {
+	return nil
+}
+
+func (m *MockPostStore) Create(ctx context.Context, post *postsEntity.Post) error {
+	return nil
+}
+
+func (m *MockPostStore) Update(ctx context.Context, post *postsEntity.Post) error {
+	return nil
+}
+
+func (m *MockPostStore) GetUserFeed(ctx context.Context, userID int64, fq pagination.PaginatedQuery) ([]postsEntity.Feed, error) {
+	return []postsEntity.Feed{}, nil
+}
+
+func (m *MockPostStore) GetUserPosts(ctx context.Context, userID int64, fq pagination.PaginatedQuery) ([]postsEntity.Feed, error) {
+	return []postsEntity.Feed{}, nil
+}
+
+type MockCommentStore struct {
+}
+
+func (m *MockCommentStore) Create(ctx context.Context, comment *commentsEntity.Comment) error {
+	return nil
+}
+
+func (m *MockCommentStore) GetByID(ctx context.Context, commentID int64) (*commentsEntity.Comment, error) {
+	return &commentsEntity.Comment{ID: commentID, PostID: 1}, nil
+}
+
+func (m *MockCommentStore) GetByPostID(ctx context.Context, postID int64, fq pagination.PaginatedQuery) ([]commentsEntity.Comment, error) {
+	return []commentsEntity.Comment{}, nil
+}
+
+func (m *MockCommentStore) GetReplies(ctx context.Context, commentID int64, fq pagination.PaginatedQuery) ([]commentsEntity.Comment, error) {
+	return []commentsEntity.Comment{}, nil
+}
+
+func (m *MockCommentStore) Update(ctx context.Context, comment *commentsEntity.Comment) error {
+	return nil
+}
+
+func (m *MockCommentStore) Delete(ctx context.Context, commentID int64) error {
+	return nil
+}
+
+type MockSessionStore struct {
+}
+
+func (m *MockSessionStore) Create(ctx context.Context, session *sessionsEntity.Session, token string, tokenExp time.Duration) error {
+	return nil
+}
+
+func (m *MockSessionStore) Rotate(ctx context.Context, token, newToken string, tokenExp time.Duration) (*sessionsEntity.Session, error) {
+	return &sessionsEntity.Session{}, nil
+}
+
+func (m *MockSessionStore) IsActive(ctx context.Context, sessionID string) (bool, error) {
+	return true, nil
+}
+
+func (m *MockSessionStore) Revoke(ctx context.Context, sessionID string) error {
+	return nil
+}
+
+// MockRoleStore knows the roles seeded by the migrations
+type MockRoleStore struct {
+}
+
+var mockRoleLevels = map[string]int64{"user": 1, "moderator": 2, "admin": 2}
+
+func (m *MockRoleStore) GetByName(ctx context.Context, name string) (*usersEntity.Role, error) {
+	level, ok := mockRoleLevels[name]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	return &usersEntity.Role{Name: name, Level: level}, nil
+}
+
+type MockFollowerStore struct {
+}
+
+func (m *MockFollowerStore) Follow(ctx context.Context, followedID, userID int64) error {
+	return nil
+}
+
+func (m *MockFollowerStore) Unfollow(ctx context.Context, followedID, userID int64) error {
+	return nil
+}
+
+func (m *MockFollowerStore) GetFollowers(ctx context.Context, userID int64, fq pagination.PaginatedQuery) ([]usersEntity.Connection, error) {
+	return []usersEntity.Connection{}, nil
+}
+
+func (m *MockFollowerStore) GetFollowing(ctx context.Context, userID int64, fq pagination.PaginatedQuery) ([]usersEntity.Connection, error) {
+	return []usersEntity.Connection{}, nil
+}
+
+func (m *MockFollowerStore) GetStats(ctx context.Context, userID, viewerID int64) (*usersEntity.ProfileStats, error) {
+	return &usersEntity.ProfileStats{}, nil
+}
+
+type MockReactionStore struct {
+}
+
+func (m *MockReactionStore) Add(ctx context.Context, reaction *reactionsEntity.Reaction) error {
+	return nil
+}
+
+func (m *MockReactionStore) Remove(ctx context.Context, postID, userID int64, kind string) error {
+	return nil
+}
+
+func (m *MockReactionStore) GetByPostID(ctx context.Context, postID int64, kind string, fq pagination.PaginatedQuery) ([]reactionsEntity.Reaction, error) {
+	return []reactionsEntity.Reaction{}, nil
+}
+
+func (m *MockReactionStore) GetSummaries(ctx context.Context, postIDs []int64, viewerID int64) (map[int64]*reactionsEntity.Summary, error) {
+	summaries := make(map[int64]*reactionsEntity.Summary, len(postIDs))
+	for _, id := range postIDs {
+		summaries[id] = reactionsEntity.NewSummary()
+	}
+	return summaries, nil
+}
+
+// MockOutboxStore keeps the queued emails in memory so the outbox worker can
+// run without a database.
+type MockOutboxStore struct {
+	sync.Mutex
+	Emails []*outboxEntity.Email
+}
+
+func (m *MockOutboxStore) Claim(ctx context.Context, limit int, lease time.Duration) ([]outboxEntity.Email, error) {
+	m.Lock()
+	defer m.Unlock()
+
+	now := time.Now()
+	emails := []outboxEntity.Email{}
+	for _, e := range m.Emails {
+		if len(emails) == limit {
+			break
+		}
+		if e.Status != outboxEntity.StatusPending || e.NextAttemptAt.After(now) {
+			continue
+		}
+		e.Attempts++
+		e.NextAttemptAt = now.Add(lease)
+		emails = append(emails, *e)
+	}
+	return emails, nil
+}
+
+func (m *MockOutboxStore) MarkSent(ctx context.Context, id int64) error {
+	return m.update(id, func(e *outboxEntity.Email) {
+		e.Status = outboxEntity.StatusSent
+		e.LastError = ""
+	})
+}
+
+func (m *MockOutboxStore) Retry(ctx context.Context, id int64, lastError string, nextAttemptAt time.Time) error {
+	return m.update(id, func(e *outboxEntity.Email) {
+		e.LastError = lastError
+		e.NextAttemptAt = nextAttemptAt
+	})
+}
+
+func (m *MockOutboxStore) MarkDead(ctx context.Context, id int64, lastError string) error {
+	return m.update(id, func(e *outboxEntity.Email) {
+		e.Status = outboxEntity.StatusDead
+		e.LastError = lastError
+	})
+}
+
+func (m *MockOutboxStore) update(id int64, fn func(*outboxEntity.Email)) error {
+	m.Lock()
+	defer m.Unlock()
+
+	for _, e := range m.Emails {
+		if e.ID == id {
+			fn(e)
+			return nil
+		}
+	}
+	return storage.ErrNotFound
+}
+
+// MockSearchStore finds a single document of the requested type
+type MockSearchStore struct{}
+
+func (m *MockSearchStore) Posts(ctx context.Context, q searchEntity.Query) ([]searchEntity.Result, error) {
+	return []searchEntity.Result{{Type: searchEntity.TypePosts, ID: 1}}, nil
+}
+
+func (m *MockSearchStore) Comments(ctx context.Context, q searchEntity.Query) ([]searchEntity.Result, error) {
+	return []searchEntity.Result{{Type: searchEntity.TypeComments, ID: 1}}, nil
+}
+
+func (m *MockSearchStore) Users(ctx context.Context, q searchEntity.Query) ([]searchEntity.Result, error) {
+	return []searchEntity.Result{{Type: searchEntity.TypeUsers, ID: 1}}, nil
+}
+
+type MockTagStore struct{}
+
+func (m *MockTagStore) GetPosts(ctx context.Context, tag string, fq pagination.PaginatedQuery) ([]postsEntity.Feed, error) {
+	return []postsEntity.Feed{}, nil
+}
+
+// GetTrending returns the tags in rank order, one more than the last
+func (m *MockTagStore) GetTrending(ctx context.Context, window time.Duration, limit int) ([]tagsEntity.TrendingTag, error) {
+	trending := []tagsEntity.TrendingTag{
+		{Tag: "golang", Authors: 3, Posts: 5},
+		{Tag: "rust", Authors: 2, Posts: 2},
+		{Tag: "zig", Authors: 1, Posts: 4},
+	}
+	return trending[:min(limit, len(trending))], nil
+}
+
+func (m *MockTagStore) GetStored(ctx context.Context, afterID int64, limit int) ([]tagsEntity.PostTags, error) {
+	return []tagsEntity.PostTags{}, nil
+}
+
+func (m *MockTagStore) Replace(ctx context.Context, postID int64, stored, tags []string) (bool, error) {
+	return true, nil
+}
+
+// MockTimelineStore has a single user, with two followers and following
+// someone who wrote the post 1
+type MockTimelineStore struct{}
+
+func (m *MockTimelineStore) GetFollowerIDs(ctx context.Context, userID int64, limit int) ([]int64, error) {
+	followers := []int64{2, 3}
+	return followers[:min(limit, len(followers))], nil
+}
+
+func (m *MockTimelineStore) GetPostIDs(ctx context.Context, userID int64, maxFollowers, limit int) ([]int64, error) {
+	return []int64{1}, nil
+}
+
+func (m *MockTimelineStore) GetUserIDs(ctx context.Context, afterID int64, limit int) ([]int64, error) {
+	if afterID > 0 {
+		return []int64{}, nil
+	}
+	return []int64{1}, nil
+}
+
+func (m *MockTimelineStore) GetFeed(ctx context.Context, userID int64, postIDs []int64, maxFollowers int, fq pagination.PaginatedQuery) ([]postsEntity.Feed, error) {
+	return []postsEntity.Feed{}, nil
+}
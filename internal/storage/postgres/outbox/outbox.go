@@ -0,0 +1,123 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	outboxEntity "github.com/orangeMangoDimz/go-social/internal/entities/outbox"
+	"github.com/orangeMangoDimz/go-social/internal/storage"
+)
+
+type OutboxStore struct {
+	Db *sql.DB
+}
+
+// Claim picks up to limit pending emails that are due and hides them from
+// other workers for the lease duration. Rows locked by a concurrent worker
+// are skipped instead of waited on. An email whose worker dies before
+// reporting back is picked up again once its lease runs out.
+func (s *OutboxStore) Claim(ctx context.Context, limit int, lease time.Duration) ([]outboxEntity.Email, error) {
+	query := `
+		UPDATE email_outbox
+		SET attempts = attempts + 1, next_attempt_at = NOW() + $2 * interval '1 millisecond'
+		WHERE id IN (
+			SELECT id
+			FROM email_outbox
+			WHERE status = 'pending' AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, template, locale, username, email, data, status, attempts, last_error, next_attempt_at, created_at
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, storage.QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := s.Db.QueryContext(ctx, query, limit, lease.Milliseconds())
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	emails := []outboxEntity.Email{}
+
+	for rows.Next() {
+		var e outboxEntity.Email
+		err := rows.Scan(
+			&e.ID,
+			&e.Template,
+			&e.Locale,
+			&e.Username,
+			&e.Email,
+			&e.Data,
+			&e.Status,
+			&e.Attempts,
+			&e.LastError,
+			&e.NextAttemptAt,
+			&e.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		emails = append(emails, e)
+	}
+	return emails, rows.Err()
+}
+
+// MarkSent records the delivery. The template data is dropped since it can
+// hold single-use links such as activation tokens.
+func (s *OutboxStore) MarkSent(ctx context.Context, id int64) error {
+	query := `
+		UPDATE email_outbox
+		SET status = 'sent', data = '{}', last_error = '', sent_at = NOW()
+		WHERE id = $1
+	`
+
+	return s.exec(ctx, query, id)
+}
+
+// Retry schedules another attempt at the given time.
+func (s *OutboxStore) Retry(ctx context.Context, id int64, lastError string, nextAttemptAt time.Time) error {
+	query := `
+		UPDATE email_outbox
+		SET last_error = $2, next_attempt_at = $3
+		WHERE id = $1
+	`
+
+	return s.exec(ctx, query, id, lastError, nextAttemptAt)
+}
+
+// MarkDead gives up on the email. It stays in the table for inspection.
+func (s *OutboxStore) MarkDead(ctx context.Context, id int64, lastError string) error {
+	query := `
+		UPDATE email_outbox
+		SET status = 'dead', last_error = $2
+		WHERE id = $1
+	`
+
+	return s.exec(ctx, query, id, lastError)
+}
+
+func (s *OutboxStore) exec(ctx context.Context, query string, args ...any) error {
+	ctx, cancel := context.WithTimeout(ctx, storage.QueryTimeoutDuration)
+	defer cancel()
+
+	res, err := s.Db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package pagination
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+var (
+	ErrInvalidCursor      = errors.New("invalid cursor")
+	ErrCursorSortMismatch = errors.New("cursor was issued for a different sort order")
+)
+
+// Cursor is the keyset position of the last row of a page, along with the
+// sort order it was read in. It is handed to clients as an opaque base64
+// string so the encoding can change freely.
+type Cursor struct {
+	CreatedAt string `json:"c"`
+	ID        int64  `json:"i"`
+	Sort      string `json:"s"`
+}
+
+func NewCursor(createdAt string, id int64, sort string) Cursor {
+	return Cursor{CreatedAt: createdAt, ID: id, Sort: sort}
+}
+
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(s string) (Cursor, error) {
+	var c Cursor
+
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, ErrInvalidCursor
+	}
+
+	if err := json.Unmarshal(data, &c); err != nil {
+		return c, ErrInvalidCursor
+	}
+
+	if c.CreatedAt == "" || c.ID <= 0 || (c.Sort != "asc" && c.Sort != "desc") {
+		return c, ErrInvalidCursor
+	}
+
+	return c, nil
+}
+
+// Keyset returns the comparison operator and the cursor position to use in a
+// "(created_at, id) <op> ($n, $m)" condition. Both values are NULL when the
+// query has no cursor.
+func (fq PaginatedQuery) Keyset() (string, sql.NullString, sql.NullInt64, error) {
+	op := "<"
+	if fq.Sort == "asc" {
+		op = ">"
+	}
+
+	if fq.Cursor == "" {
+		return op, sql.NullString{}, sql.NullInt64{}, nil
+	}
+
+	cursor, err := DecodeCursor(fq.Cursor)
+	if err != nil {
+		return op, sql.NullString{}, sql.NullInt64{}, err
+	}
+
+	if cursor.Sort != fq.Sort {
+		return op, sql.NullString{}, sql.NullInt64{}, ErrCursorSortMismatch
+	}
+
+	return op, sql.NullString{String: cursor.CreatedAt, Valid: true}, sql.NullInt64{Int64: cursor.ID, Valid: true}, nil
+}
+
+// NextCursor returns the cursor of the page after items, or "" when items is
+// not a full page and so the last one. key returns the keyset position of an
+// item.
+func NextCursor[T any](items []T, fq PaginatedQuery, key func(T) (string, int64)) string {
+	if len(items) < fq.Limit || len(items) == 0 {
+		return ""
+	}
+	createdAt, id := key(items[len(items)-1])
+	return NewCursor(createdAt, id, fq.Sort).Encode()
+}
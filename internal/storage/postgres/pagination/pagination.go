@@ -7,6 +7,7 @@ import (
 	"time"
 
 	paginationEntity "github.com/orangeMangoDimz/go-social/internal/entities/pagination"
+	tagsEntity "github.com/orangeMangoDimz/go-social/internal/entities/tags"
 )
 
 type PaginatedQuery paginationEntity.PaginatedQuery
@@ -39,7 +40,12 @@ func (fq PaginatedQuery) Parse(r *http.Request) (PaginatedQuery, error) {
 
 	tags := qs.Get("tags")
 	if tags != "" {
-		fq.Tags = strings.Split(tags, ",")
+		// match the tags as they were stored
+		normalized, err := tagsEntity.NormalizeAll(strings.Split(tags, ","))
+		if err != nil {
+			return fq, err
+		}
+		fq.Tags = normalized
 	}
 
 	search := qs.Get("search")
@@ -55,6 +61,21 @@ func (fq PaginatedQuery) Parse(r *http.Request) (PaginatedQuery, error) {
 		fq.Since = paginationEntity.InitSinceTime
 	}
 
+	cursor := qs.Get("cursor")
+	if cursor != "" {
+		c, err := DecodeCursor(cursor)
+		if err != nil {
+			return fq, err
+		}
+		// the keyset condition only holds in the order the cursor was read in
+		if c.Sort != fq.Sort {
+			return fq, ErrCursorSortMismatch
+		}
+		// keyset pages ignore the offset
+		fq.Cursor = cursor
+		fq.Offset = 0
+	}
+
 	until := qs.Get("until")
 	if until != "" {
 		fq.Until = parseTime(until)
@@ -8,6 +8,7 @@ import (
 	"github.com/lib/pq"
 	postsEntity "github.com/orangeMangoDimz/go-social/internal/entities/posts"
 	"github.com/orangeMangoDimz/go-social/internal/storage"
+	"github.com/orangeMangoDimz/go-social/internal/storage/postgres/feed"
 	"github.com/orangeMangoDimz/go-social/internal/storage/postgres/pagination"
 )
 
@@ -16,67 +17,17 @@ type PostStore struct {
 }
 
 func (s *PostStore) GetUserFeed(ctx context.Context, userID int64, fq pagination.PaginatedQuery) ([]postsEntity.Feed, error) {
-	query := `
-		SELECT
-			p.id, p.user_id, p.title, p.content, p.created_at, p.tags,
-			u.username,
-			COUNT(c.id) AS comments_count
-		FROM posts p
-		LEFT JOIN comments c ON c.post_id = p.id
-		LEFT JOIN users u ON p.user_id = u.id
-		JOIN followers f ON f.follower_id = p.user_id OR p.user_id = $1
-		WHERE 
-			(f.user_id = $1 OR p.user_id = $1) AND
-			(p.title ILIKE '%' || $4 || '%' OR p.content ILIKE '%' || $4 || '%') AND
-			(p.tags @> $5 OR $5 = '{}') AND
-			(p.created_at >= $6 AND p.created_at <= $7)
-		GROUP BY p.id, u.username
-		ORDER BY p.created_at ` + fq.Sort + `
-		LIMIT $2
-		OFFSET $3
-	`
-
-	ctx, cancel := context.WithTimeout(ctx, storage.QueryTimeoutDuration)
-	defer cancel()
-
-	rows, err := s.Db.QueryContext(
-		ctx,
-		query,
-		userID,
-		fq.Limit,
-		fq.Offset,
-		fq.Search,
-		pq.Array(fq.Tags),
-		fq.Since,
-		fq.Until,
-	)
-
-	if err != nil {
-		return nil, err
-	}
+	// the caller's own posts and those of the users they follow
+	cond := `p.user_id = $1 OR EXISTS (
+		SELECT 1 FROM followers f WHERE f.user_id = p.user_id AND f.follower_id = $1
+	)`
+	return feed.Query(ctx, s.Db, cond, []any{userID}, fq)
+}
 
-	defer rows.Close()
-
-	feeds := []postsEntity.Feed{}
-
-	for rows.Next() {
-		var f postsEntity.Feed
-		err := rows.Scan(
-			&f.ID,
-			&f.UserId,
-			&f.Title,
-			&f.Content,
-			&f.CreatedAt,
-			pq.Array(&f.Tags),
-			&f.User.Username,
-			&f.TotalComments,
-		)
-		if err != nil {
-			return nil, err
-		}
-		feeds = append(feeds, f)
-	}
-	return feeds, nil
+// GetUserPosts lists the posts written by a single user, with the same filters
+// as the feed.
+func (s *PostStore) GetUserPosts(ctx context.Context, userID int64, fq pagination.PaginatedQuery) ([]postsEntity.Feed, error) {
+	return feed.Query(ctx, s.Db, `p.user_id = $1`, []any{userID}, fq)
 }
 
 func (s *PostStore) Create(ctx context.Context, post *postsEntity.Post) error {
@@ -147,7 +98,7 @@ func (s *PostStore) GetById(ctx context.Context, postId int64) (*postsEntity.Pos
 func (s *PostStore) Update(ctx context.Context, post *postsEntity.Post) error {
 	query := `
 		UPDATE posts
-		SET title = $1, content = $2, version = version + 1 
+		SET title = $1, content = $2, tags = $5, tags_raw = NULL, version = version + 1
 		WHERE id = $3 AND version = $4
 		RETURNING version
 	`
@@ -162,6 +113,7 @@ func (s *PostStore) Update(ctx context.Context, post *postsEntity.Post) error {
 		post.Content,
 		post.ID,
 		post.Version,
+		pq.Array(post.Tags),
 	).Scan(&post.Version)
 
 	if err != nil {
@@ -0,0 +1,82 @@
+//go:build integration
+
+package posts
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	paginationEntity "github.com/orangeMangoDimz/go-social/internal/entities/pagination"
+	"github.com/orangeMangoDimz/go-social/internal/storage/postgres/pagination"
+	"github.com/orangeMangoDimz/go-social/internal/storage/postgres/pgtest"
+)
+
+func TestGetUserFeed(t *testing.T) {
+	db := pgtest.New(t)
+	store := &PostStore{Db: db}
+
+	viewer := pgtest.CreateUser(t, db, "viewer")
+	followed := pgtest.CreateUser(t, db, "followed")
+	follower := pgtest.CreateUser(t, db, "follower")
+	stranger := pgtest.CreateUser(t, db, "stranger")
+
+	fq := pagination.PaginatedQuery{
+		Limit: 20,
+		Sort:  "desc",
+		Since: paginationEntity.InitSinceTime,
+		Until: "2999-01-01 00:00:00",
+	}
+
+	feedIDs := func(t *testing.T) []int64 {
+		t.Helper()
+
+		feed, err := store.GetUserFeed(context.Background(), viewer, fq)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ids := []int64{}
+		for _, post := range feed {
+			ids = append(ids, post.ID)
+		}
+		return ids
+	}
+
+	t.Run("Should list the viewer's posts when they follow nobody", func(t *testing.T) {
+		own := pgtest.CreatePost(t, db, viewer, "own")
+
+		if got, want := feedIDs(t), []int64{own}; !reflect.DeepEqual(got, want) {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+	})
+
+	t.Run("Should list the followed users' posts and not the followers'", func(t *testing.T) {
+		pgtest.Follow(t, db, followed, viewer)
+		pgtest.Follow(t, db, viewer, follower)
+
+		fromFollowed := pgtest.CreatePost(t, db, followed, "followed")
+		pgtest.CreatePost(t, db, follower, "follower")
+		pgtest.CreatePost(t, db, stranger, "stranger")
+
+		got := feedIDs(t)
+		if len(got) != 2 || got[0] != fromFollowed {
+			t.Errorf("expected the viewer's post and %d, got %v", fromFollowed, got)
+		}
+	})
+
+	t.Run("Should count the comments once per post", func(t *testing.T) {
+		own := pgtest.CreatePost(t, db, viewer, "commented")
+		if _, err := db.Exec(`INSERT INTO comments (post_id, user_id, content) VALUES ($1, $2, 'first'), ($1, $3, 'second')`, own, followed, follower); err != nil {
+			t.Fatal(err)
+		}
+
+		feed, err := store.GetUserFeed(context.Background(), viewer, fq)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if feed[0].ID != own || feed[0].TotalComments != 2 {
+			t.Errorf("expected post %d with 2 comments, got post %d with %d", own, feed[0].ID, feed[0].TotalComments)
+		}
+	})
+}
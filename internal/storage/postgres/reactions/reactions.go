@@ -0,0 +1,178 @@
+package reactions
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+	reactionsEntity "github.com/orangeMangoDimz/go-social/internal/entities/reactions"
+	"github.com/orangeMangoDimz/go-social/internal/storage"
+	"github.com/orangeMangoDimz/go-social/internal/storage/postgres/pagination"
+)
+
+type ReactionStore struct {
+	Db *sql.DB
+}
+
+// Add records the reaction. Reacting twice with the same kind is a no-op
+// that keeps the original created_at.
+func (s *ReactionStore) Add(ctx context.Context, reaction *reactionsEntity.Reaction) error {
+	query := `
+		INSERT INTO reactions (post_id, user_id, kind)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (post_id, user_id, kind) DO UPDATE SET kind = EXCLUDED.kind
+		RETURNING created_at
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, storage.QueryTimeoutDuration)
+	defer cancel()
+
+	err := s.Db.QueryRowContext(
+		ctx,
+		query,
+		reaction.PostID,
+		reaction.UserID,
+		reaction.Kind,
+	).Scan(&reaction.CreatedAt)
+
+	if err != nil {
+		var pqErr *pq.Error
+		switch {
+		// the post was deleted in the meantime
+		case errors.As(err, &pqErr) && pqErr.Code == "23503":
+			return storage.ErrNotFound
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *ReactionStore) Remove(ctx context.Context, postID, userID int64, kind string) error {
+	query := `
+		DELETE FROM reactions
+		WHERE post_id = $1 AND user_id = $2 AND kind = $3
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, storage.QueryTimeoutDuration)
+	defer cancel()
+
+	res, err := s.Db.ExecContext(ctx, query, postID, userID, kind)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+// GetByPostID lists the users who reacted to postID with kind.
+func (s *ReactionStore) GetByPostID(ctx context.Context, postID int64, kind string, fq pagination.PaginatedQuery) ([]reactionsEntity.Reaction, error) {
+	keysetOp, cursorCreatedAt, cursorID, err := fq.Keyset()
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT r.post_id, r.user_id, u.username, r.kind, r.created_at
+		FROM reactions r
+		JOIN users u ON u.id = r.user_id
+		WHERE
+			r.post_id = $1 AND r.kind = $2 AND
+			($5::timestamptz IS NULL OR (r.created_at, r.user_id) ` + keysetOp + ` ($5::timestamptz, $6))
+		ORDER BY r.created_at ` + fq.Sort + `, r.user_id ` + fq.Sort + `
+		LIMIT $3
+		OFFSET $4
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, storage.QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := s.Db.QueryContext(
+		ctx,
+		query,
+		postID,
+		kind,
+		fq.Limit,
+		fq.Offset,
+		cursorCreatedAt,
+		cursorID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	reactions := []reactionsEntity.Reaction{}
+
+	for rows.Next() {
+		var r reactionsEntity.Reaction
+		if err := rows.Scan(&r.PostID, &r.UserID, &r.Username, &r.Kind, &r.CreatedAt); err != nil {
+			return nil, err
+		}
+		reactions = append(reactions, r)
+	}
+	return reactions, nil
+}
+
+// GetSummaries returns the reaction summary of every post in postIDs as seen
+// by viewerID. Posts without reactions get an empty summary.
+func (s *ReactionStore) GetSummaries(ctx context.Context, postIDs []int64, viewerID int64) (map[int64]*reactionsEntity.Summary, error) {
+	summaries := make(map[int64]*reactionsEntity.Summary, len(postIDs))
+	for _, id := range postIDs {
+		summaries[id] = reactionsEntity.NewSummary()
+	}
+
+	if len(postIDs) == 0 {
+		return summaries, nil
+	}
+
+	query := `
+		SELECT post_id, kind, COUNT(*), BOOL_OR(user_id = $2)
+		FROM reactions
+		WHERE post_id = ANY($1)
+		GROUP BY post_id, kind
+		ORDER BY post_id, kind
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, storage.QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := s.Db.QueryContext(ctx, query, pq.Array(postIDs), viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			postID  int64
+			kind    string
+			count   int64
+			reacted bool
+		)
+		if err := rows.Scan(&postID, &kind, &count, &reacted); err != nil {
+			return nil, err
+		}
+
+		summary := summaries[postID]
+		summary.Counts[kind] = count
+		summary.Total += count
+		if reacted {
+			summary.ViewerReactions = append(summary.ViewerReactions, kind)
+		}
+	}
+	return summaries, rows.Err()
+}
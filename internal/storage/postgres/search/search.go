@@ -0,0 +1,162 @@
+package search
+
+import (
+	"context"
+	"database/sql"
+	"html"
+	"strings"
+
+	searchEntity "github.com/orangeMangoDimz/go-social/internal/entities/search"
+	"github.com/orangeMangoDimz/go-social/internal/storage"
+)
+
+// ts_headline marks the matches with control characters rather than HTML so
+// the rest of the text can be escaped before the marks become <mark> tags.
+const (
+	startSel = "\x02"
+	stopSel  = "\x03"
+
+	titleHeadline   = `StartSel="` + startSel + `", StopSel="` + stopSel + `", HighlightAll=true`
+	snippetHeadline = `StartSel="` + startSel + `", StopSel="` + stopSel + `", MaxFragments=2, MinWords=10, MaxWords=30, FragmentDelimiter=" … "`
+)
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+type SearchStore struct {
+	Db *sql.DB
+}
+
+// Posts ranks the posts matching the query, titles weigh more than content.
+// Headlines are only computed for the requested page.
+func (s *SearchStore) Posts(ctx context.Context, q searchEntity.Query) ([]searchEntity.Result, error) {
+	query := `
+		SELECT
+			id, user_id, username, created_at, rank,
+			ts_headline('english', title, tsq, $2),
+			ts_headline('english', content, tsq, $3)
+		FROM (
+			SELECT
+				p.id, p.user_id, u.username, p.created_at, p.title, p.content, tsq,
+				ts_rank_cd(p.search_vector, tsq) AS rank
+			FROM posts p
+			JOIN users u ON u.id = p.user_id
+			CROSS JOIN websearch_to_tsquery('english', $1) tsq
+			WHERE p.search_vector @@ tsq
+			ORDER BY rank DESC, p.created_at DESC, p.id DESC
+			LIMIT $4
+			OFFSET $5
+		) hits
+		ORDER BY rank DESC, created_at DESC, id DESC
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, storage.QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := s.Db.QueryContext(ctx, query, q.Q, titleHeadline, snippetHeadline, q.Limit, q.Offset)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	results := []searchEntity.Result{}
+
+	for rows.Next() {
+		res := searchEntity.Result{Type: searchEntity.TypePosts}
+		if err := rows.Scan(&res.ID, &res.UserID, &res.Username, &res.CreatedAt, &res.Rank, &res.Title, &res.Snippet); err != nil {
+			return nil, err
+		}
+		res.Title = highlight(res.Title)
+		res.Snippet = highlight(res.Snippet)
+		results = append(results, res)
+	}
+	return results, rows.Err()
+}
+
+// Comments ranks the comments matching the query.
+func (s *SearchStore) Comments(ctx context.Context, q searchEntity.Query) ([]searchEntity.Result, error) {
+	query := `
+		SELECT
+			id, post_id, user_id, username, created_at, rank,
+			ts_headline('english', content, tsq, $2)
+		FROM (
+			SELECT
+				c.id, c.post_id, c.user_id, u.username, c.created_at, c.content, tsq,
+				ts_rank_cd(c.search_vector, tsq) AS rank
+			FROM comments c
+			JOIN users u ON u.id = c.user_id
+			CROSS JOIN websearch_to_tsquery('english', $1) tsq
+			WHERE c.search_vector @@ tsq
+			ORDER BY rank DESC, c.created_at DESC, c.id DESC
+			LIMIT $3
+			OFFSET $4
+		) hits
+		ORDER BY rank DESC, created_at DESC, id DESC
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, storage.QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := s.Db.QueryContext(ctx, query, q.Q, snippetHeadline, q.Limit, q.Offset)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	results := []searchEntity.Result{}
+
+	for rows.Next() {
+		res := searchEntity.Result{Type: searchEntity.TypeComments}
+		if err := rows.Scan(&res.ID, &res.PostID, &res.UserID, &res.Username, &res.CreatedAt, &res.Rank, &res.Snippet); err != nil {
+			return nil, err
+		}
+		res.Snippet = highlight(res.Snippet)
+		results = append(results, res)
+	}
+	return results, rows.Err()
+}
+
+// Users ranks the active users by trigram similarity of their username, so
+// typos still match. Usernames containing the query as is always match, even
+// when they are too short to be similar.
+func (s *SearchStore) Users(ctx context.Context, q searchEntity.Query) ([]searchEntity.Result, error) {
+	query := `
+		SELECT id, username, created_at, similarity(username, $1) AS rank
+		FROM users
+		WHERE is_active = true AND (username % $1 OR username ILIKE $2)
+		ORDER BY rank DESC, id ASC
+		LIMIT $3
+		OFFSET $4
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, storage.QueryTimeoutDuration)
+	defer cancel()
+
+	contains := "%" + likeEscaper.Replace(q.Q) + "%"
+	rows, err := s.Db.QueryContext(ctx, query, q.Q, contains, q.Limit, q.Offset)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	results := []searchEntity.Result{}
+
+	for rows.Next() {
+		res := searchEntity.Result{Type: searchEntity.TypeUsers}
+		if err := rows.Scan(&res.ID, &res.Username, &res.CreatedAt, &res.Rank); err != nil {
+			return nil, err
+		}
+		res.UserID = res.ID
+		results = append(results, res)
+	}
+	return results, rows.Err()
+}
+
+// highlight escapes the headline and turns the match markers into <mark>
+// tags, so clients can render snippets without trusting user content.
+func highlight(headline string) string {
+	escaped := html.EscapeString(headline)
+	return strings.NewReplacer(startSel, "<mark>", stopSel, "</mark>").Replace(escaped)
+}
@@ -0,0 +1,251 @@
+package sessions
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	sessionsEntity "github.com/orangeMangoDimz/go-social/internal/entities/sessions"
+	"github.com/orangeMangoDimz/go-social/internal/storage"
+)
+
+type SessionStore struct {
+	Db *sql.DB
+}
+
+type refreshToken struct {
+	session   sessionsEntity.Session
+	expiry    time.Time
+	rotatedAt sql.NullTime
+}
+
+func (s *SessionStore) Create(ctx context.Context, session *sessionsEntity.Session, token string, tokenExp time.Duration) error {
+	return storage.WithTx(s.Db, ctx, func(tx *sql.Tx) error {
+		// Create session
+		if err := s.create(ctx, tx, session); err != nil {
+			return err
+		}
+
+		// Create its first refresh token
+		if err := s.createRefreshToken(ctx, tx, token, tokenExp, session.ID); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+func (s *SessionStore) create(ctx context.Context, tx *sql.Tx, session *sessionsEntity.Session) error {
+	query := `
+		INSERT INTO user_sessions (id, user_id)
+		VALUES ($1, $2)
+		RETURNING created_at
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, storage.QueryTimeoutDuration)
+	defer cancel()
+
+	return tx.QueryRowContext(ctx, query, session.ID, session.UserID).Scan(&session.CreatedAt)
+}
+
+func (s *SessionStore) createRefreshToken(ctx context.Context, tx *sql.Tx, token string, tokenExp time.Duration, sessionID string) error {
+	query := `
+		INSERT INTO refresh_tokens (token, session_id, expiry)
+		VALUES ($1, $2, $3)
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, storage.QueryTimeoutDuration)
+	defer cancel()
+
+	_, err := tx.ExecContext(ctx, query, token, sessionID, time.Now().Add(tokenExp))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Rotate exchanges a refresh token for a new one within the same session.
+// Presenting a token that was already rotated means it leaked, so the whole
+// session is revoked and returned along with ErrTokenReused.
+func (s *SessionStore) Rotate(ctx context.Context, token, newToken string, tokenExp time.Duration) (*sessionsEntity.Session, error) {
+	var session *sessionsEntity.Session
+	reused := false
+
+	err := storage.WithTx(s.Db, ctx, func(tx *sql.Tx) error {
+		rt, err := s.getRefreshToken(ctx, tx, token)
+		if err != nil {
+			return err
+		}
+
+		if rt.session.RevokedAt != nil {
+			return storage.ErrSessionRevoked
+		}
+
+		// The revocation has to be committed, so it is reported after the transaction
+		if rt.rotatedAt.Valid {
+			reused = true
+			session = &rt.session
+			return s.revoke(ctx, tx, rt.session.ID)
+		}
+
+		if time.Now().After(rt.expiry) {
+			return storage.ErrNotFound
+		}
+
+		if err := s.markRotated(ctx, tx, token); err != nil {
+			return err
+		}
+
+		if err := s.createRefreshToken(ctx, tx, newToken, tokenExp, rt.session.ID); err != nil {
+			return err
+		}
+
+		session = &rt.session
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if reused {
+		return session, storage.ErrTokenReused
+	}
+
+	return session, nil
+}
+
+func (s *SessionStore) getRefreshToken(ctx context.Context, tx *sql.Tx, token string) (*refreshToken, error) {
+	query := `
+		SELECT us.id, us.user_id, us.created_at, us.revoked_at, rt.expiry, rt.rotated_at
+		FROM refresh_tokens rt
+		JOIN user_sessions us ON us.id = rt.session_id
+		WHERE rt.token = $1
+		FOR UPDATE
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, storage.QueryTimeoutDuration)
+	defer cancel()
+
+	rt := &refreshToken{}
+	var revokedAt sql.NullString
+	err := tx.QueryRowContext(ctx, query, token).Scan(
+		&rt.session.ID,
+		&rt.session.UserID,
+		&rt.session.CreatedAt,
+		&revokedAt,
+		&rt.expiry,
+		&rt.rotatedAt,
+	)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, storage.ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	if revokedAt.Valid {
+		rt.session.RevokedAt = &revokedAt.String
+	}
+
+	return rt, nil
+}
+
+func (s *SessionStore) markRotated(ctx context.Context, tx *sql.Tx, token string) error {
+	query := `
+		UPDATE refresh_tokens SET rotated_at = NOW() WHERE token = $1
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, storage.QueryTimeoutDuration)
+	defer cancel()
+
+	_, err := tx.ExecContext(ctx, query, token)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *SessionStore) IsActive(ctx context.Context, sessionID string) (bool, error) {
+	query := `
+		SELECT revoked_at IS NULL
+		FROM user_sessions
+		WHERE id = $1
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, storage.QueryTimeoutDuration)
+	defer cancel()
+
+	var active bool
+	err := s.Db.QueryRowContext(ctx, query, sessionID).Scan(&active)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return false, nil
+		default:
+			return false, err
+		}
+	}
+
+	return active, nil
+}
+
+func (s *SessionStore) Revoke(ctx context.Context, sessionID string) error {
+	return storage.WithTx(s.Db, ctx, func(tx *sql.Tx) error {
+		return s.revoke(ctx, tx, sessionID)
+	})
+}
+
+func (s *SessionStore) revoke(ctx context.Context, tx *sql.Tx, sessionID string) error {
+	query := `
+		UPDATE user_sessions SET revoked_at = NOW()
+		WHERE id = $1 AND revoked_at IS NULL
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, storage.QueryTimeoutDuration)
+	defer cancel()
+
+	_, err := tx.ExecContext(ctx, query, sessionID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RevokeAllByUserID signs the user out everywhere as part of tx, such as the
+// password reset, and returns the IDs of the sessions it revoked.
+func (s *SessionStore) RevokeAllByUserID(ctx context.Context, tx *sql.Tx, userID int64) ([]string, error) {
+	query := `
+		UPDATE user_sessions SET revoked_at = NOW()
+		WHERE user_id = $1 AND revoked_at IS NULL
+		RETURNING id
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, storage.QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := tx.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	sessionIDs := []string{}
+
+	for rows.Next() {
+		var sessionID string
+		if err := rows.Scan(&sessionID); err != nil {
+			return nil, err
+		}
+		sessionIDs = append(sessionIDs, sessionID)
+	}
+	return sessionIDs, rows.Err()
+}
@@ -6,17 +6,32 @@ import (
 	"github.com/orangeMangoDimz/go-social/internal/storage"
 	"github.com/orangeMangoDimz/go-social/internal/storage/postgres/comments"
 	"github.com/orangeMangoDimz/go-social/internal/storage/postgres/followers"
+	"github.com/orangeMangoDimz/go-social/internal/storage/postgres/outbox"
 	"github.com/orangeMangoDimz/go-social/internal/storage/postgres/posts"
+	"github.com/orangeMangoDimz/go-social/internal/storage/postgres/reactions"
 	"github.com/orangeMangoDimz/go-social/internal/storage/postgres/roles"
+	"github.com/orangeMangoDimz/go-social/internal/storage/postgres/search"
+	"github.com/orangeMangoDimz/go-social/internal/storage/postgres/sessions"
+	"github.com/orangeMangoDimz/go-social/internal/storage/postgres/tags"
+	"github.com/orangeMangoDimz/go-social/internal/storage/postgres/timelines"
 	"github.com/orangeMangoDimz/go-social/internal/storage/postgres/users"
 )
 
 func NewStore(db *sql.DB) storage.Storage {
+	// the password reset signs the user out within its own transaction
+	sessionStore := &sessions.SessionStore{Db: db}
+
 	return storage.Storage{
 		Posts:     &posts.PostStore{Db: db},
-		Users:     &users.UserStore{Db: db},
+		Users:     &users.UserStore{Db: db, Sessions: sessionStore},
 		Comments:  &comments.CommentStore{Db: db},
 		Followers: &followers.FollowerStore{Db: db},
 		Roles:     &roles.RoleStore{Db: db},
+		Sessions:  sessionStore,
+		Reactions: &reactions.ReactionStore{Db: db},
+		Outbox:    &outbox.OutboxStore{Db: db},
+		Search:    &search.SearchStore{Db: db},
+		Tags:      &tags.TagStore{Db: db},
+		Timelines: &timelines.TimelineStore{Db: db},
 	}
 }
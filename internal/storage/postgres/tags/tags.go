@@ -0,0 +1,118 @@
+package tags
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+	postsEntity "github.com/orangeMangoDimz/go-social/internal/entities/posts"
+	tagsEntity "github.com/orangeMangoDimz/go-social/internal/entities/tags"
+	"github.com/orangeMangoDimz/go-social/internal/storage"
+	"github.com/orangeMangoDimz/go-social/internal/storage/postgres/feed"
+	"github.com/orangeMangoDimz/go-social/internal/storage/postgres/pagination"
+)
+
+type TagStore struct {
+	Db *sql.DB
+}
+
+// GetPosts lists the posts of every user tagged with tag, newest first by
+// default.
+func (s *TagStore) GetPosts(ctx context.Context, tag string, fq pagination.PaginatedQuery) ([]postsEntity.Feed, error) {
+	return feed.Query(ctx, s.Db, `p.tags @> ARRAY[$1]::varchar(100)[]`, []any{tag}, fq)
+}
+
+// GetTrending ranks the tags of the posts created within window by the number
+// of distinct authors, so a single user posting in a loop cannot push a tag.
+func (s *TagStore) GetTrending(ctx context.Context, window time.Duration, limit int) ([]tagsEntity.TrendingTag, error) {
+	query := `
+		SELECT tag, COUNT(DISTINCT p.user_id) AS authors, COUNT(*) AS posts
+		FROM posts p
+		CROSS JOIN unnest(p.tags) AS tag
+		WHERE p.created_at >= NOW() - make_interval(secs => $1)
+		GROUP BY tag
+		ORDER BY authors DESC, posts DESC, tag ASC
+		LIMIT $2
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, storage.QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := s.Db.QueryContext(ctx, query, window.Seconds(), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	trending := []tagsEntity.TrendingTag{}
+
+	for rows.Next() {
+		var t tagsEntity.TrendingTag
+		if err := rows.Scan(&t.Tag, &t.Authors, &t.Posts); err != nil {
+			return nil, err
+		}
+		trending = append(trending, t)
+	}
+	return trending, rows.Err()
+}
+
+// GetStored lists the tags of up to limit posts with an ID greater than
+// afterID, in order.
+func (s *TagStore) GetStored(ctx context.Context, afterID int64, limit int) ([]tagsEntity.PostTags, error) {
+	query := `
+		SELECT id, tags
+		FROM posts
+		WHERE id > $1
+		ORDER BY id
+		LIMIT $2
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, storage.QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := s.Db.QueryContext(ctx, query, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	posts := []tagsEntity.PostTags{}
+
+	for rows.Next() {
+		var p tagsEntity.PostTags
+		if err := rows.Scan(&p.PostID, pq.Array(&p.Tags)); err != nil {
+			return nil, err
+		}
+		posts = append(posts, p)
+	}
+	return posts, rows.Err()
+}
+
+// Replace sets the tags of postID, keeping the ones it replaces in tags_raw so
+// the migration adding the column can restore them. Nothing is replaced and
+// false is returned when the post no longer has the stored tags, e.g. because
+// its author edited them meanwhile.
+func (s *TagStore) Replace(ctx context.Context, postID int64, stored, tags []string) (bool, error) {
+	query := `
+		UPDATE posts
+		SET tags_raw = COALESCE(tags_raw, tags), tags = $3
+		WHERE id = $1 AND tags IS NOT DISTINCT FROM $2
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, storage.QueryTimeoutDuration)
+	defer cancel()
+
+	res, err := s.Db.ExecContext(ctx, query, postID, pq.Array(stored), pq.Array(tags))
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows == 1, nil
+}
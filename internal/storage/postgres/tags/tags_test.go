@@ -0,0 +1,63 @@
+//go:build integration
+
+package tags
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/orangeMangoDimz/go-social/internal/storage/postgres/pgtest"
+)
+
+func TestReplace(t *testing.T) {
+	db := pgtest.New(t)
+	store := &TagStore{Db: db}
+	ctx := context.Background()
+
+	author := pgtest.CreateUser(t, db, "author")
+
+	tagsOf := func(t *testing.T, postID int64) (tags, raw []string) {
+		t.Helper()
+
+		err := db.QueryRow(`SELECT tags, tags_raw FROM posts WHERE id = $1`, postID).Scan(pq.Array(&tags), pq.Array(&raw))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tags, raw
+	}
+
+	t.Run("Should keep the replaced tags until the post is edited", func(t *testing.T) {
+		postID := pgtest.CreatePost(t, db, author, "stored")
+		stored := []string{"C++", "#Go"}
+		if _, err := db.Exec(`UPDATE posts SET tags = $2 WHERE id = $1`, postID, pq.Array(stored)); err != nil {
+			t.Fatal(err)
+		}
+
+		replaced, err := store.Replace(ctx, postID, stored, []string{"go"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !replaced {
+			t.Fatal("Expected the tags to be replaced")
+		}
+
+		tags, raw := tagsOf(t, postID)
+		if !reflect.DeepEqual(tags, []string{"go"}) || !reflect.DeepEqual(raw, stored) {
+			t.Errorf("Expected tags [go] and raw %v, got %v and %v", stored, tags, raw)
+		}
+	})
+
+	t.Run("Should not replace tags edited meanwhile", func(t *testing.T) {
+		postID := pgtest.CreatePost(t, db, author, "edited")
+
+		replaced, err := store.Replace(ctx, postID, []string{"#Go"}, []string{"go"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if replaced {
+			t.Error("Expected the edited tags to be kept")
+		}
+	})
+}
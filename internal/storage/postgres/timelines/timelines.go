@@ -0,0 +1,116 @@
+package timelines
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+	postsEntity "github.com/orangeMangoDimz/go-social/internal/entities/posts"
+	"github.com/orangeMangoDimz/go-social/internal/storage"
+	"github.com/orangeMangoDimz/go-social/internal/storage/postgres/feed"
+	"github.com/orangeMangoDimz/go-social/internal/storage/postgres/pagination"
+)
+
+type TimelineStore struct {
+	Db *sql.DB
+}
+
+// GetFollowerIDs lists up to limit users following userID.
+func (s *TimelineStore) GetFollowerIDs(ctx context.Context, userID int64, limit int) ([]int64, error) {
+	query := `
+		SELECT follower_id
+		FROM followers
+		WHERE user_id = $1
+		LIMIT $2
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, storage.QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := s.Db.QueryContext(ctx, query, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanIDs(rows)
+}
+
+// GetPostIDs lists the newest limit posts of the users followed by userID
+// that have at most maxFollowers followers, the ones fanned out on write.
+func (s *TimelineStore) GetPostIDs(ctx context.Context, userID int64, maxFollowers, limit int) ([]int64, error) {
+	query := `
+		WITH followed AS (
+			SELECT f.user_id
+			FROM followers f
+			JOIN users u ON u.id = f.user_id
+			WHERE f.follower_id = $1 AND u.follower_count <= $2
+		)
+		SELECT p.id
+		FROM posts p
+		JOIN followed ON followed.user_id = p.user_id
+		ORDER BY p.id DESC
+		LIMIT $3
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, storage.QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := s.Db.QueryContext(ctx, query, userID, maxFollowers, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanIDs(rows)
+}
+
+// GetUserIDs lists up to limit user IDs greater than afterID, in order.
+func (s *TimelineStore) GetUserIDs(ctx context.Context, afterID int64, limit int) ([]int64, error) {
+	query := `
+		SELECT id
+		FROM users
+		WHERE id > $1
+		ORDER BY id
+		LIMIT $2
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, storage.QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := s.Db.QueryContext(ctx, query, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanIDs(rows)
+}
+
+// GetFeed loads the feed of userID from the post IDs of their timeline, their
+// own posts and the posts of the followed users with more than maxFollowers
+// followers, which are not fanned out.
+func (s *TimelineStore) GetFeed(ctx context.Context, userID int64, postIDs []int64, maxFollowers int, fq pagination.PaginatedQuery) ([]postsEntity.Feed, error) {
+	cond := `
+		p.id = ANY($2) OR
+		p.user_id = $1 OR
+		p.user_id IN (
+			SELECT f.user_id
+			FROM followers f
+			JOIN users u ON u.id = f.user_id
+			WHERE f.follower_id = $1 AND u.follower_count > $3
+		)`
+	return feed.Query(ctx, s.Db, cond, []any{userID, pq.Array(postIDs), maxFollowers}, fq)
+}
+
+func scanIDs(rows *sql.Rows) ([]int64, error) {
+	defer rows.Close()
+
+	ids := []int64{}
+
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
@@ -0,0 +1,54 @@
+//go:build integration
+
+package timelines
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/orangeMangoDimz/go-social/internal/storage/postgres/pgtest"
+)
+
+func TestGetPostIDs(t *testing.T) {
+	db := pgtest.New(t)
+	store := &TimelineStore{Db: db}
+
+	viewer := pgtest.CreateUser(t, db, "viewer")
+	author := pgtest.CreateUser(t, db, "author")
+	celebrity := pgtest.CreateUser(t, db, "celebrity")
+	fan := pgtest.CreateUser(t, db, "fan")
+
+	pgtest.Follow(t, db, author, viewer)
+	pgtest.Follow(t, db, celebrity, viewer)
+	pgtest.Follow(t, db, celebrity, fan)
+
+	fromAuthor := pgtest.CreatePost(t, db, author, "author")
+	pgtest.CreatePost(t, db, celebrity, "celebrity")
+
+	postIDs := func(t *testing.T) []int64 {
+		t.Helper()
+
+		ids, err := store.GetPostIDs(context.Background(), viewer, 1, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ids
+	}
+
+	t.Run("Should leave out the users with more followers than the fan-out bound", func(t *testing.T) {
+		if got, want := postIDs(t), []int64{fromAuthor}; !reflect.DeepEqual(got, want) {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+	})
+
+	t.Run("Should count the followers as they unfollow", func(t *testing.T) {
+		if _, err := db.Exec(`DELETE FROM followers WHERE user_id = $1 AND follower_id = $2`, celebrity, fan); err != nil {
+			t.Fatal(err)
+		}
+
+		if got := postIDs(t); len(got) != 2 {
+			t.Errorf("expected the posts of both followed users, got %v", got)
+		}
+	})
+}
@@ -8,17 +8,20 @@ import (
 	"errors"
 	"time"
 
+	outboxEntity "github.com/orangeMangoDimz/go-social/internal/entities/outbox"
 	usersEntity "github.com/orangeMangoDimz/go-social/internal/entities/users"
 	"github.com/orangeMangoDimz/go-social/internal/storage"
+	"github.com/orangeMangoDimz/go-social/internal/storage/postgres/sessions"
 )
 
 type UserStore struct {
-	Db *sql.DB
+	Db       *sql.DB
+	Sessions *sessions.SessionStore
 }
 
 func (s *UserStore) GetById(ctx context.Context, userID int64) (*usersEntity.User, error) {
 	query := `
-		SELECT users.id, username, email, password, created_at, roles.*
+		SELECT users.id, username, email, password, created_at, language, roles.*
 		FROM users
 		JOIN roles ON users.role_id = roles.id
 		WHERE users.id = $1
@@ -38,6 +41,7 @@ func (s *UserStore) GetById(ctx context.Context, userID int64) (*usersEntity.Use
 		&user.Email,
 		&user.Password.Hash,
 		&user.CreatedAt,
+		&user.Language,
 		&user.Role.ID,
 		&user.Role.Name,
 		&user.Role.Level,
@@ -57,7 +61,7 @@ func (s *UserStore) GetById(ctx context.Context, userID int64) (*usersEntity.Use
 
 func (s *UserStore) GetByEmail(ctx context.Context, userEmail string) (*usersEntity.User, error) {
 	query := `
-		SELECT id, username, email, password, created_at
+		SELECT id, username, email, password, created_at, language
 		FROM users
 		WHERE email = $1 AND is_active = true
 	`
@@ -76,6 +80,7 @@ func (s *UserStore) GetByEmail(ctx context.Context, userEmail string) (*usersEnt
 		&user.Email,
 		&user.Password.Hash,
 		&user.CreatedAt,
+		&user.Language,
 	)
 
 	if err != nil {
@@ -91,8 +96,8 @@ func (s *UserStore) GetByEmail(ctx context.Context, userEmail string) (*usersEnt
 
 func (s *UserStore) Create(ctx context.Context, tx *sql.Tx, user *usersEntity.User) error {
 	query := `
-		INSERT INTO users (username, password, email, role_id) 
-		VALUES ($1, $2, $3, (SELECT id FROM roles WHERE name = $4)) 
+		INSERT INTO users (username, password, email, role_id, language) 
+		VALUES ($1, $2, $3, (SELECT id FROM roles WHERE name = $4), $5) 
 		RETURNING id, created_at
 	`
 
@@ -104,6 +109,11 @@ func (s *UserStore) Create(ctx context.Context, tx *sql.Tx, user *usersEntity.Us
 		role = "user"
 	}
 
+	language := user.Language
+	if language == "" {
+		language = "en"
+	}
+
 	err := tx.QueryRowContext(
 		ctx,
 		query,
@@ -111,6 +121,7 @@ func (s *UserStore) Create(ctx context.Context, tx *sql.Tx, user *usersEntity.Us
 		user.Password.Hash,
 		user.Email,
 		role,
+		language,
 	).Scan(&user.ID,
 		&user.CreatedAt,
 	)
@@ -129,7 +140,7 @@ func (s *UserStore) Create(ctx context.Context, tx *sql.Tx, user *usersEntity.Us
 	return nil
 }
 
-func (s *UserStore) CreateAndInvite(ctx context.Context, user *usersEntity.User, token string, invitationExp time.Duration) error {
+func (s *UserStore) CreateAndInvite(ctx context.Context, user *usersEntity.User, token string, invitationExp time.Duration, email *outboxEntity.Email) error {
 	return storage.WithTx(s.Db, ctx, func(tx *sql.Tx) error {
 		// Create user
 		if err := s.Create(ctx, tx, user); err != nil {
@@ -141,6 +152,11 @@ func (s *UserStore) CreateAndInvite(ctx context.Context, user *usersEntity.User,
 			return err
 		}
 
+		// Queue the invitation email, the outbox worker sends it once the user is committed
+		if err := s.createOutboxEmail(ctx, tx, email); err != nil {
+			return err
+		}
+
 		return nil
 
 	})
@@ -164,13 +180,184 @@ func (s *UserStore) createUserInvitation(ctx context.Context, tx *sql.Tx, token
 
 }
 
-func (s *UserStore) Activate(ctx context.Context, token string) error {
+func (s *UserStore) createOutboxEmail(ctx context.Context, tx *sql.Tx, email *outboxEntity.Email) error {
+	query := `
+		INSERT INTO email_outbox (template, locale, username, email, data)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, status, next_attempt_at, created_at
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, storage.QueryTimeoutDuration)
+	defer cancel()
+
+	return tx.QueryRowContext(
+		ctx,
+		query,
+		email.Template,
+		email.Locale,
+		email.Username,
+		email.Email,
+		email.Data,
+	).Scan(
+		&email.ID,
+		&email.Status,
+		&email.NextAttemptAt,
+		&email.CreatedAt,
+	)
+}
+
+// GetPendingByEmail returns the account registered with the email that has
+// not been activated yet.
+func (s *UserStore) GetPendingByEmail(ctx context.Context, userEmail string) (*usersEntity.User, error) {
+	query := `
+		SELECT id, username, email, created_at, language
+		FROM users
+		WHERE email = $1 AND is_active = false
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, storage.QueryTimeoutDuration)
+	defer cancel()
+
+	var user usersEntity.User
+	err := s.Db.QueryRowContext(
+		ctx,
+		query,
+		userEmail,
+	).Scan(
+		&user.ID,
+		&user.Username,
+		&user.Email,
+		&user.CreatedAt,
+		&user.Language,
+	)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, storage.ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+	return &user, nil
+}
+
+// ReissueInvitation replaces every invitation of the user with a new one and
+// queues its email. It returns storage.ErrInvitationCooldown when the last
+// invitation was issued less than cooldown ago, and storage.ErrNotFound when
+// the user has been activated in the meantime.
+func (s *UserStore) ReissueInvitation(ctx context.Context, userID int64, token string, invitationExp, cooldown time.Duration, email *outboxEntity.Email) error {
 	return storage.WithTx(s.Db, ctx, func(tx *sql.Tx) error {
+		// Lock the user so concurrent requests wait for each other's invitation
+		lastIssuedAt, err := s.lockPendingUser(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+
+		if lastIssuedAt.Valid && time.Since(lastIssuedAt.Time) < cooldown {
+			return storage.ErrInvitationCooldown
+		}
+
+		// Old tokens stop working
+		if err := s.deleteUserInvitation(ctx, tx, userID); err != nil {
+			return err
+		}
+
+		if err := s.createUserInvitation(ctx, tx, token, invitationExp, userID); err != nil {
+			return err
+		}
+
+		if err := s.createOutboxEmail(ctx, tx, email); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+// lockPendingUser locks the row of a user that is not activated yet and
+// returns when its latest invitation was issued.
+func (s *UserStore) lockPendingUser(ctx context.Context, tx *sql.Tx, userID int64) (sql.NullTime, error) {
+	query := `
+		SELECT (SELECT MAX(ui.created_at) FROM user_invitations ui WHERE ui.user_id = u.id)
+		FROM users u
+		WHERE u.id = $1 AND u.is_active = false
+		FOR UPDATE
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, storage.QueryTimeoutDuration)
+	defer cancel()
+
+	var lastIssuedAt sql.NullTime
+	err := tx.QueryRowContext(ctx, query, userID).Scan(&lastIssuedAt)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return lastIssuedAt, storage.ErrNotFound
+		default:
+			return lastIssuedAt, err
+		}
+	}
+
+	return lastIssuedAt, nil
+}
+
+// DeleteUnactivated deletes the accounts created before createdBefore that
+// were never activated and have no invitation left that could activate them.
+// Their username and email become available again.
+func (s *UserStore) DeleteUnactivated(ctx context.Context, createdBefore time.Time) (int64, error) {
+	query := `
+		DELETE FROM users u
+		WHERE
+			u.is_active = false AND
+			u.created_at < $1 AND
+			NOT EXISTS (SELECT 1 FROM user_invitations ui WHERE ui.user_id = u.id AND ui.expiry > NOW()) AND
+			NOT EXISTS (SELECT 1 FROM posts p WHERE p.user_id = u.id)
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, storage.QueryTimeoutDuration)
+	defer cancel()
+
+	res, err := s.Db.ExecContext(ctx, query, createdBefore)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// PurgeExpiredInvitations deletes the invitations that expired or whose user
+// no longer exists.
+func (s *UserStore) PurgeExpiredInvitations(ctx context.Context) (int64, error) {
+	query := `
+		DELETE FROM user_invitations ui
+		WHERE
+			ui.expiry <= NOW() OR
+			NOT EXISTS (SELECT 1 FROM users u WHERE u.id = ui.user_id)
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, storage.QueryTimeoutDuration)
+	defer cancel()
+
+	res, err := s.Db.ExecContext(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Activate activates the account the invitation token was sent for and
+// returns its ID.
+func (s *UserStore) Activate(ctx context.Context, token string) (int64, error) {
+	var userID int64
+	err := storage.WithTx(s.Db, ctx, func(tx *sql.Tx) error {
 		// Find the user that this token belongs to
 		user, err := s.getUserFromInvitation(ctx, tx, token)
 		if err != nil {
 			return err
 		}
+		userID = user.ID
 
 		// Update user
 		user.IsActive = true
@@ -186,6 +373,7 @@ func (s *UserStore) Activate(ctx context.Context, token string) error {
 		return nil
 
 	})
+	return userID, err
 }
 
 func (s *UserStore) getUserFromInvitation(ctx context.Context, tx *sql.Tx, token string) (*usersEntity.User, error) {
@@ -290,3 +478,124 @@ func (s *UserStore) delete(ctx context.Context, tx *sql.Tx, userID int64) error
 
 	return nil
 }
+
+func (s *UserStore) CreatePasswordReset(ctx context.Context, userID int64, token string, resetExp time.Duration, email *outboxEntity.Email) error {
+	return storage.WithTx(s.Db, ctx, func(tx *sql.Tx) error {
+		// Only the latest reset link stays valid
+		if err := s.deletePasswordResets(ctx, tx, userID); err != nil {
+			return err
+		}
+
+		query := `
+			INSERT INTO password_resets (token, user_id, expiry)
+			VALUES ($1, $2, $3)
+		`
+
+		ctx, cancel := context.WithTimeout(ctx, storage.QueryTimeoutDuration)
+		defer cancel()
+
+		_, err := tx.ExecContext(ctx, query, token, userID, time.Now().Add(resetExp))
+		if err != nil {
+			return err
+		}
+
+		// Queue the reset email, the outbox worker sends it once the token is committed
+		if err := s.createOutboxEmail(ctx, tx, email); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+// ResetPassword sets the password of the user the token was issued to and
+// returns the IDs of the sessions it revoked.
+func (s *UserStore) ResetPassword(ctx context.Context, token string, password *usersEntity.Password) ([]string, error) {
+	var sessionIDs []string
+
+	err := storage.WithTx(s.Db, ctx, func(tx *sql.Tx) error {
+		// Consume the token and find the user it belongs to, a concurrent
+		// reset with the same token waits for the row and then finds none
+		userID, err := s.consumePasswordReset(ctx, tx, token)
+		if err != nil {
+			return err
+		}
+
+		if err := s.updatePassword(ctx, tx, userID, password); err != nil {
+			return err
+		}
+
+		// Drop the other reset links of the user
+		if err := s.deletePasswordResets(ctx, tx, userID); err != nil {
+			return err
+		}
+
+		// Sign the user out everywhere
+		sessionIDs, err = s.Sessions.RevokeAllByUserID(ctx, tx, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sessionIDs, nil
+}
+
+func (s *UserStore) consumePasswordReset(ctx context.Context, tx *sql.Tx, token string) (int64, error) {
+	query := `
+		DELETE FROM password_resets
+		WHERE token = $1 AND expiry > $2
+		RETURNING user_id
+	`
+
+	hash := sha256.Sum256([]byte(token))
+	hashToken := hex.EncodeToString(hash[:])
+
+	ctx, cancel := context.WithTimeout(ctx, storage.QueryTimeoutDuration)
+	defer cancel()
+
+	var userID int64
+	err := tx.QueryRowContext(ctx, query, hashToken, time.Now()).Scan(&userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return 0, storage.ErrNotFound
+		default:
+			return 0, err
+		}
+	}
+
+	return userID, nil
+}
+
+func (s *UserStore) updatePassword(ctx context.Context, tx *sql.Tx, userID int64, password *usersEntity.Password) error {
+	query := `
+		UPDATE users SET password = $1 WHERE id = $2
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, storage.QueryTimeoutDuration)
+	defer cancel()
+
+	_, err := tx.ExecContext(ctx, query, password.Hash, userID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *UserStore) deletePasswordResets(ctx context.Context, tx *sql.Tx, userID int64) error {
+	query := `
+		DELETE FROM password_resets WHERE user_id = $1
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, storage.QueryTimeoutDuration)
+	defer cancel()
+
+	_, err := tx.ExecContext(ctx, query, userID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
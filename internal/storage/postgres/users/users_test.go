@@ -0,0 +1,63 @@
+//go:build integration
+
+package users
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"testing"
+
+	usersEntity "github.com/orangeMangoDimz/go-social/internal/entities/users"
+	"github.com/orangeMangoDimz/go-social/internal/storage"
+	"github.com/orangeMangoDimz/go-social/internal/storage/postgres/pgtest"
+	"github.com/orangeMangoDimz/go-social/internal/storage/postgres/sessions"
+)
+
+func TestResetPassword(t *testing.T) {
+	db := pgtest.New(t)
+	store := &UserStore{Db: db, Sessions: &sessions.SessionStore{Db: db}}
+	ctx := context.Background()
+
+	userID := pgtest.CreateUser(t, db, "forgetful")
+
+	t.Run("Should reset the password once for concurrent uses of a token", func(t *testing.T) {
+		hash := sha256.Sum256([]byte("token"))
+		if _, err := db.Exec(`
+			INSERT INTO password_resets (token, user_id, expiry)
+			VALUES ($1, $2, now() + interval '1 hour')
+		`, hex.EncodeToString(hash[:]), userID); err != nil {
+			t.Fatal(err)
+		}
+
+		var wg sync.WaitGroup
+		errs := make(chan error, 2)
+		for _, password := range []string{"first-password", "second-password"} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := store.ResetPassword(ctx, "token", &usersEntity.Password{Hash: []byte(password)})
+				errs <- err
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		var reset, notFound int
+		for err := range errs {
+			switch {
+			case err == nil:
+				reset++
+			case errors.Is(err, storage.ErrNotFound):
+				notFound++
+			default:
+				t.Fatal(err)
+			}
+		}
+		if reset != 1 || notFound != 1 {
+			t.Errorf("expected a single reset, got %d resets and %d rejected tokens", reset, notFound)
+		}
+	})
+}
@@ -7,17 +7,25 @@ import (
 	"time"
 
 	commentsEntity "github.com/orangeMangoDimz/go-social/internal/entities/comments"
+	outboxEntity "github.com/orangeMangoDimz/go-social/internal/entities/outbox"
 	postsEntity "github.com/orangeMangoDimz/go-social/internal/entities/posts"
+	reactionsEntity "github.com/orangeMangoDimz/go-social/internal/entities/reactions"
+	searchEntity "github.com/orangeMangoDimz/go-social/internal/entities/search"
+	sessionsEntity "github.com/orangeMangoDimz/go-social/internal/entities/sessions"
+	tagsEntity "github.com/orangeMangoDimz/go-social/internal/entities/tags"
 	usersEntity "github.com/orangeMangoDimz/go-social/internal/entities/users"
 	"github.com/orangeMangoDimz/go-social/internal/storage/postgres/pagination"
 )
 
 var (
-	ErrNotFound          = errors.New("RESOURCE NOT FOUND")
-	QueryTimeoutDuration = time.Second * 5
-	ErrUniqueViolation   = errors.New("DUPLICATE UNIQUE RECORDS")
-	ErrDuplicateEmail    = errors.New("a user with that email already exists")
-	ErrDuplicateUsername = errors.New("a user with that username already exists")
+	ErrNotFound           = errors.New("RESOURCE NOT FOUND")
+	QueryTimeoutDuration  = time.Second * 5
+	ErrUniqueViolation    = errors.New("DUPLICATE UNIQUE RECORDS")
+	ErrDuplicateEmail     = errors.New("a user with that email already exists")
+	ErrDuplicateUsername  = errors.New("a user with that username already exists")
+	ErrSessionRevoked     = errors.New("session has been revoked")
+	ErrTokenReused        = errors.New("refresh token has already been used")
+	ErrInvitationCooldown = errors.New("an invitation was sent recently")
 )
 
 type Storage struct {
@@ -26,15 +34,27 @@ type Storage struct {
 	Comments  CommentsRepository
 	Followers FollowersRepository
 	Roles     RolesRepository
+	Sessions  SessionsRepository
+	Reactions ReactionsRepository
+	Outbox    OutboxRepository
+	Search    SearchRepository
+	Tags      TagsRepository
+	Timelines TimelinesRepository
 }
 
 type UsersRepository interface {
 	GetById(context.Context, int64) (*usersEntity.User, error)
 	GetByEmail(context.Context, string) (*usersEntity.User, error)
 	Create(context.Context, *sql.Tx, *usersEntity.User) error
-	CreateAndInvite(context.Context, *usersEntity.User, string, time.Duration) error
-	Activate(context.Context, string) error
+	CreateAndInvite(context.Context, *usersEntity.User, string, time.Duration, *outboxEntity.Email) error
+	GetPendingByEmail(context.Context, string) (*usersEntity.User, error)
+	ReissueInvitation(context.Context, int64, string, time.Duration, time.Duration, *outboxEntity.Email) error
+	DeleteUnactivated(context.Context, time.Time) (int64, error)
+	PurgeExpiredInvitations(context.Context) (int64, error)
+	Activate(context.Context, string) (int64, error)
 	Delete(context.Context, int64) error
+	CreatePasswordReset(context.Context, int64, string, time.Duration, *outboxEntity.Email) error
+	ResetPassword(context.Context, string, *usersEntity.Password) ([]string, error)
 }
 
 type PostsRepository interface {
@@ -43,16 +63,65 @@ type PostsRepository interface {
 	Create(context.Context, *postsEntity.Post) error
 	Update(context.Context, *postsEntity.Post) error
 	GetUserFeed(context.Context, int64, pagination.PaginatedQuery) ([]postsEntity.Feed, error)
+	GetUserPosts(context.Context, int64, pagination.PaginatedQuery) ([]postsEntity.Feed, error)
 }
 
 type CommentsRepository interface {
 	Create(context.Context, *commentsEntity.Comment) error
-	GetByPostID(context.Context, int64) ([]commentsEntity.Comment, error)
+	GetByID(context.Context, int64) (*commentsEntity.Comment, error)
+	GetByPostID(context.Context, int64, pagination.PaginatedQuery) ([]commentsEntity.Comment, error)
+	GetReplies(context.Context, int64, pagination.PaginatedQuery) ([]commentsEntity.Comment, error)
+	Update(context.Context, *commentsEntity.Comment) error
+	Delete(context.Context, int64) error
 }
 
 type FollowersRepository interface {
 	Follow(ctx context.Context, followedID, userID int64) error
 	Unfollow(ctx context.Context, followedID, userID int64) error
+	GetFollowers(context.Context, int64, pagination.PaginatedQuery) ([]usersEntity.Connection, error)
+	GetFollowing(context.Context, int64, pagination.PaginatedQuery) ([]usersEntity.Connection, error)
+	GetStats(ctx context.Context, userID, viewerID int64) (*usersEntity.ProfileStats, error)
+}
+
+type ReactionsRepository interface {
+	Add(context.Context, *reactionsEntity.Reaction) error
+	Remove(ctx context.Context, postID, userID int64, kind string) error
+	GetByPostID(context.Context, int64, string, pagination.PaginatedQuery) ([]reactionsEntity.Reaction, error)
+	GetSummaries(ctx context.Context, postIDs []int64, viewerID int64) (map[int64]*reactionsEntity.Summary, error)
+}
+
+type TimelinesRepository interface {
+	GetFollowerIDs(ctx context.Context, userID int64, limit int) ([]int64, error)
+	GetPostIDs(ctx context.Context, userID int64, maxFollowers, limit int) ([]int64, error)
+	GetUserIDs(ctx context.Context, afterID int64, limit int) ([]int64, error)
+	GetFeed(ctx context.Context, userID int64, postIDs []int64, maxFollowers int, fq pagination.PaginatedQuery) ([]postsEntity.Feed, error)
+}
+
+type TagsRepository interface {
+	GetPosts(context.Context, string, pagination.PaginatedQuery) ([]postsEntity.Feed, error)
+	GetTrending(ctx context.Context, window time.Duration, limit int) ([]tagsEntity.TrendingTag, error)
+	GetStored(ctx context.Context, afterID int64, limit int) ([]tagsEntity.PostTags, error)
+	Replace(ctx context.Context, postID int64, stored, tags []string) (bool, error)
+}
+
+type SearchRepository interface {
+	Posts(context.Context, searchEntity.Query) ([]searchEntity.Result, error)
+	Comments(context.Context, searchEntity.Query) ([]searchEntity.Result, error)
+	Users(context.Context, searchEntity.Query) ([]searchEntity.Result, error)
+}
+
+type OutboxRepository interface {
+	Claim(context.Context, int, time.Duration) ([]outboxEntity.Email, error)
+	MarkSent(context.Context, int64) error
+	Retry(context.Context, int64, string, time.Time) error
+	MarkDead(context.Context, int64, string) error
+}
+
+type SessionsRepository interface {
+	Create(context.Context, *sessionsEntity.Session, string, time.Duration) error
+	Rotate(context.Context, string, string, time.Duration) (*sessionsEntity.Session, error)
+	IsActive(context.Context, string) (bool, error)
+	Revoke(context.Context, string) error
 }
 
 type RolesRepository interface {
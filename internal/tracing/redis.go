@@ -0,0 +1,87 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RedisHook traces the commands sent by a go-redis client. Add it with
+// AddHook.
+type RedisHook struct{}
+
+func (RedisHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (RedisHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		ctx, span := startRedis(ctx, cmd.Name(), 1)
+		err := next(ctx, cmd)
+		endRedis(span, err)
+		return err
+	}
+}
+
+func (RedisHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		ctx, span := startRedis(ctx, "pipeline", len(cmds))
+		span.SetAttributes(attribute.StringSlice(redisCommandsKey, pipelineCommands(cmds)))
+		err := next(ctx, cmds)
+		endRedis(span, err)
+		return err
+	}
+}
+
+// redisCommandsKey lists the distinct commands of a pipeline span.
+const redisCommandsKey = "db.redis.commands"
+
+// maxPipelineCommands bounds the number of command names recorded on a
+// pipeline span, so a large batch does not produce a large attribute.
+const maxPipelineCommands = 10
+
+// pipelineCommands returns the distinct command names of cmds in the order
+// they are first sent, with "..." in place of the names past the bound.
+func pipelineCommands(cmds []redis.Cmder) []string {
+	names := []string{}
+	seen := map[string]bool{}
+	for _, cmd := range cmds {
+		name := cmd.Name()
+		if seen[name] {
+			continue
+		}
+		if len(names) == maxPipelineCommands {
+			return append(names, "...")
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names
+}
+
+func startRedis(ctx context.Context, operation string, batchSize int) (context.Context, trace.Span) {
+	attrs := []attribute.KeyValue{
+		semconv.DBSystemNameRedis,
+		semconv.DBOperationName(operation),
+	}
+	if batchSize > 1 {
+		attrs = append(attrs, semconv.DBOperationBatchSize(batchSize))
+	}
+
+	return Start(ctx, "redis "+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+}
+
+// endRedis ends the span. A missing key is an answer, not a failure.
+func endRedis(span trace.Span, err error) {
+	if errors.Is(err, redis.Nil) {
+		err = nil
+	}
+	End(span, err)
+}
@@ -0,0 +1,217 @@
+package tracing
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"runtime"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const statementFramesDepth = 32
+
+// NewSQLConnector wraps a Postgres connector so every query and exec gets a
+// client span carrying the SQL, the number of rows returned or affected, and
+// the name of the statement.
+//
+// The statement is named after the innermost function of a package under
+// storePrefix that issued it, for instance "users.UserStore.GetById". That
+// way every repository method is traced without touching the stores.
+func NewSQLConnector(connector driver.Connector, storePrefix string) driver.Connector {
+	return &sqlConnector{Connector: connector, storePrefix: storePrefix}
+}
+
+type sqlConnector struct {
+	driver.Connector
+	storePrefix string
+}
+
+func (c *sqlConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &sqlConn{Conn: conn, storePrefix: c.storePrefix}, nil
+}
+
+// sqlConn forwards the optional interfaces of the wrapped connection that
+// database/sql relies on, so the pool behaves exactly as without tracing.
+type sqlConn struct {
+	driver.Conn
+	storePrefix string
+}
+
+func (c *sqlConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	ctx, span := c.start(ctx, query)
+	rows, err := queryer.QueryContext(ctx, query, args)
+	if err != nil {
+		End(span, err)
+		return nil, err
+	}
+
+	// the span lasts until the rows have been read
+	return &sqlRows{Rows: rows, span: span}, nil
+}
+
+func (c *sqlConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	ctx, span := c.start(ctx, query)
+	res, err := execer.ExecContext(ctx, query, args)
+	if err == nil {
+		if n, err := res.RowsAffected(); err == nil {
+			span.SetAttributes(attribute.Int64("db.response.affected_rows", n))
+		}
+	}
+	End(span, err)
+
+	return res, err
+}
+
+func (c *sqlConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		return preparer.PrepareContext(ctx, query)
+	}
+	return c.Conn.Prepare(query)
+}
+
+func (c *sqlConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin()
+}
+
+func (c *sqlConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *sqlConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *sqlConn) IsValid() bool {
+	if validator, ok := c.Conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+	return true
+}
+
+func (c *sqlConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if checker, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+func (c *sqlConn) start(ctx context.Context, query string) (context.Context, trace.Span) {
+	operation := sqlOperation(query)
+
+	name := c.statementName()
+	if name == "" {
+		name = operation
+	}
+
+	return Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemNamePostgreSQL,
+			semconv.DBOperationName(operation),
+			semconv.DBQueryText(strings.TrimSpace(query)),
+			attribute.String("db.statement.name", name),
+		),
+	)
+}
+
+// statementName names the statement after the store method that issued it.
+func (c *sqlConn) statementName() string {
+	pcs := make([]uintptr, statementFramesDepth)
+	// skip runtime.Callers, statementName, start and the Query or Exec method
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(4, pcs)])
+
+	for {
+		frame, more := frames.Next()
+		if strings.HasPrefix(frame.Function, c.storePrefix) {
+			return cleanFunctionName(frame.Function)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// cleanFunctionName turns "github.com/x/postgres/users.(*UserStore).Create.func1"
+// into "users.UserStore.Create".
+func cleanFunctionName(fn string) string {
+	if i := strings.LastIndex(fn, "/"); i >= 0 {
+		fn = fn[i+1:]
+	}
+	fn = strings.NewReplacer("(*", "", ")", "").Replace(fn)
+
+	// drop the closures, such as the body of a transaction
+	parts := strings.Split(fn, ".")
+	for len(parts) > 1 && isClosure(parts[len(parts)-1]) {
+		parts = parts[:len(parts)-1]
+	}
+	return strings.Join(parts, ".")
+}
+
+// isClosure reports whether part names an anonymous function, such as
+// "func1", or one nested in it, such as the "2" of "func1.2".
+func isClosure(part string) bool {
+	return strings.HasPrefix(part, "func") || strings.Trim(part, "0123456789") == ""
+}
+
+// sqlOperation returns the first keyword of the query, such as SELECT.
+func sqlOperation(query string) string {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return "QUERY"
+	}
+	return strings.ToUpper(fields[0])
+}
+
+// sqlRows counts the rows read and ends the span once they are closed.
+type sqlRows struct {
+	driver.Rows
+	span  trace.Span
+	count int
+	err   error
+}
+
+func (r *sqlRows) Next(dest []driver.Value) error {
+	err := r.Rows.Next(dest)
+	switch {
+	case err == nil:
+		r.count++
+	case !errors.Is(err, io.EOF):
+		r.err = err
+	}
+	return err
+}
+
+func (r *sqlRows) Close() error {
+	err := r.Rows.Close()
+	r.span.SetAttributes(semconv.DBResponseReturnedRows(r.count))
+	End(r.span, r.err)
+	return err
+}
@@ -0,0 +1,71 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/orangeMangoDimz/go-social/internal/config"
+	"github.com/orangeMangoDimz/go-social/internal/storage"
+	"github.com/orangeMangoDimz/go-social/internal/tracing"
+	"go.uber.org/zap"
+)
+
+// InvitationPurger periodically deletes the expired invitations and the
+// accounts that were never activated, so their username and email can be
+// registered again.
+type InvitationPurger struct {
+	repository storage.UsersRepository
+	config     config.InvitationsConfig
+	logger     *zap.SugaredLogger
+}
+
+func NewInvitationPurger(repository storage.UsersRepository, cfg config.Config, logger *zap.SugaredLogger) *InvitationPurger {
+	return &InvitationPurger{
+		repository: repository,
+		config:     cfg.Invitations,
+		logger:     logger,
+	}
+}
+
+// Run purges right away and then on every interval until ctx is cancelled.
+func (p *InvitationPurger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.config.PurgeInterval)
+	defer ticker.Stop()
+
+	p.logger.Infow("invitation purger has started", "interval", p.config.PurgeInterval.String(), "max_unactivated_age", p.config.MaxUnactivatedAge.String())
+
+	for {
+		if err := p.Purge(ctx); err != nil && ctx.Err() == nil {
+			p.logger.Errorw("failed to purge invitations", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			p.logger.Info("invitation purger stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Purge deletes the accounts older than the configured age that were never
+// activated, then every invitation that can no longer be used.
+func (p *InvitationPurger) Purge(ctx context.Context) (err error) {
+	ctx, span := tracing.Start(ctx, "InvitationPurger.Purge")
+	defer func() { tracing.End(span, err) }()
+
+	users, err := p.repository.DeleteUnactivated(ctx, time.Now().Add(-p.config.MaxUnactivatedAge))
+	if err != nil {
+		return err
+	}
+
+	invitations, err := p.repository.PurgeExpiredInvitations(ctx)
+	if err != nil {
+		return err
+	}
+
+	if users > 0 || invitations > 0 {
+		p.logger.Infow("purged invitations", "users", users, "invitations", invitations)
+	}
+	return nil
+}
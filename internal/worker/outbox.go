@@ -0,0 +1,151 @@
+// Package worker contains the background jobs that run next to the HTTP
+// server, such as delivering the emails queued in the outbox.
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand/v2"
+	"time"
+
+	"github.com/orangeMangoDimz/go-social/internal/config"
+	outboxEntity "github.com/orangeMangoDimz/go-social/internal/entities/outbox"
+	"github.com/orangeMangoDimz/go-social/internal/mailer"
+	"github.com/orangeMangoDimz/go-social/internal/storage"
+	"github.com/orangeMangoDimz/go-social/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// OutboxWorker delivers the emails queued in the outbox. Several workers can
+// poll the same table, each email is claimed by a single one of them.
+//
+// A failed delivery is retried with an exponential backoff and jitter. Once
+// an email has used up its attempts it is moved to the dead state and left
+// for an operator to look at.
+type OutboxWorker struct {
+	repository storage.OutboxRepository
+	mailer     mailer.Client
+	config     config.OutboxConfig
+	isSandbox  bool
+	logger     *zap.SugaredLogger
+}
+
+func NewOutboxWorker(repository storage.OutboxRepository, mailer mailer.Client, cfg config.Config, logger *zap.SugaredLogger) *OutboxWorker {
+	return &OutboxWorker{
+		repository: repository,
+		mailer:     mailer,
+		config:     cfg.Outbox,
+		isSandbox:  cfg.Env != "production",
+		logger:     logger,
+	}
+}
+
+// Run polls the outbox until ctx is cancelled. Full batches are processed
+// back to back so a backlog drains without waiting for the next tick.
+func (w *OutboxWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.config.PollInterval)
+	defer ticker.Stop()
+
+	w.logger.Infow("outbox worker has started", "poll_interval", w.config.PollInterval.String())
+
+	for {
+		for ctx.Err() == nil {
+			n, err := w.ProcessBatch(ctx)
+			if err != nil {
+				w.logger.Errorw("failed to claim outbox emails", "error", err)
+				break
+			}
+			if n < w.config.BatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			w.logger.Info("outbox worker stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessBatch claims the next due emails and tries to deliver each of them.
+// It returns how many emails were claimed.
+func (w *OutboxWorker) ProcessBatch(ctx context.Context) (int, error) {
+	emails, err := w.repository.Claim(ctx, w.config.BatchSize, w.config.Lease)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range emails {
+		// the rest of the batch is picked up again once its lease runs out
+		if ctx.Err() != nil {
+			break
+		}
+		w.deliver(ctx, &emails[i])
+	}
+
+	return len(emails), nil
+}
+
+func (w *OutboxWorker) deliver(ctx context.Context, email *outboxEntity.Email) {
+	// record the outcome even when shutting down, so a sent email is not sent twice
+	ctx = context.WithoutCancel(ctx)
+
+	ctx, span := tracing.Start(ctx, "OutboxWorker.deliver", trace.WithAttributes(
+		attribute.Int64("outbox.email_id", email.ID),
+		attribute.Int("outbox.attempt", email.Attempts),
+	))
+	defer span.End()
+
+	var data map[string]any
+	if err := json.Unmarshal(email.Data, &data); err != nil {
+		// retrying will not fix the payload
+		w.bury(ctx, email, err)
+		return
+	}
+
+	status, err := w.mailer.Send(ctx, email.Template, email.Locale, email.Username, email.Email, data, w.isSandbox)
+	if err != nil {
+		if email.Attempts >= w.config.MaxAttempts {
+			w.bury(ctx, email, err)
+			return
+		}
+
+		next := time.Now().Add(backoff(email.Attempts, w.config.BaseBackoff, w.config.MaxBackoff))
+		w.logger.Warnw("error sending outbox email, will retry", "email_id", email.ID, "attempt", email.Attempts, "next_attempt_at", next, "error", err)
+		if err := w.repository.Retry(ctx, email.ID, err.Error(), next); err != nil {
+			w.logger.Errorw("failed to reschedule outbox email", "email_id", email.ID, "error", err)
+		}
+		return
+	}
+
+	w.logger.Infow("Email sent", "email_id", email.ID, "template", email.Template, "status code", status)
+	if err := w.repository.MarkSent(ctx, email.ID); err != nil {
+		w.logger.Errorw("failed to mark outbox email as sent", "email_id", email.ID, "error", err)
+	}
+}
+
+func (w *OutboxWorker) bury(ctx context.Context, email *outboxEntity.Email, cause error) {
+	w.logger.Errorw("giving up on outbox email", "email_id", email.ID, "template", email.Template, "attempts", email.Attempts, "error", cause)
+	if err := w.repository.MarkDead(ctx, email.ID, cause.Error()); err != nil {
+		w.logger.Errorw("failed to move outbox email to dead letter", "email_id", email.ID, "error", err)
+	}
+}
+
+// backoff doubles the delay with every attempt up to maxDelay, then picks a
+// random point in its upper half so emails that failed together do not retry
+// in lockstep.
+func backoff(attempt int, base, maxDelay time.Duration) time.Duration {
+	delay := maxDelay
+	if attempt > 0 && attempt < 32 {
+		if d := base << (attempt - 1); d > 0 && d < maxDelay {
+			delay = d
+		}
+	}
+
+	half := delay / 2
+	return half + rand.N(half+1)
+}